@@ -3,8 +3,11 @@ package main
 import (
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/manhhung2111/go-idm/internal/config"
+	go_idm_v1 "github.com/manhhung2111/go-idm/internal/generated/proto"
+	"github.com/manhhung2111/go-idm/internal/logic"
 	"github.com/manhhung2111/go-idm/internal/wiring"
 	"github.com/spf13/cobra"
 )
@@ -16,12 +19,32 @@ var (
 
 const (
 	flagConfigFilePath = "config-file-path"
+	flagTopic          = "topic"
+	flagLimit          = "limit"
+	flagPartition      = "partition"
+	flagOffset         = "offset"
+	flagSteps          = "steps"
+	flagMismatchFix    = "mismatch-fix"
+	flagDeleteOrphans  = "delete-orphans"
+	flagSkipChecksum   = "skip-checksum"
+	flagFrom           = "from"
+	flagTo             = "to"
+	flagConcurrency    = "concurrency"
+	flagDeleteSource   = "delete-source"
 )
 
+// mismatchFixFlagValues maps the values of the mismatch-fix flag to the fix they apply.
+var mismatchFixFlagValues = map[string]go_idm_v1.DownloadFileMismatchFix{
+	"none":        go_idm_v1.DownloadFileMismatchFix_UndefinedDownloadFileMismatchFix,
+	"mark-failed": go_idm_v1.DownloadFileMismatchFix_MarkFailed,
+	"reenqueue":   go_idm_v1.DownloadFileMismatchFix_Reenqueue,
+}
+
 
 func server() *cobra.Command {
 	command := &cobra.Command{
-		Use: "server",
+		Use:   "server",
+		Short: "Run the APIs and the download worker in a single process",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			configFilePath, err := cmd.Flags().GetString(flagConfigFilePath)
 			if err != nil {
@@ -44,12 +67,499 @@ func server() *cobra.Command {
 	return command
 }
 
+func api() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "api",
+		Short: "Run the gRPC and HTTP APIs only, leaving the download tasks to the workers",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configFilePath, err := cmd.Flags().GetString(flagConfigFilePath)
+			if err != nil {
+				return err
+			}
+
+			apiServer, cleanup, err := wiring.InitializeAPIServer(config.ConfigFilePath(configFilePath))
+			if err != nil {
+				return err
+			}
+
+			defer cleanup()
+
+			return apiServer.Start()
+		},
+	}
+
+	command.Flags().String(flagConfigFilePath, "", "If provided, will use the provided config file.")
+
+	return command
+}
+
+func worker() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "worker",
+		Short: "Run the message queue consumer executing the download tasks, without serving any API",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configFilePath, err := cmd.Flags().GetString(flagConfigFilePath)
+			if err != nil {
+				return err
+			}
+
+			worker, cleanup, err := wiring.InitializeWorker(config.ConfigFilePath(configFilePath))
+			if err != nil {
+				return err
+			}
+
+			defer cleanup()
+
+			return worker.Start()
+		},
+	}
+
+	command.Flags().String(flagConfigFilePath, "", "If provided, will use the provided config file.")
+
+	return command
+}
+
+func standalone() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "standalone",
+		Short: "Run go-idm in a single process without any outside service, for trying it out or testing",
+		Long: "Run go-idm in a single process with the embedded standalone profile: an SQLite database, an " +
+			"in-memory cache, an in-process queue and local file storage, all kept under the data directory.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configFilePath, err := cmd.Flags().GetString(flagConfigFilePath)
+			if err != nil {
+				return err
+			}
+
+			if configFilePath == "" {
+				configFilePath = string(config.ConfigFilePathStandalone)
+			}
+
+			app, cleanup, err := wiring.InitializeServer(config.ConfigFilePath(configFilePath))
+			if err != nil {
+				return err
+			}
+
+			defer cleanup()
+
+			return app.Start()
+		},
+	}
+
+	command.Flags().String(flagConfigFilePath, "", "If provided, will use the provided config file instead of the standalone profile.")
+
+	return command
+}
+
+func deadLetterQueue() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "dlq",
+		Short: "Inspect and replay the messages in the dead letter topic of a topic",
+	}
+
+	listCommand := &cobra.Command{
+		Use: "list",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configFilePath, err := cmd.Flags().GetString(flagConfigFilePath)
+			if err != nil {
+				return err
+			}
+
+			topic, err := cmd.Flags().GetString(flagTopic)
+			if err != nil {
+				return err
+			}
+
+			limit, err := cmd.Flags().GetInt64(flagLimit)
+			if err != nil {
+				return err
+			}
+
+			deadLetterQueue, cleanup, err := wiring.InitializeDeadLetterQueue(config.ConfigFilePath(configFilePath))
+			if err != nil {
+				return err
+			}
+
+			defer cleanup()
+
+			deadLetterMessageList, err := deadLetterQueue.GetDeadLetterMessageList(cmd.Context(), topic, limit)
+			if err != nil {
+				return err
+			}
+
+			for _, deadLetterMessage := range deadLetterMessageList {
+				fmt.Printf(
+					"partition=%d offset=%d key=%q attempt=%d failed_time=%s error=%q payload=%s\n",
+					deadLetterMessage.Partition,
+					deadLetterMessage.Offset,
+					deadLetterMessage.Key,
+					deadLetterMessage.Attempt,
+					deadLetterMessage.FailedTime,
+					deadLetterMessage.Error,
+					deadLetterMessage.Payload,
+				)
+			}
+
+			return nil
+		},
+	}
+
+	listCommand.Flags().Int64(flagLimit, 20, "Maximum number of messages to list per partition.")
+
+	replayCommand := &cobra.Command{
+		Use: "replay",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configFilePath, err := cmd.Flags().GetString(flagConfigFilePath)
+			if err != nil {
+				return err
+			}
+
+			topic, err := cmd.Flags().GetString(flagTopic)
+			if err != nil {
+				return err
+			}
+
+			partition, err := cmd.Flags().GetInt32(flagPartition)
+			if err != nil {
+				return err
+			}
+
+			offset, err := cmd.Flags().GetInt64(flagOffset)
+			if err != nil {
+				return err
+			}
+
+			deadLetterQueue, cleanup, err := wiring.InitializeDeadLetterQueue(config.ConfigFilePath(configFilePath))
+			if err != nil {
+				return err
+			}
+
+			defer cleanup()
+
+			return deadLetterQueue.ReplayDeadLetterMessage(cmd.Context(), topic, partition, offset)
+		},
+	}
+
+	replayCommand.Flags().Int32(flagPartition, 0, "Partition of the dead letter message to replay.")
+	replayCommand.Flags().Int64(flagOffset, 0, "Offset of the dead letter message to replay.")
+	_ = replayCommand.MarkFlagRequired(flagOffset)
+
+	command.PersistentFlags().String(flagConfigFilePath, "", "If provided, will use the provided config file.")
+	command.PersistentFlags().String(flagTopic, "", "Original topic whose dead letter topic to use.")
+	_ = command.MarkPersistentFlagRequired(flagTopic)
+
+	command.AddCommand(listCommand, replayCommand)
+
+	return command
+}
+
+func migrate() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply, revert or list the database schema migrations",
+	}
+
+	upCommand := &cobra.Command{
+		Use:   "up",
+		Short: "Apply every migration not applied yet",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configFilePath, err := cmd.Flags().GetString(flagConfigFilePath)
+			if err != nil {
+				return err
+			}
+
+			migrator, cleanup, err := wiring.InitializeMigrator(config.ConfigFilePath(configFilePath))
+			if err != nil {
+				return err
+			}
+
+			defer cleanup()
+
+			return migrator.Up(cmd.Context())
+		},
+	}
+
+	downCommand := &cobra.Command{
+		Use:   "down",
+		Short: "Revert the last applied migrations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configFilePath, err := cmd.Flags().GetString(flagConfigFilePath)
+			if err != nil {
+				return err
+			}
+
+			steps, err := cmd.Flags().GetInt(flagSteps)
+			if err != nil {
+				return err
+			}
+
+			migrator, cleanup, err := wiring.InitializeMigrator(config.ConfigFilePath(configFilePath))
+			if err != nil {
+				return err
+			}
+
+			defer cleanup()
+
+			return migrator.Down(cmd.Context(), steps)
+		},
+	}
+
+	downCommand.Flags().Int(flagSteps, 1, "Number of migrations to revert.")
+
+	statusCommand := &cobra.Command{
+		Use:   "status",
+		Short: "List the migrations and when they were applied",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configFilePath, err := cmd.Flags().GetString(flagConfigFilePath)
+			if err != nil {
+				return err
+			}
+
+			migrator, cleanup, err := wiring.InitializeMigrator(config.ConfigFilePath(configFilePath))
+			if err != nil {
+				return err
+			}
+
+			defer cleanup()
+
+			migrationStatusList, err := migrator.Status(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			for _, migrationStatus := range migrationStatusList {
+				appliedTime := "pending"
+				if migrationStatus.AppliedTime != nil {
+					appliedTime = migrationStatus.AppliedTime.Format(time.RFC3339)
+				}
+
+				fmt.Printf("%04d %-30s %s\n", migrationStatus.Version, migrationStatus.Name, appliedTime)
+			}
+
+			return nil
+		},
+	}
+
+	command.PersistentFlags().String(flagConfigFilePath, "", "If provided, will use the provided config file.")
+
+	command.AddCommand(upCommand, downCommand, statusCommand)
+
+	return command
+}
+
+func fsck() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "fsck",
+		Short: "Check that the stored files match the download tasks, and optionally fix what does not",
+		Long: "Check that the file of every succeeded download task is stored with the size and checksum " +
+			"recorded when it was downloaded, and report the files left behind by deleted download tasks or " +
+			"in a storage their download task does not record, once older than the orphan reconciler min_age. " +
+			"Nothing is changed unless --mismatch-fix or --delete-orphans is given.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configFilePath, err := cmd.Flags().GetString(flagConfigFilePath)
+			if err != nil {
+				return err
+			}
+
+			mismatchFixFlagValue, err := cmd.Flags().GetString(flagMismatchFix)
+			if err != nil {
+				return err
+			}
+
+			mismatchFix, ok := mismatchFixFlagValues[mismatchFixFlagValue]
+			if !ok {
+				return fmt.Errorf("unsupported %s: %s", flagMismatchFix, mismatchFixFlagValue)
+			}
+
+			deleteOrphans, err := cmd.Flags().GetBool(flagDeleteOrphans)
+			if err != nil {
+				return err
+			}
+
+			skipChecksum, err := cmd.Flags().GetBool(flagSkipChecksum)
+			if err != nil {
+				return err
+			}
+
+			downloadFileCheck, cleanup, err := wiring.InitializeDownloadFileCheck(config.ConfigFilePath(configFilePath))
+			if err != nil {
+				return err
+			}
+
+			defer cleanup()
+
+			output, err := downloadFileCheck.Check(cmd.Context(), logic.DownloadFileCheckOptions{
+				MismatchFix:   mismatchFix,
+				DeleteOrphans: deleteOrphans,
+				SkipChecksum:  skipChecksum,
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, issue := range output.DownloadFileIssueList {
+				fmt.Printf(
+					"%-20s download_task_id=%d file_name=%q size=%d/%d checksum=%q/%q fixed=%t\n",
+					issue.Kind,
+					issue.DownloadTaskId,
+					issue.FileName,
+					issue.ActualFileSize,
+					issue.ExpectedFileSize,
+					issue.ActualChecksum,
+					issue.ExpectedChecksum,
+					issue.Fixed,
+				)
+			}
+
+			fmt.Printf(
+				"checked %d download tasks and %d files, found %d issues\n",
+				output.CheckedDownloadTaskCount,
+				output.CheckedFileCount,
+				len(output.DownloadFileIssueList),
+			)
+
+			return nil
+		},
+	}
+
+	command.Flags().String(flagConfigFilePath, "", "If provided, will use the provided config file.")
+	command.Flags().String(flagMismatchFix, "none", "Fix of the download tasks whose file is missing or does not match: none, mark-failed or reenqueue.")
+	command.Flags().Bool(flagDeleteOrphans, false, "Delete the orphaned files reported.")
+	command.Flags().Bool(flagSkipChecksum, false, "Only compare the size of the files, without reading them.")
+
+	return command
+}
+
+func storage() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "storage",
+		Short: "Manage the storage of the downloaded files",
+	}
+
+	migrateCommand := &cobra.Command{
+		Use:   "migrate",
+		Short: "Copy the files of the download tasks from a storage to another",
+		Long: "Copy the file of every succeeded download task from a storage to another, check what is copied " +
+			"against the recorded checksum and point the download task to the copy. Both storages are named " +
+			"storages of download.storages, or a mode, local or s3, when only one storage is of that mode. The " +
+			"migration can be run again to resume it: switch the default storage of download.placement to the " +
+			"storage migrated to, then run the migration again to move the files downloaded in the meantime.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configFilePath, err := cmd.Flags().GetString(flagConfigFilePath)
+			if err != nil {
+				return err
+			}
+
+			from, err := cmd.Flags().GetString(flagFrom)
+			if err != nil {
+				return err
+			}
+
+			to, err := cmd.Flags().GetString(flagTo)
+			if err != nil {
+				return err
+			}
+
+			concurrency, err := cmd.Flags().GetUint64(flagConcurrency)
+			if err != nil {
+				return err
+			}
+
+			deleteSource, err := cmd.Flags().GetBool(flagDeleteSource)
+			if err != nil {
+				return err
+			}
+
+			storageMigration, cleanup, err := wiring.InitializeStorageMigration(config.ConfigFilePath(configFilePath))
+			if err != nil {
+				return err
+			}
+
+			defer cleanup()
+
+			output, err := storageMigration.MigrateStorage(cmd.Context(), logic.MigrateStorageParams{
+				From:         from,
+				To:           to,
+				Concurrency:  concurrency,
+				DeleteSource: deleteSource,
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, failure := range output.FailureList {
+				fmt.Printf("failed download_task_id=%d error=%q\n", failure.DownloadTaskID, failure.Error)
+			}
+
+			fmt.Printf(
+				"migrated %d files, skipped %d, failed %d\n",
+				output.MigratedCount,
+				output.SkippedCount,
+				len(output.FailureList),
+			)
+
+			return nil
+		},
+	}
+
+	migrateCommand.Flags().String(flagFrom, "", "Name or mode of the storage to copy the files from.")
+	migrateCommand.Flags().String(flagTo, "", "Name or mode of the storage to copy the files to.")
+	migrateCommand.Flags().Uint64(flagConcurrency, 4, "Maximum number of files copied at the same time.")
+	migrateCommand.Flags().Bool(flagDeleteSource, false, "Delete each file from the storage copied from once it is migrated.")
+	_ = migrateCommand.MarkFlagRequired(flagFrom)
+	_ = migrateCommand.MarkFlagRequired(flagTo)
+
+	rotateMasterKeyCommand := &cobra.Command{
+		Use:   "rotate-master-key",
+		Short: "Wrap the data keys of the encrypted files with the current master key",
+		Long: "Wrap the data key of every encrypted file again with the current master key of " +
+			"download.encryption, without encrypting the files again. Make the new master key the current one " +
+			"while keeping the previous one in the master keys, run this command, then remove the previous " +
+			"master key.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configFilePath, err := cmd.Flags().GetString(flagConfigFilePath)
+			if err != nil {
+				return err
+			}
+
+			masterKeyRotation, cleanup, err := wiring.InitializeMasterKeyRotation(config.ConfigFilePath(configFilePath))
+			if err != nil {
+				return err
+			}
+
+			defer cleanup()
+
+			output, err := masterKeyRotation.RotateMasterKey(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("wrapped %d data keys again\n", output.RewrappedCount)
+			return nil
+		},
+	}
+
+	command.PersistentFlags().String(flagConfigFilePath, "", "If provided, will use the provided config file.")
+
+	command.AddCommand(migrateCommand)
+	command.AddCommand(rotateMasterKeyCommand)
+
+	return command
+}
+
 func main() {
 	rootCommand := &cobra.Command{
 		Version: fmt.Sprintf("%s-%s", version, commitHash),
 	}
 	rootCommand.AddCommand(
 		server(),
+		api(),
+		worker(),
+		standalone(),
+		deadLetterQueue(),
+		migrate(),
+		fsck(),
+		storage(),
 	)
 
 	if err := rootCommand.Execute(); err != nil {
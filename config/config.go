@@ -5,4 +5,7 @@ import (
 )
 
 //go:embed local.yaml
-var DefaultConfigBytes []byte
\ No newline at end of file
+var DefaultConfigBytes []byte
+
+//go:embed standalone.yaml
+var StandaloneConfigBytes []byte
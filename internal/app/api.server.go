@@ -0,0 +1,49 @@
+package app
+
+import (
+	"github.com/manhhung2111/go-idm/internal/config"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/database"
+	"github.com/manhhung2111/go-idm/internal/handler/grpc"
+	"github.com/manhhung2111/go-idm/internal/handler/http"
+	"go.uber.org/zap"
+)
+
+// APIServer only serves the gRPC and HTTP APIs. Download tasks created through it are executed by the
+// Worker processes.
+type APIServer struct {
+	grpcServer     grpc.Server
+	httpServer     http.Server
+	migrator       database.Migrator
+	databaseConfig config.Database
+	shutdownConfig config.Shutdown
+	logger         *zap.Logger
+}
+
+func NewAPIServer(
+	grpcServer grpc.Server,
+	httpServer http.Server,
+	migrator database.Migrator,
+	databaseConfig config.Database,
+	shutdownConfig config.Shutdown,
+	logger *zap.Logger,
+) *APIServer {
+	return &APIServer{
+		grpcServer:     grpcServer,
+		httpServer:     httpServer,
+		migrator:       migrator,
+		databaseConfig: databaseConfig,
+		shutdownConfig: shutdownConfig,
+		logger:         logger,
+	}
+}
+
+func (s *APIServer) Start() error {
+	if err := autoMigrate(s.migrator, s.databaseConfig, s.logger); err != nil {
+		return err
+	}
+
+	return runUntilSignal(s.shutdownConfig, s.logger, []component{
+		{name: "grpc server", start: s.grpcServer.Start, stop: s.grpcServer.Stop},
+		{name: "http server", start: s.httpServer.Start, stop: s.httpServer.Stop},
+	})
+}
@@ -2,19 +2,35 @@ package app
 
 import (
 	"context"
+	"sync"
 	"syscall"
 
+	"github.com/manhhung2111/go-idm/internal/config"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/database"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/kafka/producer"
 	handler_consumer "github.com/manhhung2111/go-idm/internal/handler/consumer"
 	"github.com/manhhung2111/go-idm/internal/handler/grpc"
 	"github.com/manhhung2111/go-idm/internal/handler/http"
+	"github.com/manhhung2111/go-idm/internal/logic"
 	"github.com/manhhung2111/go-idm/internal/utils"
 	"go.uber.org/zap"
 )
 
+// Server runs every component of go-idm in a single process: the APIs of APIServer and the background work of
+// Worker.
 type Server struct {
 	grpcServer grpc.Server
 	httpServer http.Server
 	rootConsumer handler_consumer.Root
+	monitoringServer http.MonitoringServer
+	outboxRelay producer.OutboxRelay
+	downloadTaskReaper logic.DownloadTaskReaper
+	downloadTaskPurger logic.DownloadTaskPurger
+	downloadFileReconciler logic.DownloadFileReconciler
+	downloadFileTiering logic.DownloadFileTiering
+	migrator database.Migrator
+	databaseConfig config.Database
+	shutdownConfig config.Shutdown
 	logger *zap.Logger
 }
 
@@ -22,32 +38,122 @@ func NewServer(
 	grpcServer grpc.Server,
 	httpServer http.Server,
 	rootConsumer handler_consumer.Root,
+	monitoringServer http.MonitoringServer,
+	outboxRelay producer.OutboxRelay,
+	downloadTaskReaper logic.DownloadTaskReaper,
+	downloadTaskPurger logic.DownloadTaskPurger,
+	downloadFileReconciler logic.DownloadFileReconciler,
+	downloadFileTiering logic.DownloadFileTiering,
+	migrator database.Migrator,
+	databaseConfig config.Database,
+	shutdownConfig config.Shutdown,
 	logger *zap.Logger,
 ) *Server {
 	return &Server{
 		grpcServer: grpcServer,
 		httpServer: httpServer,
 		rootConsumer: rootConsumer,
+		monitoringServer: monitoringServer,
+		outboxRelay: outboxRelay,
+		downloadTaskReaper: downloadTaskReaper,
+		downloadTaskPurger: downloadTaskPurger,
+		downloadFileReconciler: downloadFileReconciler,
+		downloadFileTiering: downloadFileTiering,
+		migrator: migrator,
+		databaseConfig: databaseConfig,
+		shutdownConfig: shutdownConfig,
 		logger: logger,
 	}
 }
 
 func (s *Server) Start() error {
-	go func() {
-		err := s.grpcServer.Start(context.Background())
-		s.logger.With(zap.Error(err)).Info("grpc server stopped")
-	}()
+	if err := autoMigrate(s.migrator, s.databaseConfig, s.logger); err != nil {
+		return err
+	}
 
-	go func() {
-		err := s.httpServer.Start(context.Background())
-		s.logger.With(zap.Error(err)).Info("http server stopped")
-	}()
+	return runUntilSignal(s.shutdownConfig, s.logger, []component{
+		{name: "grpc server", start: s.grpcServer.Start, stop: s.grpcServer.Stop},
+		{name: "http server", start: s.httpServer.Start, stop: s.httpServer.Stop},
+		{name: "message queue consumer", start: s.rootConsumer.Start, stop: s.rootConsumer.Stop},
+		{name: "monitoring http server", start: s.monitoringServer.Start, stop: s.monitoringServer.Stop},
+		{name: "outbox relay", start: s.outboxRelay.Start},
+		{name: "download task reaper", start: s.downloadTaskReaper.Start},
+		{name: "download task purger", start: s.downloadTaskPurger.Start},
+		{name: "download file reconciler", start: s.downloadFileReconciler.Start},
+		{name: "download file tiering", start: s.downloadFileTiering.Start},
+	})
+}
 
-	go func() {
-		err := s.rootConsumer.Start(context.Background())
-		s.logger.With(zap.Error(err)).Info("message queue consumer stopped")
-	}()
+// autoMigrate brings the schema of the database up to date if the config asks for it, before any component
+// starts using it.
+func autoMigrate(migrator database.Migrator, databaseConfig config.Database, logger *zap.Logger) error {
+	if !databaseConfig.AutoMigrate {
+		return nil
+	}
+
+	logger.Info("applying database migrations")
+	return migrator.Up(context.Background())
+}
+
+// component is a part of a server. Components without a stop function are stopped by canceling the context
+// they were started with.
+type component struct {
+	name  string
+	start func(ctx context.Context) error
+	stop  func(ctx context.Context) error
+}
+
+// runUntilSignal runs the components until SIGINT or SIGTERM. It then stops the components accepting new
+// RPCs, requests and messages, and gives the pending ones the shutdown timeout to finish before canceling
+// them. Downloads canceled this way are put back into pending so that they resume later on. The components
+// without a stop function, the outbox relay and the reaper, are stopped last; outbox messages they did not
+// get to publish are published on the next start.
+func runUntilSignal(shutdownConfig config.Shutdown, logger *zap.Logger, componentList []component) error {
+	shutdownTimeout, err := shutdownConfig.GetTimeoutDuration()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	waitGroup := new(sync.WaitGroup)
+	for _, c := range componentList {
+		runInBackground(waitGroup, logger.With(zap.String("component", c.name)), "stopped", func() error {
+			return c.start(ctx)
+		})
+	}
 
 	utils.BlockUntilSignal(syscall.SIGINT, syscall.SIGTERM)
+	logger.With(zap.Duration("timeout", shutdownTimeout)).Info("shutting down")
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancelShutdown()
+
+	stopWaitGroup := new(sync.WaitGroup)
+	for _, c := range componentList {
+		if c.stop == nil {
+			continue
+		}
+
+		runInBackground(stopWaitGroup, logger.With(zap.String("component", c.name)), "drained", func() error {
+			return c.stop(shutdownCtx)
+		})
+	}
+
+	stopWaitGroup.Wait()
+
+	cancel()
+	waitGroup.Wait()
+
+	logger.Info("shut down")
 	return nil
-}
\ No newline at end of file
+}
+
+func runInBackground(waitGroup *sync.WaitGroup, logger *zap.Logger, message string, f func() error) {
+	waitGroup.Add(1)
+	go func() {
+		defer waitGroup.Done()
+		logger.With(zap.Error(f())).Info(message)
+	}()
+}
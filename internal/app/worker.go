@@ -0,0 +1,74 @@
+package app
+
+import (
+	"github.com/manhhung2111/go-idm/internal/config"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/database"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/kafka/producer"
+	handler_consumer "github.com/manhhung2111/go-idm/internal/handler/consumer"
+	"github.com/manhhung2111/go-idm/internal/handler/http"
+	"github.com/manhhung2111/go-idm/internal/logic"
+	"go.uber.org/zap"
+)
+
+// Worker does the background work of go-idm without serving any API: it consumes the message queue to execute
+// download tasks, publishes the outbox, recovers the download tasks whose lease expired, empties the trash,
+// deletes the orphaned files and moves the old files to the cold storage. It only serves the monitoring of its
+// worker pool. Several workers can run side by side, they share the work through the consumer group and row
+// locks.
+type Worker struct {
+	rootConsumer           handler_consumer.Root
+	monitoringServer       http.MonitoringServer
+	outboxRelay            producer.OutboxRelay
+	downloadTaskReaper     logic.DownloadTaskReaper
+	downloadTaskPurger     logic.DownloadTaskPurger
+	downloadFileReconciler logic.DownloadFileReconciler
+	downloadFileTiering    logic.DownloadFileTiering
+	migrator               database.Migrator
+	databaseConfig         config.Database
+	shutdownConfig         config.Shutdown
+	logger                 *zap.Logger
+}
+
+func NewWorker(
+	rootConsumer handler_consumer.Root,
+	monitoringServer http.MonitoringServer,
+	outboxRelay producer.OutboxRelay,
+	downloadTaskReaper logic.DownloadTaskReaper,
+	downloadTaskPurger logic.DownloadTaskPurger,
+	downloadFileReconciler logic.DownloadFileReconciler,
+	downloadFileTiering logic.DownloadFileTiering,
+	migrator database.Migrator,
+	databaseConfig config.Database,
+	shutdownConfig config.Shutdown,
+	logger *zap.Logger,
+) *Worker {
+	return &Worker{
+		rootConsumer:           rootConsumer,
+		monitoringServer:       monitoringServer,
+		outboxRelay:            outboxRelay,
+		downloadTaskReaper:     downloadTaskReaper,
+		downloadTaskPurger:     downloadTaskPurger,
+		downloadFileReconciler: downloadFileReconciler,
+		downloadFileTiering:    downloadFileTiering,
+		migrator:               migrator,
+		databaseConfig:         databaseConfig,
+		shutdownConfig:         shutdownConfig,
+		logger:                 logger,
+	}
+}
+
+func (w *Worker) Start() error {
+	if err := autoMigrate(w.migrator, w.databaseConfig, w.logger); err != nil {
+		return err
+	}
+
+	return runUntilSignal(w.shutdownConfig, w.logger, []component{
+		{name: "message queue consumer", start: w.rootConsumer.Start, stop: w.rootConsumer.Stop},
+		{name: "monitoring http server", start: w.monitoringServer.Start, stop: w.monitoringServer.Stop},
+		{name: "outbox relay", start: w.outboxRelay.Start},
+		{name: "download task reaper", start: w.downloadTaskReaper.Start},
+		{name: "download task purger", start: w.downloadTaskPurger.Start},
+		{name: "download file reconciler", start: w.downloadFileReconciler.Start},
+		{name: "download file tiering", start: w.downloadFileTiering.Start},
+	})
+}
@@ -19,7 +19,20 @@ func (t *Token) GetRegenerateTokenBeforeExpiryDuration() (time.Duration, error)
 	return time.ParseDuration(t.RegenerateTokenBeforeExpiry)
 }
 
+// Auth configures the authentication of the accounts. The accounts named in AdminAccountNames are admins,
+// allowed to see what every account did.
 type Auth struct {
-	Hash  Hash
-	Token Token
+	Hash              Hash
+	Token             Token
+	AdminAccountNames []string `yaml:"admin_account_names"`
+}
+
+func (a Auth) IsAdminAccountName(accountName string) bool {
+	for _, adminAccountName := range a.AdminAccountNames {
+		if adminAccountName == accountName {
+			return true
+		}
+	}
+
+	return false
 }
@@ -10,6 +10,11 @@ import (
 
 type ConfigFilePath string
 
+// ConfigFilePathStandalone loads the embedded standalone profile in place of a config file. It runs go-idm
+// without any outside service: an SQLite database, the in-memory cache, the in-process queue and the local
+// file storage.
+const ConfigFilePathStandalone ConfigFilePath = "embedded:standalone"
+
 type Config struct {
 	GRPC     GRPC     `yaml:"grpc"`
 	HTTP     HTTP     `yaml:"http"`
@@ -18,17 +23,24 @@ type Config struct {
 	Database Database `yaml:"database"`
 	Cache    Cache    `yaml:"cache"`
 	Kafka    Kafka    `yaml:"kafka"`
+	Queue    Queue    `yaml:"queue"`
 	Download Download `yaml:"download"`
+	Shutdown Shutdown `yaml:"shutdown"`
 }
 
 func NewConfig(filePath ConfigFilePath) (Config, error) {
 	var (
-		configBytes = config.DefaultConfigBytes
-		config      = Config{}
-		err         error
+		configBytes           = config.DefaultConfigBytes
+		standaloneConfigBytes = config.StandaloneConfigBytes
+		config                = Config{}
+		err                   error
 	)
 
-	if filePath != "" {
+	switch filePath {
+	case "":
+	case ConfigFilePathStandalone:
+		configBytes = standaloneConfigBytes
+	default:
 		configBytes, err = os.ReadFile(string(filePath))
 		if err != nil {
 			return Config{}, fmt.Errorf("failed to read YAML file: %w", err)
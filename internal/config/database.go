@@ -1,17 +1,171 @@
 package config
 
+import "time"
+
+const (
+	defaultSQLiteDatabasePath = "go-idm.db"
+)
+
 type DatabaseType string
 
 const (
-	DatabaseTypeMySQL DatabaseType = "mysql"
+	DatabaseTypeMySQL    DatabaseType = "mysql"
+	DatabaseTypePostgres DatabaseType = "postgres"
+	DatabaseTypeSQLite   DatabaseType = "sqlite"
 )
 
+// SQLiteDatabase configures the embedded SQLite database. Path is the file the database is stored in, it is
+// created if it does not exist.
+type SQLiteDatabase struct {
+	Path string `yaml:"path"`
+}
 
-type Database struct {
-	Type     DatabaseType `yaml:"type"`
+func (s SQLiteDatabase) GetPath() string {
+	if s.Path == "" {
+		return defaultSQLiteDatabasePath
+	}
+
+	return s.Path
+}
+
+const (
+	defaultDatabaseMaxOpenConnections    = 20
+	defaultDatabaseMaxIdleConnections    = 10
+	defaultDatabaseConnectionMaxLifetime = 30 * time.Minute
+	defaultDatabaseConnectionMaxIdleTime = 5 * time.Minute
+)
+
+// DatabasePool configures the connection pool of the database. Connections are closed once they have been
+// open for ConnectionMaxLifetime, or idle for ConnectionMaxIdleTime, so that the pool follows failovers and
+// load balancers. A negative MaxOpenConnections leaves the number of open connections unbounded.
+type DatabasePool struct {
+	MaxOpenConnections    int    `yaml:"max_open_connections"`
+	MaxIdleConnections    int    `yaml:"max_idle_connections"`
+	ConnectionMaxLifetime string `yaml:"connection_max_lifetime"`
+	ConnectionMaxIdleTime string `yaml:"connection_max_idle_time"`
+}
+
+func (d DatabasePool) GetMaxOpenConnections() int {
+	if d.MaxOpenConnections == 0 {
+		return defaultDatabaseMaxOpenConnections
+	}
+
+	return d.MaxOpenConnections
+}
+
+func (d DatabasePool) GetMaxIdleConnections() int {
+	if d.MaxIdleConnections == 0 {
+		return defaultDatabaseMaxIdleConnections
+	}
+
+	return d.MaxIdleConnections
+}
+
+func (d DatabasePool) GetConnectionMaxLifetimeDuration() (time.Duration, error) {
+	if d.ConnectionMaxLifetime == "" {
+		return defaultDatabaseConnectionMaxLifetime, nil
+	}
+
+	return time.ParseDuration(d.ConnectionMaxLifetime)
+}
+
+func (d DatabasePool) GetConnectionMaxIdleTimeDuration() (time.Duration, error) {
+	if d.ConnectionMaxIdleTime == "" {
+		return defaultDatabaseConnectionMaxIdleTime, nil
+	}
+
+	return time.ParseDuration(d.ConnectionMaxIdleTime)
+}
+
+// DatabaseTLS configures TLS for the connections to MySQL and PostgreSQL. The server certificate is verified
+// against CAFile, or the system roots if it is empty, and CertFile and KeyFile are the client certificate
+// presented to servers requiring one. ServerName overrides the host name the server certificate is verified
+// for.
+type DatabaseTLS struct {
+	Enabled            bool   `yaml:"enabled"`
+	CAFile             string `yaml:"ca_file"`
+	CertFile           string `yaml:"cert_file"`
+	KeyFile            string `yaml:"key_file"`
+	ServerName         string `yaml:"server_name"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify"`
+}
+
+// DatabaseReplica is a read replica of the database. It is connected to like the database, with the fields
+// left empty taken from the database.
+type DatabaseReplica struct {
 	Host     string `yaml:"host"`
 	Port     int    `yaml:"port"`
 	Username string `yaml:"username"`
 	Password string `yaml:"password"`
-	Database string `yaml:"database"`
+}
+
+// Apply returns the config of the database with the fields of the replica.
+func (d DatabaseReplica) Apply(databaseConfig Database) Database {
+	if d.Host != "" {
+		databaseConfig.Host = d.Host
+	}
+
+	if d.Port != 0 {
+		databaseConfig.Port = d.Port
+	}
+
+	if d.Username != "" {
+		databaseConfig.Username = d.Username
+		databaseConfig.Password = d.Password
+	}
+
+	databaseConfig.Replicas = nil
+	return databaseConfig
+}
+
+const (
+	defaultDatabaseDialTimeout = 5 * time.Second
+)
+
+// Database configures the database. DialTimeout bounds the opening of a connection, and the check that the
+// database is reachable as the process starts. ReadTimeout and WriteTimeout bound each network read and write
+// on MySQL, which are not bounded if they are empty. Params are added to the connection string as they are,
+// for the driver options not covered by the config. The non-locking reads of the requests that have not
+// written yet run on the Replicas, if any.
+type Database struct {
+	Type         DatabaseType      `yaml:"type"`
+	Host         string            `yaml:"host"`
+	Port         int               `yaml:"port"`
+	Username     string            `yaml:"username"`
+	Password     string            `yaml:"password"`
+	Database     string            `yaml:"database"`
+	SQLite       SQLiteDatabase    `yaml:"sqlite"`
+	Pool         DatabasePool      `yaml:"pool"`
+	DialTimeout  string            `yaml:"dial_timeout"`
+	ReadTimeout  string            `yaml:"read_timeout"`
+	WriteTimeout string            `yaml:"write_timeout"`
+	TLS          DatabaseTLS       `yaml:"tls"`
+	Params       map[string]string `yaml:"params"`
+	Replicas     []DatabaseReplica `yaml:"replicas"`
+	// AutoMigrate applies the migrations not applied yet to the database as the process starts.
+	AutoMigrate bool `yaml:"auto_migrate"`
+}
+
+func (d Database) GetDialTimeoutDuration() (time.Duration, error) {
+	if d.DialTimeout == "" {
+		return defaultDatabaseDialTimeout, nil
+	}
+
+	return time.ParseDuration(d.DialTimeout)
+}
+
+func (d Database) GetReadTimeoutDuration() (time.Duration, error) {
+	if d.ReadTimeout == "" {
+		return 0, nil
+	}
+
+	return time.ParseDuration(d.ReadTimeout)
+}
+
+func (d Database) GetWriteTimeoutDuration() (time.Duration, error) {
+	if d.WriteTimeout == "" {
+		return 0, nil
+	}
+
+	return time.ParseDuration(d.WriteTimeout)
 }
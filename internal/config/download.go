@@ -1,5 +1,13 @@
 package config
 
+import (
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize"
+)
+
 type DownloadMode string
 
 const (
@@ -7,11 +15,412 @@ const (
 	DownloadModeS3    DownloadMode = "s3"
 )
 
-type Download struct {
+const (
+	defaultMaxConcurrentDownloads = 4
+	defaultMaxConnections         = 32
+)
+
+// DownloadWorkerPool limits the work a process takes on. MaxConcurrentDownloads bounds the download tasks
+// executed at the same time, and MaxConnections the connections opened to download them, counted across all
+// the download tasks of the process.
+type DownloadWorkerPool struct {
+	MaxConcurrentDownloads uint64 `yaml:"max_concurrent_downloads"`
+	MaxConnections         uint64 `yaml:"max_connections"`
+}
+
+func (d DownloadWorkerPool) GetMaxConcurrentDownloads() uint64 {
+	if d.MaxConcurrentDownloads == 0 {
+		return defaultMaxConcurrentDownloads
+	}
+
+	return d.MaxConcurrentDownloads
+}
+
+func (d DownloadWorkerPool) GetMaxConnections() uint64 {
+	if d.MaxConnections == 0 {
+		return defaultMaxConnections
+	}
+
+	return d.MaxConnections
+}
+
+const (
+	defaultLeaseHeartbeatInterval = 10 * time.Second
+	defaultLeaseExpiresIn         = time.Minute
+	defaultLeaseReaperInterval    = 30 * time.Second
+	defaultLeaseReaperBatchSize   = 100
+)
+
+// DownloadLease configures the leases of the download tasks being executed. The worker executing a download
+// task renews its lease every HeartbeatInterval, and the reaper puts the download tasks whose lease was not
+// renewed for ExpiresIn back into pending, every ReaperInterval.
+type DownloadLease struct {
+	HeartbeatInterval string `yaml:"heartbeat_interval"`
+	ExpiresIn         string `yaml:"expires_in"`
+	ReaperInterval    string `yaml:"reaper_interval"`
+	ReaperBatchSize   uint64 `yaml:"reaper_batch_size"`
+}
+
+func (d DownloadLease) GetHeartbeatIntervalDuration() (time.Duration, error) {
+	if d.HeartbeatInterval == "" {
+		return defaultLeaseHeartbeatInterval, nil
+	}
+
+	return time.ParseDuration(d.HeartbeatInterval)
+}
+
+func (d DownloadLease) GetExpiresInDuration() (time.Duration, error) {
+	if d.ExpiresIn == "" {
+		return defaultLeaseExpiresIn, nil
+	}
+
+	return time.ParseDuration(d.ExpiresIn)
+}
+
+func (d DownloadLease) GetReaperIntervalDuration() (time.Duration, error) {
+	if d.ReaperInterval == "" {
+		return defaultLeaseReaperInterval, nil
+	}
+
+	return time.ParseDuration(d.ReaperInterval)
+}
+
+func (d DownloadLease) GetReaperBatchSize() uint64 {
+	if d.ReaperBatchSize == 0 {
+		return defaultLeaseReaperBatchSize
+	}
+
+	return d.ReaperBatchSize
+}
+
+const (
+	defaultTrashRetention      = 30 * 24 * time.Hour
+	defaultTrashPurgeInterval  = time.Hour
+	defaultTrashPurgeBatchSize = 100
+)
+
+// DownloadTrash configures the trash of the download tasks. A deleted download task stays in the trash, where
+// it can be restored, for Retention, after which the purger deletes it for good along with its file. The
+// purger runs every PurgeInterval.
+type DownloadTrash struct {
+	Retention      string `yaml:"retention"`
+	PurgeInterval  string `yaml:"purge_interval"`
+	PurgeBatchSize uint64 `yaml:"purge_batch_size"`
+}
+
+func (d DownloadTrash) GetRetentionDuration() (time.Duration, error) {
+	if d.Retention == "" {
+		return defaultTrashRetention, nil
+	}
+
+	return time.ParseDuration(d.Retention)
+}
+
+func (d DownloadTrash) GetPurgeIntervalDuration() (time.Duration, error) {
+	if d.PurgeInterval == "" {
+		return defaultTrashPurgeInterval, nil
+	}
+
+	return time.ParseDuration(d.PurgeInterval)
+}
+
+func (d DownloadTrash) GetPurgeBatchSize() uint64 {
+	if d.PurgeBatchSize == 0 {
+		return defaultTrashPurgeBatchSize
+	}
+
+	return d.PurgeBatchSize
+}
+
+const (
+	defaultOrphanReconcilerInterval  = 6 * time.Hour
+	defaultOrphanReconcilerMinAge    = time.Hour
+	defaultOrphanReconcilerBatchSize = 100
+)
+
+// DownloadOrphanReconciler configures the reconciler deleting the stored files no download task references,
+// left behind when deleting a file fails after its download task was purged. It runs every Interval, and
+// leaves alone the files modified less than MinAge ago.
+type DownloadOrphanReconciler struct {
+	Interval  string `yaml:"interval"`
+	MinAge    string `yaml:"min_age"`
+	BatchSize uint64 `yaml:"batch_size"`
+}
+
+func (d DownloadOrphanReconciler) GetIntervalDuration() (time.Duration, error) {
+	if d.Interval == "" {
+		return defaultOrphanReconcilerInterval, nil
+	}
+
+	return time.ParseDuration(d.Interval)
+}
+
+func (d DownloadOrphanReconciler) GetMinAgeDuration() (time.Duration, error) {
+	if d.MinAge == "" {
+		return defaultOrphanReconcilerMinAge, nil
+	}
+
+	return time.ParseDuration(d.MinAge)
+}
+
+func (d DownloadOrphanReconciler) GetBatchSize() uint64 {
+	if d.BatchSize == 0 {
+		return defaultOrphanReconcilerBatchSize
+	}
+
+	return d.BatchSize
+}
+
+const (
+	defaultTieringMinAge      = 30 * 24 * time.Hour
+	defaultTieringInterval    = 24 * time.Hour
+	defaultTieringConcurrency = 4
+)
+
+// DownloadStorage is a named storage files are downloaded to, a directory of the local disk or a bucket of an
+// S3 compatible storage, depending on Mode.
+type DownloadStorage struct {
+	Name              string       `yaml:"name"`
 	Mode              DownloadMode `yaml:"mode"`
 	DownloadDirectory string       `yaml:"download_directory"`
 	Bucket            string       `yaml:"bucket"`
 	Address           string       `yaml:"address"`
 	Username          string       `yaml:"username"`
 	Password          string       `yaml:"password"`
-}
\ No newline at end of file
+	// Preallocate allocates the disk space of the files of known size before writing them, local storages
+	// only.
+	Preallocate bool `yaml:"preallocate"`
+}
+
+// DownloadPlacementRule places the files of the download tasks it matches in Storage. A rule matches a
+// download task if it matches all of its conditions: the account the download task belongs to is one of
+// AccountIDs, its file is at least MinFileSize bytes, and its content type is one of ContentTypes, which may
+// end with "/*" to match a whole type, such as "video/*". Empty conditions match every download task.
+type DownloadPlacementRule struct {
+	Storage      string   `yaml:"storage"`
+	AccountIDs   []uint64 `yaml:"account_ids"`
+	MinFileSize  uint64   `yaml:"min_file_size"`
+	ContentTypes []string `yaml:"content_types"`
+}
+
+// DownloadPlacement configures the storage the file of a download task is downloaded to: the storage of the
+// first rule matching the download task, or DefaultStorage if none does. LegacyStorage is the storage of the
+// files downloaded before the storage of download tasks was recorded, it must not change once set, unlike
+// DefaultStorage.
+type DownloadPlacement struct {
+	DefaultStorage string                  `yaml:"default_storage"`
+	LegacyStorage  string                  `yaml:"legacy_storage"`
+	Rules          []DownloadPlacementRule `yaml:"rules"`
+}
+
+// DownloadTiering configures the tiering job, moving the files modified more than MinAge ago from FromStorage,
+// typically a fast local disk, to ToStorage, typically a cold bucket. It runs every Interval if both storages
+// are set, copying Concurrency files at the same time.
+type DownloadTiering struct {
+	FromStorage string `yaml:"from_storage"`
+	ToStorage   string `yaml:"to_storage"`
+	MinAge      string `yaml:"min_age"`
+	Interval    string `yaml:"interval"`
+	Concurrency uint64 `yaml:"concurrency"`
+}
+
+func (d DownloadTiering) IsEnabled() bool {
+	return d.FromStorage != "" && d.ToStorage != ""
+}
+
+func (d DownloadTiering) GetMinAgeDuration() (time.Duration, error) {
+	if d.MinAge == "" {
+		return defaultTieringMinAge, nil
+	}
+
+	return time.ParseDuration(d.MinAge)
+}
+
+func (d DownloadTiering) GetIntervalDuration() (time.Duration, error) {
+	if d.Interval == "" {
+		return defaultTieringInterval, nil
+	}
+
+	return time.ParseDuration(d.Interval)
+}
+
+func (d DownloadTiering) GetConcurrency() uint64 {
+	if d.Concurrency == 0 {
+		return defaultTieringConcurrency
+	}
+
+	return d.Concurrency
+}
+
+const (
+	defaultEncryptionChunkSize = 64 * 1024
+	encryptionMasterKeySize    = 32
+)
+
+// DownloadEncryptionMasterKey is a master key wrapping the data keys files are encrypted with. Key is the
+// base64 encoding of a 256 bits AES key.
+type DownloadEncryptionMasterKey struct {
+	ID  string `yaml:"id"`
+	Key string `yaml:"key"`
+}
+
+// DownloadEncryption configures the envelope encryption of the downloaded files. Each file is encrypted with
+// its own data key, wrapped by the master key of MasterKeyID and stored in the file header. MasterKeys lists the
+// master keys, the previous ones included until the data keys they wrapped are wrapped again by the current
+// one. Files are encrypted by chunks of ChunkSize, so that a part of a file can be read without decrypting all
+// of it. Files are stored unencrypted if MasterKeyID is empty.
+type DownloadEncryption struct {
+	MasterKeyID string                        `yaml:"master_key_id"`
+	MasterKeys  []DownloadEncryptionMasterKey `yaml:"master_keys"`
+	ChunkSize   string                        `yaml:"chunk_size"`
+}
+
+func (d DownloadEncryption) IsEnabled() bool {
+	return d.MasterKeyID != ""
+}
+
+// GetMasterKeyMap returns the decoded master keys, by id.
+func (d DownloadEncryption) GetMasterKeyMap() (map[string][]byte, error) {
+	masterKeyMap := make(map[string][]byte, len(d.MasterKeys))
+	for _, masterKey := range d.MasterKeys {
+		key, err := base64.StdEncoding.DecodeString(masterKey.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode master key %s: %w", masterKey.ID, err)
+		}
+
+		if len(key) != encryptionMasterKeySize {
+			return nil, fmt.Errorf("master key %s is not %d bytes long", masterKey.ID, encryptionMasterKeySize)
+		}
+
+		masterKeyMap[masterKey.ID] = key
+	}
+
+	if _, ok := masterKeyMap[d.MasterKeyID]; !ok {
+		return nil, fmt.Errorf("master key %s is not configured", d.MasterKeyID)
+	}
+
+	return masterKeyMap, nil
+}
+
+func (d DownloadEncryption) GetChunkSizeInBytes() (uint64, error) {
+	if d.ChunkSize == "" {
+		return defaultEncryptionChunkSize, nil
+	}
+
+	return humanize.ParseBytes(d.ChunkSize)
+}
+
+type DownloadCompressionCodec string
+
+const (
+	DownloadCompressionCodecZstd DownloadCompressionCodec = "zstd"
+	DownloadCompressionCodecGzip DownloadCompressionCodec = "gzip"
+)
+
+var (
+	defaultCompressionContentTypes = []string{
+		"text/*",
+		"application/json",
+		"application/xml",
+		"application/x-ndjson",
+		"application/csv",
+	}
+	defaultCompressionExtensions = []string{".txt", ".log", ".csv", ".tsv", ".json", ".ndjson", ".xml"}
+)
+
+// DownloadCompression configures the compression of the downloaded files with Codec, zstd or gzip. Only the
+// files whose content type is one of ContentTypes, which may end with "/*" to match a whole type, or whose URL
+// ends with one of Extensions are compressed, the others would not compress well. Files are stored
+// uncompressed if Codec is empty. A compressed file can only be read from its start: a range read, as made to
+// resume a download, decompresses everything before the range, so that it gets slower the further the range
+// is in the file. Leave the large files that are often downloaded by range uncompressed.
+type DownloadCompression struct {
+	Codec        DownloadCompressionCodec `yaml:"codec"`
+	ContentTypes []string                 `yaml:"content_types"`
+	Extensions   []string                 `yaml:"extensions"`
+}
+
+func (d DownloadCompression) IsEnabled() bool {
+	return d.Codec != ""
+}
+
+func (d DownloadCompression) GetContentTypes() []string {
+	if len(d.ContentTypes) == 0 {
+		return defaultCompressionContentTypes
+	}
+
+	return d.ContentTypes
+}
+
+func (d DownloadCompression) GetExtensions() []string {
+	if len(d.Extensions) == 0 {
+		return defaultCompressionExtensions
+	}
+
+	return d.Extensions
+}
+
+// Download configures where files are downloaded to. Storages lists the named storages; without any, the
+// single storage described by Mode and the fields next to it is used, named after its mode.
+type Download struct {
+	Mode              DownloadMode             `yaml:"mode"`
+	DownloadDirectory string                   `yaml:"download_directory"`
+	Bucket            string                   `yaml:"bucket"`
+	Address           string                   `yaml:"address"`
+	Username          string                   `yaml:"username"`
+	Password          string                   `yaml:"password"`
+	Preallocate       bool                     `yaml:"preallocate"`
+	Storages          []DownloadStorage        `yaml:"storages"`
+	Placement         DownloadPlacement        `yaml:"placement"`
+	Tiering           DownloadTiering          `yaml:"tiering"`
+	Encryption        DownloadEncryption       `yaml:"encryption"`
+	Compression       DownloadCompression      `yaml:"compression"`
+	WorkerPool        DownloadWorkerPool       `yaml:"worker_pool"`
+	Lease             DownloadLease            `yaml:"lease"`
+	Trash             DownloadTrash            `yaml:"trash"`
+	OrphanReconciler  DownloadOrphanReconciler `yaml:"orphan_reconciler"`
+}
+
+func (d Download) GetStorages() []DownloadStorage {
+	if len(d.Storages) > 0 {
+		return d.Storages
+	}
+
+	return []DownloadStorage{
+		{
+			Name:              string(d.Mode),
+			Mode:              d.Mode,
+			DownloadDirectory: d.DownloadDirectory,
+			Bucket:            d.Bucket,
+			Address:           d.Address,
+			Username:          d.Username,
+			Password:          d.Password,
+			Preallocate:       d.Preallocate,
+		},
+	}
+}
+
+// GetDefaultStorageName returns the storage files are downloaded to when no placement rule matches.
+func (d Download) GetDefaultStorageName() string {
+	if d.Placement.DefaultStorage != "" {
+		return d.Placement.DefaultStorage
+	}
+
+	return d.GetStorages()[0].Name
+}
+
+// GetLegacyStorageName returns the storage of the files downloaded before the storage of a download task was
+// recorded: LegacyStorage, or the single storage described by Mode when Storages is empty, as it was the only
+// storage back then. It returns an empty name if Storages is set without LegacyStorage, the storage of these
+// files is then unknown.
+func (d Download) GetLegacyStorageName() string {
+	if d.Placement.LegacyStorage != "" {
+		return d.Placement.LegacyStorage
+	}
+
+	if len(d.Storages) > 0 {
+		return ""
+	}
+
+	return string(d.Mode)
+}
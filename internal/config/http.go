@@ -1,5 +1,65 @@
 package config
 
+import "time"
+
+const (
+	defaultShareLinkPasswordFailureLimit  = 10
+	defaultShareLinkPasswordFailureWindow = 15 * time.Minute
+)
+
+type ShareLink struct {
+	BaseURL               string `yaml:"base_url"`
+	PresignedURLExpiresIn string `yaml:"presigned_url_expires_in"`
+	PasswordFailureLimit  uint64 `yaml:"password_failure_limit"`
+	PasswordFailureWindow string `yaml:"password_failure_window"`
+}
+
+// GetPresignedURLExpiresInDuration returns how long presigned URLs handed out for share links stay valid. A
+// zero duration means share links are always streamed through the HTTP server.
+func (s ShareLink) GetPresignedURLExpiresInDuration() (time.Duration, error) {
+	if s.PresignedURLExpiresIn == "" {
+		return 0, nil
+	}
+
+	return time.ParseDuration(s.PresignedURLExpiresIn)
+}
+
+// GetPasswordFailureLimit returns how many incorrect passwords a share link accepts within the failure window,
+// after which it refuses every password until the window ends.
+func (s ShareLink) GetPasswordFailureLimit() uint64 {
+	if s.PasswordFailureLimit == 0 {
+		return defaultShareLinkPasswordFailureLimit
+	}
+
+	return s.PasswordFailureLimit
+}
+
+// GetPasswordFailureWindowDuration returns how long the incorrect passwords given to a share link are counted,
+// starting from the first one.
+func (s ShareLink) GetPasswordFailureWindowDuration() (time.Duration, error) {
+	if s.PasswordFailureWindow == "" {
+		return defaultShareLinkPasswordFailureWindow, nil
+	}
+
+	return time.ParseDuration(s.PasswordFailureWindow)
+}
+
+const (
+	defaultHTTPMonitoringAddress = "127.0.0.1:8082"
+)
+
+// HTTP configures the HTTP server of the API, listening on Address, and the monitoring HTTP server of the
+// processes doing the background work, listening on MonitoringAddress.
 type HTTP struct {
-	Address string `yaml:"address"`
-}
\ No newline at end of file
+	Address           string    `yaml:"address"`
+	MonitoringAddress string    `yaml:"monitoring_address"`
+	ShareLink         ShareLink `yaml:"share_link"`
+}
+
+func (h HTTP) GetMonitoringAddress() string {
+	if h.MonitoringAddress == "" {
+		return defaultHTTPMonitoringAddress
+	}
+
+	return h.MonitoringAddress
+}
@@ -1,7 +1,109 @@
 package config
 
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	defaultOutboxPollInterval = time.Second
+	defaultOutboxBatchSize    = 100
+	defaultOutboxRetention    = 24 * time.Hour
+)
+
+// Outbox configures the relay of the outbox messages to the message queue. The messages sent are kept for
+// Retention, to look into what was published, then deleted.
+type Outbox struct {
+	PollInterval string `yaml:"poll_interval"`
+	BatchSize    uint64 `yaml:"batch_size"`
+	Retention    string `yaml:"retention"`
+}
+
+func (o Outbox) GetPollIntervalDuration() (time.Duration, error) {
+	if o.PollInterval == "" {
+		return defaultOutboxPollInterval, nil
+	}
+
+	return time.ParseDuration(o.PollInterval)
+}
+
+func (o Outbox) GetBatchSize() uint64 {
+	if o.BatchSize == 0 {
+		return defaultOutboxBatchSize
+	}
+
+	return o.BatchSize
+}
+
+func (o Outbox) GetRetentionDuration() (time.Duration, error) {
+	if o.Retention == "" {
+		return defaultOutboxRetention, nil
+	}
+
+	return time.ParseDuration(o.Retention)
+}
+
+type KafkaRebalanceStrategy string
+
+const (
+	KafkaRebalanceStrategyRange      KafkaRebalanceStrategy = "range"
+	KafkaRebalanceStrategyRoundRobin KafkaRebalanceStrategy = "round_robin"
+	KafkaRebalanceStrategySticky     KafkaRebalanceStrategy = "sticky"
+)
+
+type KafkaConsumer struct {
+	GroupId           string                 `yaml:"group_id"`
+	RebalanceStrategy KafkaRebalanceStrategy `yaml:"rebalance_strategy"`
+}
+
+// GetGroupId returns the consumer group id, falling back to the client id so that all the workers of a
+// deployment join the same group by default.
+func (k KafkaConsumer) GetGroupId(clientId string) string {
+	if k.GroupId == "" {
+		return clientId
+	}
+
+	return k.GroupId
+}
+
+// KafkaRetryPolicy describes how the failed messages of a topic are retried. A failed message goes through
+// one retry topic per delay, in order, then ends up in the dead letter topic.
+type KafkaRetryPolicy struct {
+	Delays          []string `yaml:"delays"`
+	DeadLetterTopic string   `yaml:"dead_letter_topic"`
+}
+
+func (k KafkaRetryPolicy) GetDelayDurations() ([]time.Duration, error) {
+	delayDurations := make([]time.Duration, 0, len(k.Delays))
+	for _, delay := range k.Delays {
+		delayDuration, err := time.ParseDuration(delay)
+		if err != nil {
+			return nil, err
+		}
+
+		delayDurations = append(delayDurations, delayDuration)
+	}
+
+	return delayDurations, nil
+}
+
+func (k KafkaRetryPolicy) GetRetryTopic(topic string, attempt int) string {
+	return fmt.Sprintf("%s.retry.%s", topic, k.Delays[attempt])
+}
+
+func (k KafkaRetryPolicy) GetDeadLetterTopic(topic string) string {
+	if k.DeadLetterTopic == "" {
+		return topic + ".dlq"
+	}
+
+	return k.DeadLetterTopic
+}
+
 type Kafka struct {
-	Host string `yaml:"host"`
-	Port string `yaml:"port"`
-	ClientId string `yaml:"client_id"`
-}
\ No newline at end of file
+	Host          string                      `yaml:"host"`
+	Port          string                      `yaml:"port"`
+	ClientId      string                      `yaml:"client_id"`
+	Outbox        Outbox                      `yaml:"outbox"`
+	Consumer      KafkaConsumer               `yaml:"consumer"`
+	RetryPolicies map[string]KafkaRetryPolicy `yaml:"retry_policies"`
+}
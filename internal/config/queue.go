@@ -0,0 +1,86 @@
+package config
+
+import "time"
+
+type QueueType string
+
+const (
+	QueueTypeKafka     QueueType = "kafka"
+	QueueTypeInProcess QueueType = "in_process"
+	QueueTypeRedis     QueueType = "redis"
+)
+
+const (
+	defaultInProcessQueueBufferSize  = 1024
+	defaultRedisQueueBlockTimeout    = 5 * time.Second
+	defaultRedisQueueReclaimInterval = 30 * time.Second
+	defaultRedisQueueReclaimIdleTime = time.Minute
+	defaultRedisQueueMaxLength       = 100000
+)
+
+type InProcessQueue struct {
+	BufferSize uint64 `yaml:"buffer_size"`
+}
+
+func (i InProcessQueue) GetBufferSize() uint64 {
+	if i.BufferSize == 0 {
+		return defaultInProcessQueueBufferSize
+	}
+
+	return i.BufferSize
+}
+
+// RedisQueue configures the Redis Streams backend. Each topic is a stream, consumed through a consumer group
+// named after the Kafka consumer group id. Entries left pending by a crashed consumer for longer than
+// ReclaimIdleTime are claimed by another one every ReclaimInterval, while a live consumer refreshes the idle
+// time of the entries it is still handling every half ReclaimIdleTime. Streams are trimmed to about MaxLength
+// entries as messages are added, the oldest first, whether they were consumed or not.
+type RedisQueue struct {
+	Address         string `yaml:"address"`
+	Username        string `yaml:"username"`
+	Password        string `yaml:"password"`
+	BlockTimeout    string `yaml:"block_timeout"`
+	ReclaimInterval string `yaml:"reclaim_interval"`
+	ReclaimIdleTime string `yaml:"reclaim_idle_time"`
+	MaxLength       uint64 `yaml:"max_length"`
+}
+
+func (r RedisQueue) GetBlockTimeoutDuration() (time.Duration, error) {
+	if r.BlockTimeout == "" {
+		return defaultRedisQueueBlockTimeout, nil
+	}
+
+	return time.ParseDuration(r.BlockTimeout)
+}
+
+func (r RedisQueue) GetReclaimIntervalDuration() (time.Duration, error) {
+	if r.ReclaimInterval == "" {
+		return defaultRedisQueueReclaimInterval, nil
+	}
+
+	return time.ParseDuration(r.ReclaimInterval)
+}
+
+func (r RedisQueue) GetReclaimIdleTimeDuration() (time.Duration, error) {
+	if r.ReclaimIdleTime == "" {
+		return defaultRedisQueueReclaimIdleTime, nil
+	}
+
+	return time.ParseDuration(r.ReclaimIdleTime)
+}
+
+func (r RedisQueue) GetMaxLength() uint64 {
+	if r.MaxLength == 0 {
+		return defaultRedisQueueMaxLength
+	}
+
+	return r.MaxLength
+}
+
+// Queue selects the message queue backend. The consumer group id and the retry policies of the kafka config
+// apply to every backend.
+type Queue struct {
+	Type      QueueType      `yaml:"type"`
+	InProcess InProcessQueue `yaml:"in_process"`
+	Redis     RedisQueue     `yaml:"redis"`
+}
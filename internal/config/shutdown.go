@@ -0,0 +1,21 @@
+package config
+
+import "time"
+
+const (
+	defaultShutdownTimeout = 30 * time.Second
+)
+
+// Shutdown configures the stop of the server on SIGINT or SIGTERM. Pending requests and messages being handled
+// are given Timeout to finish, after which they are canceled.
+type Shutdown struct {
+	Timeout string `yaml:"timeout"`
+}
+
+func (s Shutdown) GetTimeoutDuration() (time.Duration, error) {
+	if s.Timeout == "" {
+		return defaultShutdownTimeout, nil
+	}
+
+	return time.ParseDuration(s.Timeout)
+}
@@ -11,5 +11,7 @@ var WireSet = wire.NewSet(
 	wire.FieldsOf(new(Config), "Database"),
 	wire.FieldsOf(new(Config), "Cache"),
 	wire.FieldsOf(new(Config), "Kafka"),
+	wire.FieldsOf(new(Config), "Queue"),
 	wire.FieldsOf(new(Config), "Download"),
+	wire.FieldsOf(new(Config), "Shutdown"),
 )
\ No newline at end of file
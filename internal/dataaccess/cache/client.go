@@ -24,21 +24,27 @@ type CacheClient interface {
 	Get(ctx context.Context, key string) (any, error)
 	AddToSet(ctx context.Context, key string, data ...any) error
 	IsDataInSet(ctx context.Context, key string, data any) (bool, error)
+	// IncreaseCounter increases the counter of key by one and returns its new value. The counter expires ttl
+	// after its first increase.
+	IncreaseCounter(ctx context.Context, key string, ttl time.Duration) (int64, error)
+	// GetCounter returns the value of the counter of key, zero if it does not exist or has expired.
+	GetCounter(ctx context.Context, key string) (int64, error)
 }
 
 func NewCacheClient(
 	cacheConfig config.Cache,
 	logger *zap.Logger,
-) (CacheClient, error) {
+) (CacheClient, func(), error) {
 	switch cacheConfig.Type {
 	case config.CacheTypeInMemory:
-		return NewInMemoryClient(logger), nil
+		return NewInMemoryClient(logger), func() {}, nil
 
 	case config.CacheTypeRedis:
-		return NewRedisClient(cacheConfig, logger), nil
+		cacheClient, cleanupFunc := NewRedisClient(cacheConfig, logger)
+		return cacheClient, cleanupFunc, nil
 
 	default:
-		return nil, fmt.Errorf("unsupported cache type: %s", cacheConfig.Type)
+		return nil, nil, fmt.Errorf("unsupported cache type: %s", cacheConfig.Type)
 	}
 }
 
@@ -50,15 +56,23 @@ type redisClient struct {
 func NewRedisClient(
 	cacheConfig config.Cache,
 	logger *zap.Logger,
-) CacheClient {
-	return &redisClient{
-		redisClient: redis.NewClient(&redis.Options{
-			Addr:     cacheConfig.Address,
-			Username: cacheConfig.Username,
-			Password: cacheConfig.Password,
-		}),
-		logger: logger,
+) (CacheClient, func()) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     cacheConfig.Address,
+		Username: cacheConfig.Username,
+		Password: cacheConfig.Password,
+	})
+
+	cleanupFunc := func() {
+		if err := client.Close(); err != nil {
+			logger.With(zap.Error(err)).Error("failed to close redis cache client")
+		}
 	}
+
+	return &redisClient{
+		redisClient: client,
+		logger:      logger,
+	}, cleanupFunc
 }
 
 // AddToSet implements CacheClient.
@@ -123,6 +137,46 @@ func (c *redisClient) Set(ctx context.Context, key string, data any, ttl time.Du
 	return nil
 }
 
+// IncreaseCounter implements CacheClient.
+func (c *redisClient) IncreaseCounter(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	logger := utils.LoggerWithContext(ctx, c.logger).
+		With(zap.String("key", key)).
+		With(zap.Duration("ttl", ttl))
+
+	value, err := c.redisClient.Incr(ctx, key).Result()
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to increase counter inside cache")
+		return 0, status.Errorf(codes.Internal, "failed to increase counter inside cache: %+v", err)
+	}
+
+	if value == 1 {
+		if err = c.redisClient.Expire(ctx, key, ttl).Err(); err != nil {
+			logger.With(zap.Error(err)).Error("failed to set ttl of counter inside cache")
+			return 0, status.Errorf(codes.Internal, "failed to set ttl of counter inside cache: %+v", err)
+		}
+	}
+
+	return value, nil
+}
+
+// GetCounter implements CacheClient.
+func (c *redisClient) GetCounter(ctx context.Context, key string) (int64, error) {
+	logger := utils.LoggerWithContext(ctx, c.logger).
+		With(zap.String("key", key))
+
+	value, err := c.redisClient.Get(ctx, key).Int64()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, nil
+		}
+
+		logger.With(zap.Error(err)).Error("failed to get counter from cache")
+		return 0, status.Errorf(codes.Internal, "failed to get counter from cache: %+v", err)
+	}
+
+	return value, nil
+}
+
 type inMemoryClient struct {
 	cache      map[string]any
 	cacheMutex *sync.Mutex
@@ -191,3 +245,35 @@ func (c inMemoryClient) getSet(key string) []any {
 
 	return set
 }
+
+// inMemoryCounter is a counter of the in memory cache, which unlike the other data does expire.
+type inMemoryCounter struct {
+	value      int64
+	expireTime time.Time
+}
+
+func (c inMemoryClient) IncreaseCounter(_ context.Context, key string, ttl time.Duration) (int64, error) {
+	c.cacheMutex.Lock()
+	defer c.cacheMutex.Unlock()
+
+	counter, ok := c.cache[key].(inMemoryCounter)
+	if !ok || time.Now().After(counter.expireTime) {
+		counter = inMemoryCounter{expireTime: time.Now().Add(ttl)}
+	}
+
+	counter.value++
+	c.cache[key] = counter
+	return counter.value, nil
+}
+
+func (c inMemoryClient) GetCounter(_ context.Context, key string) (int64, error) {
+	c.cacheMutex.Lock()
+	defer c.cacheMutex.Unlock()
+
+	counter, ok := c.cache[key].(inMemoryCounter)
+	if !ok || time.Now().After(counter.expireTime) {
+		return 0, nil
+	}
+
+	return counter.value, nil
+}
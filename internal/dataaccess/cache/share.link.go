@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/manhhung2111/go-idm/internal/utils"
+	"go.uber.org/zap"
+)
+
+const (
+	shareLinkPasswordFailureKeyFormat = "go.idm:share.link.password.failure.count:%d"
+)
+
+// ShareLinkPasswordFailureCache counts the incorrect passwords given to a share link, for the passwords of the
+// link not to be guessed by trying them all.
+type ShareLinkPasswordFailureCache interface {
+	Increase(ctx context.Context, shareLinkID uint64, window time.Duration) (uint64, error)
+	Get(ctx context.Context, shareLinkID uint64) (uint64, error)
+}
+
+type shareLinkPasswordFailureCache struct {
+	client CacheClient
+	logger *zap.Logger
+}
+
+func NewShareLinkPasswordFailureCache(
+	client CacheClient,
+	logger *zap.Logger,
+) ShareLinkPasswordFailureCache {
+	return &shareLinkPasswordFailureCache{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Increase implements ShareLinkPasswordFailureCache.
+func (s *shareLinkPasswordFailureCache) Increase(
+	ctx context.Context,
+	shareLinkID uint64,
+	window time.Duration,
+) (uint64, error) {
+	logger := utils.LoggerWithContext(ctx, s.logger).With(zap.Uint64("share_link_id", shareLinkID))
+
+	count, err := s.client.IncreaseCounter(ctx, fmt.Sprintf(shareLinkPasswordFailureKeyFormat, shareLinkID), window)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to increase share link password failure count in cache")
+		return 0, err
+	}
+
+	return uint64(count), nil
+}
+
+// Get implements ShareLinkPasswordFailureCache.
+func (s *shareLinkPasswordFailureCache) Get(ctx context.Context, shareLinkID uint64) (uint64, error) {
+	logger := utils.LoggerWithContext(ctx, s.logger).With(zap.Uint64("share_link_id", shareLinkID))
+
+	count, err := s.client.GetCounter(ctx, fmt.Sprintf(shareLinkPasswordFailureKeyFormat, shareLinkID))
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to get share link password failure count from cache")
+		return 0, err
+	}
+
+	return uint64(count), nil
+}
@@ -3,6 +3,7 @@ package cache
 import "github.com/google/wire"
 
 var WireSet = wire.NewSet(
-	NewRedisClient,
+	NewCacheClient,
 	NewAccountNameCache,
+	NewShareLinkPasswordFailureCache,
 )
\ No newline at end of file
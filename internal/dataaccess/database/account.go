@@ -33,36 +33,38 @@ type AccountDataAccessor interface {
 }
 
 type accountDataAccessor struct {
-	database IDatabase
-	logger   *zap.Logger
+	database            IDatabase
+	readReplicaDatabase *ReadReplicaDatabase
+	logger              *zap.Logger
 }
 
-func NewAccountDataAccessor(database *goqu.Database, logger *zap.Logger) AccountDataAccessor {
+func NewAccountDataAccessor(
+	database *goqu.Database,
+	readReplicaDatabase *ReadReplicaDatabase,
+	logger *zap.Logger,
+) AccountDataAccessor {
 	return &accountDataAccessor{
-		database: database,
-		logger:   logger,
+		database:            database,
+		readReplicaDatabase: readReplicaDatabase,
+		logger:              logger,
 	}
 }
 
 // CreateAccount implements AccountDataAccessor.
 func (a *accountDataAccessor) CreateAccount(ctx context.Context, account Account) (uint64, error) {
 	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("account", account))
+	markWritten(ctx)
 
-	result, err := a.database.Insert(tableNameAccounts).Rows(goqu.Record{
+	id, err := insertAndGetId(ctx, a.database.Insert(tableNameAccounts).Rows(goqu.Record{
 		colNameAccountsAccountName: account.AccountName,
-	}).Executor().ExecContext(ctx)
+	}), colNameAccountsID)
 
 	if err != nil {
 		logger.With(zap.Error(err)).Error("failed to create account")
 		return 0, status.Errorf(codes.Internal, "failed to create account: %+v", err)
 	}
 
-	lastInsertedId, err := result.LastInsertId()
-	if err != nil {
-		logger.With(zap.Error(err)).Error("failed to get last inserted id")
-		return 0, status.Errorf(codes.Internal, "failed to get last inserted id: %+v", err)
-	}
-	return uint64(lastInsertedId), nil
+	return id, nil
 }
 
 // WithDatabase implements AccountDataAccessor.
@@ -78,7 +80,7 @@ func (a *accountDataAccessor) GetAccountById(ctx context.Context, id uint64) (Ac
 	logger := utils.LoggerWithContext(ctx, a.logger)
 
 	account := Account{}
-	found, err := a.database.
+	found, err := readDatabase(ctx, a.database, a.readReplicaDatabase).
 		From(tableNameAccounts).
 		Where(goqu.Ex{colNameAccountsID: id}).
 		ScanStructContext(ctx, &account)
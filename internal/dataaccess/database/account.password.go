@@ -48,6 +48,7 @@ func NewAccountPasswordDataAccessor(database *goqu.Database, logger *zap.Logger)
 // CreateAccountPassword implements AccountPasswordDataAccessor.
 func (a *accountPasswordDataAccessor) CreateAccountPassword(ctx context.Context, accountPassword AccountPassword) error {
 	logger := utils.LoggerWithContext(ctx, a.logger)
+	markWritten(ctx)
 
 	_, err := a.database.
 		Insert(tableNameAccountPasswords).
@@ -67,6 +68,7 @@ func (a *accountPasswordDataAccessor) CreateAccountPassword(ctx context.Context,
 // UpdateAccountPassword implements AccountPasswordDataAccessor.
 func (a *accountPasswordDataAccessor) UpdateAccountPassword(ctx context.Context, accountPassword AccountPassword) error {
 	logger := utils.LoggerWithContext(ctx, a.logger)
+	markWritten(ctx)
 
 	_, err := a.database.
 		Update(tableNameAccountPasswords).
@@ -0,0 +1,106 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/manhhung2111/go-idm/internal/config"
+)
+
+// AdvisoryLock is a named lock shared by the processes of a database. It is a session lock, held by a
+// connection reserved for it until it is released, or until the connection is lost. SQLite has no such lock,
+// a database file is rarely shared by several processes, so the lock is always acquired there.
+type AdvisoryLock struct {
+	connection   *sql.Conn
+	databaseType config.DatabaseType
+	name         string
+	postgresKey  int64
+}
+
+// TryAcquireAdvisoryLock acquires the lock of name, or of postgresKey with PostgreSQL where advisory locks are
+// identified by a number, without waiting. It returns false if the lock is held by another session.
+func TryAcquireAdvisoryLock(
+	ctx context.Context,
+	db *sql.DB,
+	databaseConfig config.Database,
+	name string,
+	postgresKey int64,
+) (*AdvisoryLock, bool, error) {
+	databaseType := databaseConfig.Type
+	if databaseType == "" {
+		databaseType = config.DatabaseTypeMySQL
+	}
+
+	if databaseType == config.DatabaseTypeSQLite {
+		return &AdvisoryLock{databaseType: databaseType, name: name}, true, nil
+	}
+
+	connection, err := db.Conn(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var acquired bool
+	switch databaseType {
+	case config.DatabaseTypeMySQL:
+		var result sql.NullInt64
+		err = connection.QueryRowContext(ctx, "SELECT GET_LOCK(?, 0)", name).Scan(&result)
+		acquired = result.Int64 == 1
+
+	case config.DatabaseTypePostgres:
+		err = connection.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", postgresKey).Scan(&acquired)
+
+	default:
+		err = fmt.Errorf("unsupported database type: %s", databaseType)
+	}
+
+	if err != nil || !acquired {
+		_ = connection.Close()
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to acquire advisory lock %s: %w", name, err)
+		}
+
+		return nil, false, nil
+	}
+
+	return &AdvisoryLock{
+		connection:   connection,
+		databaseType: databaseType,
+		name:         name,
+		postgresKey:  postgresKey,
+	}, true, nil
+}
+
+// IsHeld returns whether the connection holding the lock is still alive, and so whether the lock is still
+// held.
+func (a *AdvisoryLock) IsHeld(ctx context.Context) bool {
+	if a.connection == nil {
+		return true
+	}
+
+	return a.connection.PingContext(ctx) == nil
+}
+
+// Release releases the lock and the connection holding it.
+func (a *AdvisoryLock) Release(ctx context.Context) error {
+	if a.connection == nil {
+		return nil
+	}
+
+	defer a.connection.Close()
+
+	var err error
+	switch a.databaseType {
+	case config.DatabaseTypeMySQL:
+		_, err = a.connection.ExecContext(ctx, "SELECT RELEASE_LOCK(?)", a.name)
+	case config.DatabaseTypePostgres:
+		_, err = a.connection.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", a.postgresKey)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to release advisory lock %s: %w", a.name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/manhhung2111/go-idm/internal/utils"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	tableNameAuditEvents = goqu.T("audit_events")
+)
+
+const (
+	ColNameAuditEventId             = "audit_event_id"
+	ColNameAuditEventActorAccountId = "actor_account_id"
+	ColNameAuditEventAction         = "action"
+	ColNameAuditEventResourceType   = "resource_type"
+	ColNameAuditEventResourceId     = "resource_id"
+	ColNameAuditEventBeforeValue    = "before_value"
+	ColNameAuditEventAfterValue     = "after_value"
+	ColNameAuditEventIPAddress      = "ip_address"
+	ColNameAuditEventRequestId      = "request_id"
+	ColNameAuditEventCreatedTime    = "created_time"
+)
+
+// AuditEvent records a change made to a resource: who made it, from where and in which request, along with
+// the values of the resource before and after it. ActorAccountID is nil for the changes made by no account,
+// and BeforeValue is empty for the resources created, AfterValue for the ones deleted.
+type AuditEvent struct {
+	ID             uint64    `db:"audit_event_id" goqu:"skipinsert"`
+	ActorAccountID *uint64   `db:"actor_account_id"`
+	Action         string    `db:"action"`
+	ResourceType   string    `db:"resource_type"`
+	ResourceID     uint64    `db:"resource_id"`
+	BeforeValue    JSON      `db:"before_value"`
+	AfterValue     JSON      `db:"after_value"`
+	IPAddress      string    `db:"ip_address"`
+	RequestID      string    `db:"request_id"`
+	CreatedTime    time.Time `db:"created_time"`
+}
+
+// AuditEventFilter selects audit events. The fields left nil or empty match every audit event.
+type AuditEventFilter struct {
+	ActorAccountID *uint64
+	ResourceType   string
+	ResourceID     *uint64
+}
+
+// AuditEventDataAccessor accesses the audit trail. Audit events are only ever appended, so that the trail
+// can be trusted, and are best created in the transaction of the change they record.
+type AuditEventDataAccessor interface {
+	CreateAuditEvent(ctx context.Context, auditEvent AuditEvent) (uint64, error)
+	// GetAuditEventList returns the audit events matching the filter, the latest first.
+	GetAuditEventList(ctx context.Context, filter AuditEventFilter, offset, limit uint64) ([]AuditEvent, error)
+	GetAuditEventCount(ctx context.Context, filter AuditEventFilter) (uint64, error)
+	WithDatabase(database IDatabase) AuditEventDataAccessor
+}
+
+type auditEventDataAccessor struct {
+	database            IDatabase
+	readReplicaDatabase *ReadReplicaDatabase
+	logger              *zap.Logger
+}
+
+func NewAuditEventDataAccessor(
+	database *goqu.Database,
+	readReplicaDatabase *ReadReplicaDatabase,
+	logger *zap.Logger,
+) AuditEventDataAccessor {
+	return &auditEventDataAccessor{
+		database:            database,
+		readReplicaDatabase: readReplicaDatabase,
+		logger:              logger,
+	}
+}
+
+// CreateAuditEvent implements AuditEventDataAccessor.
+func (a *auditEventDataAccessor) CreateAuditEvent(ctx context.Context, auditEvent AuditEvent) (uint64, error) {
+	logger := utils.LoggerWithContext(ctx, a.logger).
+		With(zap.String("action", auditEvent.Action)).
+		With(zap.Uint64("resource_id", auditEvent.ResourceID))
+	markWritten(ctx)
+
+	id, err := insertAndGetId(ctx, a.database.
+		Insert(tableNameAuditEvents).
+		Rows(auditEvent), ColNameAuditEventId)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to create audit event")
+		return 0, status.Errorf(codes.Internal, "failed to create audit event")
+	}
+
+	return id, nil
+}
+
+func (a auditEventDataAccessor) filterExpression(filter AuditEventFilter) goqu.Ex {
+	expression := goqu.Ex{}
+	if filter.ActorAccountID != nil {
+		expression[ColNameAuditEventActorAccountId] = *filter.ActorAccountID
+	}
+
+	if filter.ResourceType != "" {
+		expression[ColNameAuditEventResourceType] = filter.ResourceType
+	}
+
+	if filter.ResourceID != nil {
+		expression[ColNameAuditEventResourceId] = *filter.ResourceID
+	}
+
+	return expression
+}
+
+// GetAuditEventList implements AuditEventDataAccessor.
+func (a *auditEventDataAccessor) GetAuditEventList(
+	ctx context.Context,
+	filter AuditEventFilter,
+	offset uint64,
+	limit uint64,
+) ([]AuditEvent, error) {
+	logger := utils.LoggerWithContext(ctx, a.logger).
+		With(zap.Any("filter", filter)).
+		With(zap.Uint64("offset", offset)).
+		With(zap.Uint64("limit", limit))
+
+	auditEventList := make([]AuditEvent, 0)
+	if err := readDatabase(ctx, a.database, a.readReplicaDatabase).
+		Select().
+		From(tableNameAuditEvents).
+		Where(a.filterExpression(filter)).
+		Order(goqu.C(ColNameAuditEventId).Desc()).
+		Offset(uint(offset)).
+		Limit(uint(limit)).
+		Executor().
+		ScanStructsContext(ctx, &auditEventList); err != nil {
+		logger.With(zap.Error(err)).Error("failed to get audit event list")
+		return nil, status.Errorf(codes.Internal, "failed to get audit event list")
+	}
+
+	return auditEventList, nil
+}
+
+// GetAuditEventCount implements AuditEventDataAccessor.
+func (a *auditEventDataAccessor) GetAuditEventCount(ctx context.Context, filter AuditEventFilter) (uint64, error) {
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("filter", filter))
+
+	count, err := readDatabase(ctx, a.database, a.readReplicaDatabase).
+		From(tableNameAuditEvents).
+		Where(a.filterExpression(filter)).
+		CountContext(ctx)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to count audit events")
+		return 0, status.Errorf(codes.Internal, "failed to count audit events")
+	}
+
+	return uint64(count), nil
+}
+
+// WithDatabase implements AuditEventDataAccessor.
+func (a *auditEventDataAccessor) WithDatabase(database IDatabase) AuditEventDataAccessor {
+	return &auditEventDataAccessor{
+		database: database,
+		logger:   a.logger,
+	}
+}
@@ -2,16 +2,29 @@ package database
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"database/sql"
 	"fmt"
 	"log"
+	"math"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
 
 	"github.com/doug-martin/goqu/v9"
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-sql-driver/mysql"
 	_ "github.com/doug-martin/goqu/v9/dialect/mysql"
+	_ "github.com/doug-martin/goqu/v9/dialect/postgres"
+	_ "github.com/lib/pq"
 	"github.com/manhhung2111/go-idm/internal/config"
 )
 
+const (
+	dialectPostgres = "postgres"
+)
+
 type IDatabase interface {
 	Delete(table interface{}) *goqu.DeleteDataset
 	Dialect() string
@@ -40,21 +53,43 @@ type IDatabase interface {
 	Update(table interface{}) *goqu.UpdateDataset
 }
 
-func InitializeDB(config config.Database) (*sql.DB, func(), error) {
-	connectionString := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s",
-		config.Username,
-		config.Password,
-		config.Host,
-		config.Port,
-		config.Database,
+// InitializeDB opens the database of the config and checks that it is reachable, so that a process pointed at
+// a wrong or unreachable database fails as it starts rather than on its first query.
+func InitializeDB(databaseConfig config.Database) (*sql.DB, func(), error) {
+	var (
+		db  *sql.DB
+		err error
 	)
 
-	db, err := sql.Open("mysql", connectionString)
+	switch databaseConfig.Type {
+	case config.DatabaseTypeMySQL, "":
+		db, err = openMySQLDB(databaseConfig)
+
+	case config.DatabaseTypePostgres:
+		db, err = openPostgresDB(databaseConfig)
+
+	case config.DatabaseTypeSQLite:
+		db, err = openSQLiteDB(databaseConfig)
+
+	default:
+		return nil, nil, fmt.Errorf("unsupported database type: %s", databaseConfig.Type)
+	}
+
 	if err != nil {
 		log.Printf("Error when connecting to the database: %+v\n", err)
 		return nil, nil, err
 	}
 
+	if err = configureDBPool(db, databaseConfig.Pool); err != nil {
+		db.Close()
+		return nil, nil, err
+	}
+
+	if err = pingDB(db, databaseConfig); err != nil {
+		db.Close()
+		return nil, nil, err
+	}
+
 	cleanupFunc := func() {
 		db.Close()
 	}
@@ -62,6 +97,227 @@ func InitializeDB(config config.Database) (*sql.DB, func(), error) {
 	return db, cleanupFunc, nil
 }
 
-func InitializeGoquDB(db *sql.DB) *goqu.Database {
-	return goqu.New("mysql", db)
+func configureDBPool(db *sql.DB, poolConfig config.DatabasePool) error {
+	connectionMaxLifetime, err := poolConfig.GetConnectionMaxLifetimeDuration()
+	if err != nil {
+		return fmt.Errorf("invalid database connection max lifetime: %w", err)
+	}
+
+	connectionMaxIdleTime, err := poolConfig.GetConnectionMaxIdleTimeDuration()
+	if err != nil {
+		return fmt.Errorf("invalid database connection max idle time: %w", err)
+	}
+
+	db.SetMaxOpenConns(poolConfig.GetMaxOpenConnections())
+	db.SetMaxIdleConns(poolConfig.GetMaxIdleConnections())
+	db.SetConnMaxLifetime(connectionMaxLifetime)
+	db.SetConnMaxIdleTime(connectionMaxIdleTime)
+
+	return nil
+}
+
+func pingDB(db *sql.DB, databaseConfig config.Database) error {
+	dialTimeout, err := databaseConfig.GetDialTimeoutDuration()
+	if err != nil {
+		return fmt.Errorf("invalid database dial timeout: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+	defer cancel()
+
+	if err = db.PingContext(ctx); err != nil {
+		if databaseConfig.Type == config.DatabaseTypeSQLite {
+			return fmt.Errorf("failed to open the sqlite database %s: %w", databaseConfig.SQLite.GetPath(), err)
+		}
+
+		return fmt.Errorf(
+			"failed to connect to the database %s at %s:%d: %w",
+			databaseConfig.Database, databaseConfig.Host, databaseConfig.Port, err,
+		)
+	}
+
+	return nil
+}
+
+func openMySQLDB(databaseConfig config.Database) (*sql.DB, error) {
+	dialTimeout, err := databaseConfig.GetDialTimeoutDuration()
+	if err != nil {
+		return nil, fmt.Errorf("invalid database dial timeout: %w", err)
+	}
+
+	readTimeout, err := databaseConfig.GetReadTimeoutDuration()
+	if err != nil {
+		return nil, fmt.Errorf("invalid database read timeout: %w", err)
+	}
+
+	writeTimeout, err := databaseConfig.GetWriteTimeoutDuration()
+	if err != nil {
+		return nil, fmt.Errorf("invalid database write timeout: %w", err)
+	}
+
+	mysqlConfig := mysql.NewConfig()
+	mysqlConfig.User = databaseConfig.Username
+	mysqlConfig.Passwd = databaseConfig.Password
+	mysqlConfig.Net = "tcp"
+	mysqlConfig.Addr = net.JoinHostPort(databaseConfig.Host, strconv.Itoa(databaseConfig.Port))
+	mysqlConfig.DBName = databaseConfig.Database
+	mysqlConfig.ParseTime = true
+	mysqlConfig.Timeout = dialTimeout
+	mysqlConfig.ReadTimeout = readTimeout
+	mysqlConfig.WriteTimeout = writeTimeout
+
+	// The params go through the parsing of the connection string, which tells the options of the driver from
+	// the system variables to set on each connection.
+	if len(databaseConfig.Params) > 0 {
+		params := url.Values{}
+		for key, value := range databaseConfig.Params {
+			params.Set(key, value)
+		}
+
+		// ParseTime is set, so the connection string already has a query.
+		mysqlConfig, err = mysql.ParseDSN(mysqlConfig.FormatDSN() + "&" + params.Encode())
+		if err != nil {
+			return nil, fmt.Errorf("invalid database params: %w", err)
+		}
+	}
+
+	if databaseConfig.TLS.Enabled {
+		mysqlConfig.TLS, err = newDBTLSConfig(databaseConfig.TLS, databaseConfig.Host)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	connector, err := mysql.NewConnector(mysqlConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return sql.OpenDB(connector), nil
+}
+
+// openPostgresDB opens a PostgreSQL database. The driver has no read and write timeouts, statement_timeout
+// can be set through the params to bound the queries instead, and verifies the server certificate for the
+// host it connects to, so the TLS server name is not used.
+func openPostgresDB(databaseConfig config.Database) (*sql.DB, error) {
+	dialTimeout, err := databaseConfig.GetDialTimeoutDuration()
+	if err != nil {
+		return nil, fmt.Errorf("invalid database dial timeout: %w", err)
+	}
+
+	params := url.Values{}
+	params.Set("connect_timeout", strconv.Itoa(int(math.Ceil(dialTimeout.Seconds()))))
+
+	tlsConfig := databaseConfig.TLS
+	switch {
+	case !tlsConfig.Enabled:
+		params.Set("sslmode", "disable")
+	case tlsConfig.InsecureSkipVerify:
+		params.Set("sslmode", "require")
+	default:
+		params.Set("sslmode", "verify-full")
+	}
+
+	if tlsConfig.Enabled {
+		if tlsConfig.CAFile != "" {
+			params.Set("sslrootcert", tlsConfig.CAFile)
+		}
+
+		if tlsConfig.CertFile != "" {
+			params.Set("sslcert", tlsConfig.CertFile)
+			params.Set("sslkey", tlsConfig.KeyFile)
+		}
+	}
+
+	for key, value := range databaseConfig.Params {
+		params.Set(key, value)
+	}
+
+	connectionURL := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(databaseConfig.Username, databaseConfig.Password),
+		Host:     net.JoinHostPort(databaseConfig.Host, strconv.Itoa(databaseConfig.Port)),
+		Path:     databaseConfig.Database,
+		RawQuery: params.Encode(),
+	}
+
+	return sql.Open("postgres", connectionURL.String())
+}
+
+// newDBTLSConfig builds the TLS config of the connections to host.
+func newDBTLSConfig(tlsConfig config.DatabaseTLS, host string) (*tls.Config, error) {
+	result := &tls.Config{
+		ServerName:         host,
+		InsecureSkipVerify: tlsConfig.InsecureSkipVerify, //nolint:gosec // Opted into by the config.
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	if tlsConfig.ServerName != "" {
+		result.ServerName = tlsConfig.ServerName
+	}
+
+	if tlsConfig.CAFile != "" {
+		caCertificate, err := os.ReadFile(tlsConfig.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read database ca file: %w", err)
+		}
+
+		result.RootCAs = x509.NewCertPool()
+		if !result.RootCAs.AppendCertsFromPEM(caCertificate) {
+			return nil, fmt.Errorf("no certificate found in database ca file %s", tlsConfig.CAFile)
+		}
+	}
+
+	if tlsConfig.CertFile != "" {
+		clientCertificate, err := tls.LoadX509KeyPair(tlsConfig.CertFile, tlsConfig.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load database client certificate: %w", err)
+		}
+
+		result.Certificates = []tls.Certificate{clientCertificate}
+	}
+
+	return result, nil
+}
+
+func InitializeGoquDB(db *sql.DB, databaseConfig config.Database) *goqu.Database {
+	switch databaseConfig.Type {
+	case config.DatabaseTypePostgres:
+		return goqu.New(dialectPostgres, db)
+
+	case config.DatabaseTypeSQLite:
+		return goqu.New(dialectSQLite, db)
+
+	default:
+		return goqu.New("mysql", db)
+	}
+}
+
+// insertAndGetId inserts a single row and returns the id generated for it. The id is read back with a
+// RETURNING clause on PostgreSQL, which has no last insert id, and with LastInsertId on the other databases,
+// which do not all support RETURNING.
+func insertAndGetId(ctx context.Context, insertDataset *goqu.InsertDataset, idColumnName string) (uint64, error) {
+	if insertDataset.Dialect().Dialect() == dialectPostgres {
+		var id uint64
+		if _, err := insertDataset.
+			Returning(goqu.C(idColumnName)).
+			Executor().
+			ScanValContext(ctx, &id); err != nil {
+			return 0, err
+		}
+
+		return id, nil
+	}
+
+	result, err := insertDataset.Executor().ExecContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	lastInsertedId, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(lastInsertedId), nil
 }
@@ -2,17 +2,20 @@ package database
 
 import (
 	"context"
+	"time"
 
 	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
 	go_idm_v1 "github.com/manhhung2111/go-idm/internal/generated/proto"
 	"github.com/manhhung2111/go-idm/internal/utils"
+	"github.com/samber/lo"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 var (
-	tableNameDownloadTasks = goqu.T("download_tasks")
+	tableNameDownloadTasks  = goqu.T("download_tasks")
 	ErrDownloadTaskNotFound = status.Error(codes.NotFound, "download task not found")
 )
 
@@ -23,6 +26,10 @@ const (
 	ColNameDownloadTaskURL            = "url"
 	ColNameDownloadTaskDownloadStatus = "download_status"
 	ColNameDownloadTaskMetadata       = "metadata"
+	ColNameDownloadTaskOfTeamId       = "of_team_id"
+	ColNameDownloadTaskWorkerId       = "worker_id"
+	ColNameDownloadTaskHeartbeatTime  = "heartbeat_time"
+	ColNameDownloadTaskDeletedTime    = "deleted_time"
 )
 
 type DownloadTask struct {
@@ -32,60 +39,86 @@ type DownloadTask struct {
 	URL            string                   `db:"url"`
 	DownloadStatus go_idm_v1.DownloadStatus `db:"download_status"`
 	Metadata       JSON                     `db:"metadata"`
+	OfTeamID       *uint64                  `db:"of_team_id" goqu:"skipupdate"`
+	WorkerID       *string                  `db:"worker_id" goqu:"skipinsert,skipupdate"`
+	HeartbeatTime  *time.Time               `db:"heartbeat_time" goqu:"skipinsert,skipupdate"`
+	DeletedTime    *time.Time               `db:"deleted_time" goqu:"skipinsert,skipupdate"`
+}
+
+// IsTrashed returns whether the download task was deleted and is waiting in the trash to be purged.
+func (d DownloadTask) IsTrashed() bool {
+	return d.DeletedTime != nil
 }
 
 type DownloadTaskDataAccessor interface {
 	CreateDownloadTask(ctx context.Context, downloadTask DownloadTask) (uint64, error)
 	GetDownloadTaskListOfAccount(ctx context.Context, accountId, offset, limit uint64) ([]DownloadTask, error)
 	GetDownloadTaskCountOfAccount(ctx context.Context, accountId uint64) (uint64, error)
+	GetTrashedDownloadTaskListOfAccount(ctx context.Context, accountId, offset, limit uint64) ([]DownloadTask, error)
+	GetTrashedDownloadTaskCountOfAccount(ctx context.Context, accountId uint64) (uint64, error)
 	GetDownloadTask(ctx context.Context, id uint64) (DownloadTask, error)
+	// GetDownloadTaskListOfStatusAfterId returns the download tasks with downloadStatus whose id is greater than
+	// afterId, in the order of their ids, to go through all of them batch by batch. The download tasks in the
+	// trash are left out unless includeTrashed is true.
+	GetDownloadTaskListOfStatusAfterId(
+		ctx context.Context,
+		downloadStatus go_idm_v1.DownloadStatus,
+		includeTrashed bool,
+		afterId uint64,
+		limit uint64,
+	) ([]DownloadTask, error)
+	// GetDownloadTaskListOfIdList returns the download tasks of the ids in idList that exist, trashed or not.
+	GetDownloadTaskListOfIdList(ctx context.Context, idList []uint64) ([]DownloadTask, error)
 	GetDownloadTaskWithXLock(ctx context.Context, id uint64) (DownloadTask, error)
 	UpdateDownloadTask(ctx context.Context, downloadTask DownloadTask) error
+	UpdateDownloadTaskLease(ctx context.Context, id uint64, workerId *string, heartbeatTime *time.Time) error
+	RenewDownloadTaskLease(ctx context.Context, id uint64, workerId string, heartbeatTime time.Time) (bool, error)
+	GetExpiredLeaseDownloadTaskListWithXLock(ctx context.Context, heartbeatTimeBefore time.Time, limit uint64) ([]DownloadTask, error)
+	UpdateDownloadTaskDeletedTime(ctx context.Context, id uint64, deletedTime *time.Time) error
+	GetExpiredTrashedDownloadTaskListWithXLock(ctx context.Context, deletedTimeBefore time.Time, limit uint64) ([]DownloadTask, error)
 	DeleteDownloadTask(ctx context.Context, id uint64) error
 	WithDatabase(database IDatabase) DownloadTaskDataAccessor
 }
 
 type downloadTaskDataAccessor struct {
-	database IDatabase
-	logger   *zap.Logger
+	database            IDatabase
+	readReplicaDatabase *ReadReplicaDatabase
+	logger              *zap.Logger
 }
 
 func NewDownloadTaskDataAccessor(
 	database *goqu.Database,
+	readReplicaDatabase *ReadReplicaDatabase,
 	logger *zap.Logger,
 ) DownloadTaskDataAccessor {
 	return &downloadTaskDataAccessor{
-		database: database,
-		logger:   logger,
+		database:            database,
+		readReplicaDatabase: readReplicaDatabase,
+		logger:              logger,
 	}
 }
 
 // CreateDownloadTask implements DownloadTaskDataAccessor.
 func (d *downloadTaskDataAccessor) CreateDownloadTask(ctx context.Context, downloadTask DownloadTask) (uint64, error) {
 	logger := utils.LoggerWithContext(ctx, d.logger).With(zap.Any("downloadTask", downloadTask))
+	markWritten(ctx)
 
-	result, err := d.database.
+	id, err := insertAndGetId(ctx, d.database.
 		Insert(tableNameDownloadTasks).
-		Rows(downloadTask).
-		Executor().
-		ExecContext(ctx)
+		Rows(downloadTask), ColNameDownloadTaskId)
 	if err != nil {
 		logger.With(zap.Error(err)).Error("failed to create download task")
 		return 0, status.Errorf(codes.Internal, "failed to create download task")
 	}
 
-	lastInsertedId, err := result.LastInsertId()
-	if err != nil {
-		logger.With(zap.Error(err)).Error("failed to get last inserted id")
-		return 0, status.Errorf(codes.Internal, "failed to get last inserted id")
-	}
-
-	return uint64(lastInsertedId), nil
+	return id, nil
 }
 
-// DeleteDownloadTask implements DownloadTaskDataAccessor.
+// DeleteDownloadTask deletes the row of a download task for good. Deleting a download task from the API
+// only moves it to the trash, with UpdateDownloadTaskDeletedTime.
 func (d *downloadTaskDataAccessor) DeleteDownloadTask(ctx context.Context, id uint64) error {
 	logger := utils.LoggerWithContext(ctx, d.logger).With(zap.Uint64("id", id))
+	markWritten(ctx)
 
 	if _, err := d.database.
 		Delete(tableNameDownloadTasks).
@@ -99,12 +132,68 @@ func (d *downloadTaskDataAccessor) DeleteDownloadTask(ctx context.Context, id ui
 	return nil
 }
 
+// visibleToAccountExpression matches the download tasks an account can see: the ones it owns, the ones
+// created inside a team it is a member of, and the ones shared with it directly.
+func (d downloadTaskDataAccessor) visibleToAccountExpression(accountId uint64) goqu.Expression {
+	return goqu.Or(
+		goqu.C(ColNameDownloadTaskOfAccountId).Eq(accountId),
+		goqu.C(ColNameDownloadTaskOfTeamId).In(
+			d.database.
+				From(tableNameTeamMembers).
+				Select(ColNameTeamMemberOfTeamId).
+				Where(goqu.Ex{ColNameTeamMemberOfAccountId: accountId}),
+		),
+		goqu.C(ColNameDownloadTaskId).In(
+			d.database.
+				From(tableNameDownloadTaskShares).
+				Select(ColNameDownloadTaskShareOfDownloadTaskId).
+				Where(goqu.Ex{ColNameDownloadTaskShareOfAccountId: accountId}),
+		),
+	)
+}
+
+// trashVisibleToAccountExpression matches the download tasks in the trash of an account: the ones it owns,
+// and the ones created inside a team it is an admin or owner of. The ones it can only see as a plain team
+// member or through a share are left out.
+func (d downloadTaskDataAccessor) trashVisibleToAccountExpression(accountId uint64) goqu.Expression {
+	return goqu.Or(
+		goqu.C(ColNameDownloadTaskOfAccountId).Eq(accountId),
+		goqu.C(ColNameDownloadTaskOfTeamId).In(
+			d.database.
+				From(tableNameTeamMembers).
+				Select(ColNameTeamMemberOfTeamId).
+				Where(goqu.Ex{
+					ColNameTeamMemberOfAccountId: accountId,
+					ColNameTeamMemberTeamRole:    []go_idm_v1.TeamRole{go_idm_v1.TeamRole_Admin, go_idm_v1.TeamRole_Owner},
+				}),
+		),
+	)
+}
+
 func (d downloadTaskDataAccessor) GetDownloadTaskCountOfAccount(ctx context.Context, accountId uint64) (uint64, error) {
+	return d.getDownloadTaskCountOfAccount(
+		ctx, accountId, d.visibleToAccountExpression(accountId), goqu.C(ColNameDownloadTaskDeletedTime).IsNull(),
+	)
+}
+
+// GetTrashedDownloadTaskCountOfAccount implements DownloadTaskDataAccessor.
+func (d downloadTaskDataAccessor) GetTrashedDownloadTaskCountOfAccount(ctx context.Context, accountId uint64) (uint64, error) {
+	return d.getDownloadTaskCountOfAccount(
+		ctx, accountId, d.trashVisibleToAccountExpression(accountId), goqu.C(ColNameDownloadTaskDeletedTime).IsNotNull(),
+	)
+}
+
+func (d downloadTaskDataAccessor) getDownloadTaskCountOfAccount(
+	ctx context.Context,
+	accountId uint64,
+	visibilityExpression goqu.Expression,
+	deletedTimeExpression goqu.Expression,
+) (uint64, error) {
 	logger := utils.LoggerWithContext(ctx, d.logger).With(zap.Uint64("account_id", accountId))
 
-	count, err := d.database.
+	count, err := readDatabase(ctx, d.database, d.readReplicaDatabase).
 		From(tableNameDownloadTasks).
-		Where(goqu.Ex{ColNameDownloadTaskOfAccountId: accountId}).
+		Where(visibilityExpression, deletedTimeExpression).
 		CountContext(ctx)
 	if err != nil {
 		logger.With(zap.Error(err)).Error("failed to count download task of user")
@@ -116,16 +205,41 @@ func (d downloadTaskDataAccessor) GetDownloadTaskCountOfAccount(ctx context.Cont
 
 // GetDownloadTaskList implements DownloadTaskDataAccessor.
 func (d *downloadTaskDataAccessor) GetDownloadTaskListOfAccount(ctx context.Context, accountId uint64, offset uint64, limit uint64) ([]DownloadTask, error) {
+	return d.getDownloadTaskListOfAccount(
+		ctx, accountId, offset, limit, d.visibleToAccountExpression(accountId),
+		goqu.C(ColNameDownloadTaskDeletedTime).IsNull(), goqu.C(ColNameDownloadTaskId).Asc(),
+	)
+}
+
+// GetTrashedDownloadTaskListOfAccount implements DownloadTaskDataAccessor. The download tasks deleted last
+// come first.
+func (d *downloadTaskDataAccessor) GetTrashedDownloadTaskListOfAccount(ctx context.Context, accountId uint64, offset uint64, limit uint64) ([]DownloadTask, error) {
+	return d.getDownloadTaskListOfAccount(
+		ctx, accountId, offset, limit, d.trashVisibleToAccountExpression(accountId),
+		goqu.C(ColNameDownloadTaskDeletedTime).IsNotNull(), goqu.C(ColNameDownloadTaskDeletedTime).Desc(),
+	)
+}
+
+func (d *downloadTaskDataAccessor) getDownloadTaskListOfAccount(
+	ctx context.Context,
+	accountId uint64,
+	offset uint64,
+	limit uint64,
+	visibilityExpression goqu.Expression,
+	deletedTimeExpression goqu.Expression,
+	order exp.OrderedExpression,
+) ([]DownloadTask, error) {
 	logger := utils.LoggerWithContext(ctx, d.logger).
 		With(zap.Uint64("account_id", accountId)).
 		With(zap.Uint64("offset", offset)).
 		With(zap.Uint64("limit", limit))
 
 	downloadTaskList := make([]DownloadTask, 0)
-	if err := d.database.
+	if err := readDatabase(ctx, d.database, d.readReplicaDatabase).
 		Select().
 		From(tableNameDownloadTasks).
-		Where(goqu.Ex{ColNameDownloadTaskOfAccountId: accountId}).
+		Where(visibilityExpression, deletedTimeExpression).
+		Order(order).
 		Offset(uint(offset)).
 		Limit(uint(limit)).
 		Executor().
@@ -141,7 +255,7 @@ func (d downloadTaskDataAccessor) GetDownloadTask(ctx context.Context, id uint64
 	logger := utils.LoggerWithContext(ctx, d.logger).With(zap.Uint64("id", id))
 
 	downloadTask := DownloadTask{}
-	found, err := d.database.
+	found, err := readDatabase(ctx, d.database, d.readReplicaDatabase).
 		Select().
 		From(tableNameDownloadTasks).
 		Where(goqu.Ex{ColNameDownloadTaskId: id}).
@@ -159,6 +273,67 @@ func (d downloadTaskDataAccessor) GetDownloadTask(ctx context.Context, id uint64
 	return downloadTask, nil
 }
 
+// GetDownloadTaskListOfStatusAfterId implements DownloadTaskDataAccessor.
+func (d downloadTaskDataAccessor) GetDownloadTaskListOfStatusAfterId(
+	ctx context.Context,
+	downloadStatus go_idm_v1.DownloadStatus,
+	includeTrashed bool,
+	afterId uint64,
+	limit uint64,
+) ([]DownloadTask, error) {
+	logger := utils.LoggerWithContext(ctx, d.logger).
+		With(zap.Any("download_status", downloadStatus)).
+		With(zap.Bool("include_trashed", includeTrashed)).
+		With(zap.Uint64("after_id", afterId)).
+		With(zap.Uint64("limit", limit))
+
+	expressionList := []goqu.Expression{
+		goqu.C(ColNameDownloadTaskDownloadStatus).Eq(downloadStatus),
+		goqu.C(ColNameDownloadTaskId).Gt(afterId),
+	}
+
+	if !includeTrashed {
+		expressionList = append(expressionList, goqu.C(ColNameDownloadTaskDeletedTime).IsNull())
+	}
+
+	downloadTaskList := make([]DownloadTask, 0)
+	if err := d.database.
+		Select().
+		From(tableNameDownloadTasks).
+		Where(expressionList...).
+		Order(goqu.C(ColNameDownloadTaskId).Asc()).
+		Limit(uint(limit)).
+		Executor().
+		ScanStructsContext(ctx, &downloadTaskList); err != nil {
+		logger.With(zap.Error(err)).Error("failed to get download task list of status")
+		return nil, status.Errorf(codes.Internal, "failed to get download task list of status")
+	}
+
+	return downloadTaskList, nil
+}
+
+// GetDownloadTaskListOfIdList implements DownloadTaskDataAccessor.
+func (d downloadTaskDataAccessor) GetDownloadTaskListOfIdList(ctx context.Context, idList []uint64) ([]DownloadTask, error) {
+	logger := utils.LoggerWithContext(ctx, d.logger).With(zap.Int("id_count", len(idList)))
+
+	downloadTaskList := make([]DownloadTask, 0, len(idList))
+	if len(idList) == 0 {
+		return downloadTaskList, nil
+	}
+
+	if err := d.database.
+		Select().
+		From(tableNameDownloadTasks).
+		Where(goqu.C(ColNameDownloadTaskId).In(idList)).
+		Executor().
+		ScanStructsContext(ctx, &downloadTaskList); err != nil {
+		logger.With(zap.Error(err)).Error("failed to get download task list of id list")
+		return nil, status.Errorf(codes.Internal, "failed to get download task list of id list")
+	}
+
+	return downloadTaskList, nil
+}
+
 func (d downloadTaskDataAccessor) GetDownloadTaskWithXLock(ctx context.Context, id uint64) (DownloadTask, error) {
 	logger := utils.LoggerWithContext(ctx, d.logger).With(zap.Uint64("id", id))
 
@@ -183,8 +358,9 @@ func (d downloadTaskDataAccessor) GetDownloadTaskWithXLock(ctx context.Context,
 }
 
 // UpdateDownloadTask implements DownloadTaskDataAccessor.
-func (d *downloadTaskDataAccessor) UpdateDownloadTask(ctx context.Context, downloadTask DownloadTask)  error {
+func (d *downloadTaskDataAccessor) UpdateDownloadTask(ctx context.Context, downloadTask DownloadTask) error {
 	logger := utils.LoggerWithContext(ctx, d.logger).With(zap.Any("task", downloadTask))
+	markWritten(ctx)
 
 	if _, err := d.database.
 		Update(tableNameDownloadTasks).
@@ -199,9 +375,172 @@ func (d *downloadTaskDataAccessor) UpdateDownloadTask(ctx context.Context, downl
 	return nil
 }
 
+// UpdateDownloadTaskLease sets the worker holding the lease of a download task and the time of its last
+// heartbeat. Lease columns are never written by UpdateDownloadTask, so that saving a download task read
+// before a heartbeat does not roll the heartbeat back.
+func (d *downloadTaskDataAccessor) UpdateDownloadTaskLease(
+	ctx context.Context,
+	id uint64,
+	workerId *string,
+	heartbeatTime *time.Time,
+) error {
+	logger := utils.LoggerWithContext(ctx, d.logger).With(zap.Uint64("id", id))
+	markWritten(ctx)
+
+	if _, err := d.database.
+		Update(tableNameDownloadTasks).
+		Set(goqu.Record{
+			ColNameDownloadTaskWorkerId:      workerId,
+			ColNameDownloadTaskHeartbeatTime: heartbeatTime,
+		}).
+		Where(goqu.Ex{ColNameDownloadTaskId: id}).
+		Executor().
+		ExecContext(ctx); err != nil {
+		logger.With(zap.Error(err)).Error("failed to update download task lease")
+		return status.Errorf(codes.Internal, "failed to update download task lease")
+	}
+
+	return nil
+}
+
+// RenewDownloadTaskLease moves the heartbeat of a download task forward, returning false without changing
+// anything if the lease is no longer held by workerId, for example because it expired and was reaped.
+func (d *downloadTaskDataAccessor) RenewDownloadTaskLease(
+	ctx context.Context,
+	id uint64,
+	workerId string,
+	heartbeatTime time.Time,
+) (bool, error) {
+	logger := utils.LoggerWithContext(ctx, d.logger).
+		With(zap.Uint64("id", id)).
+		With(zap.String("worker_id", workerId))
+	markWritten(ctx)
+
+	result, err := d.database.
+		Update(tableNameDownloadTasks).
+		Set(goqu.Record{ColNameDownloadTaskHeartbeatTime: heartbeatTime}).
+		Where(goqu.Ex{
+			ColNameDownloadTaskId:             id,
+			ColNameDownloadTaskWorkerId:       workerId,
+			ColNameDownloadTaskDownloadStatus: go_idm_v1.DownloadStatus_Downloading,
+		}).
+		Executor().
+		ExecContext(ctx)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to renew download task lease")
+		return false, status.Errorf(codes.Internal, "failed to renew download task lease")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to get rows affected")
+		return false, status.Errorf(codes.Internal, "failed to get rows affected")
+	}
+
+	// The heartbeat time is stored with a precision of one second, renewing a lease twice within the same
+	// second does not change the row. The row is read back from the primary, a replica may lag behind the
+	// update that took the lease.
+	if rowsAffected == 0 {
+		downloadTask, err := d.WithDatabase(d.database).GetDownloadTask(ctx, id)
+		if err != nil {
+			return false, err
+		}
+
+		return downloadTask.DownloadStatus == go_idm_v1.DownloadStatus_Downloading &&
+			lo.FromPtr(downloadTask.WorkerID) == workerId, nil
+	}
+
+	return true, nil
+}
+
+// GetExpiredLeaseDownloadTaskListWithXLock returns the downloading tasks whose last heartbeat is older than
+// heartbeatTimeBefore, skipping the ones already locked by another reaper.
+func (d *downloadTaskDataAccessor) GetExpiredLeaseDownloadTaskListWithXLock(
+	ctx context.Context,
+	heartbeatTimeBefore time.Time,
+	limit uint64,
+) ([]DownloadTask, error) {
+	logger := utils.LoggerWithContext(ctx, d.logger).
+		With(zap.Time("heartbeat_time_before", heartbeatTimeBefore)).
+		With(zap.Uint64("limit", limit))
+
+	downloadTaskList := make([]DownloadTask, 0)
+	if err := d.database.
+		Select().
+		From(tableNameDownloadTasks).
+		Where(
+			goqu.C(ColNameDownloadTaskDownloadStatus).Eq(go_idm_v1.DownloadStatus_Downloading),
+			goqu.Or(
+				goqu.C(ColNameDownloadTaskHeartbeatTime).IsNull(),
+				goqu.C(ColNameDownloadTaskHeartbeatTime).Lt(heartbeatTimeBefore),
+			),
+		).
+		Order(goqu.C(ColNameDownloadTaskHeartbeatTime).Asc()).
+		Limit(uint(limit)).
+		ForUpdate(goqu.SkipLocked).
+		Executor().
+		ScanStructsContext(ctx, &downloadTaskList); err != nil {
+		logger.With(zap.Error(err)).Error("failed to get expired lease download task list")
+		return nil, status.Errorf(codes.Internal, "failed to get expired lease download task list")
+	}
+
+	return downloadTaskList, nil
+}
+
+// UpdateDownloadTaskDeletedTime moves a download task to the trash, or restores it from the trash when
+// deletedTime is nil.
+func (d *downloadTaskDataAccessor) UpdateDownloadTaskDeletedTime(
+	ctx context.Context,
+	id uint64,
+	deletedTime *time.Time,
+) error {
+	logger := utils.LoggerWithContext(ctx, d.logger).With(zap.Uint64("id", id))
+	markWritten(ctx)
+
+	if _, err := d.database.
+		Update(tableNameDownloadTasks).
+		Set(goqu.Record{ColNameDownloadTaskDeletedTime: deletedTime}).
+		Where(goqu.Ex{ColNameDownloadTaskId: id}).
+		Executor().
+		ExecContext(ctx); err != nil {
+		logger.With(zap.Error(err)).Error("failed to update download task deleted time")
+		return status.Errorf(codes.Internal, "failed to update download task deleted time")
+	}
+
+	return nil
+}
+
+// GetExpiredTrashedDownloadTaskListWithXLock returns the download tasks moved to the trash before
+// deletedTimeBefore, skipping the ones already locked by another purger.
+func (d *downloadTaskDataAccessor) GetExpiredTrashedDownloadTaskListWithXLock(
+	ctx context.Context,
+	deletedTimeBefore time.Time,
+	limit uint64,
+) ([]DownloadTask, error) {
+	logger := utils.LoggerWithContext(ctx, d.logger).
+		With(zap.Time("deleted_time_before", deletedTimeBefore)).
+		With(zap.Uint64("limit", limit))
+
+	downloadTaskList := make([]DownloadTask, 0)
+	if err := d.database.
+		Select().
+		From(tableNameDownloadTasks).
+		Where(goqu.C(ColNameDownloadTaskDeletedTime).Lt(deletedTimeBefore)).
+		Order(goqu.C(ColNameDownloadTaskDeletedTime).Asc()).
+		Limit(uint(limit)).
+		ForUpdate(goqu.SkipLocked).
+		Executor().
+		ScanStructsContext(ctx, &downloadTaskList); err != nil {
+		logger.With(zap.Error(err)).Error("failed to get expired trashed download task list")
+		return nil, status.Errorf(codes.Internal, "failed to get expired trashed download task list")
+	}
+
+	return downloadTaskList, nil
+}
+
 func (d *downloadTaskDataAccessor) WithDatabase(database IDatabase) DownloadTaskDataAccessor {
 	return &downloadTaskDataAccessor{
 		database: database,
-		logger: d.logger,
+		logger:   d.logger,
 	}
 }
@@ -0,0 +1,129 @@
+package database
+
+import (
+	"context"
+
+	"github.com/doug-martin/goqu/v9"
+	go_idm_v1 "github.com/manhhung2111/go-idm/internal/generated/proto"
+	"github.com/manhhung2111/go-idm/internal/utils"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	tableNameDownloadTaskShares  = goqu.T("download_task_shares")
+	ErrDownloadTaskShareNotFound = status.Error(codes.NotFound, "download task share not found")
+)
+
+const (
+	ColNameDownloadTaskShareOfDownloadTaskId = "of_download_task_id"
+	ColNameDownloadTaskShareOfAccountId      = "of_account_id"
+	ColNameDownloadTaskShareShareLevel       = "share_level"
+)
+
+type DownloadTaskShare struct {
+	OfDownloadTaskID uint64                           `db:"of_download_task_id" goqu:"skipupdate"`
+	OfAccountID      uint64                           `db:"of_account_id" goqu:"skipupdate"`
+	ShareLevel       go_idm_v1.DownloadTaskShareLevel `db:"share_level"`
+}
+
+type DownloadTaskShareDataAccessor interface {
+	UpsertDownloadTaskShare(ctx context.Context, downloadTaskShare DownloadTaskShare) error
+	GetDownloadTaskShare(ctx context.Context, downloadTaskId, accountId uint64) (DownloadTaskShare, error)
+	DeleteDownloadTaskShare(ctx context.Context, downloadTaskId, accountId uint64) error
+	WithDatabase(database IDatabase) DownloadTaskShareDataAccessor
+}
+
+type downloadTaskShareDataAccessor struct {
+	database IDatabase
+	logger   *zap.Logger
+}
+
+func NewDownloadTaskShareDataAccessor(
+	database *goqu.Database,
+	logger *zap.Logger,
+) DownloadTaskShareDataAccessor {
+	return &downloadTaskShareDataAccessor{
+		database: database,
+		logger:   logger,
+	}
+}
+
+// UpsertDownloadTaskShare implements DownloadTaskShareDataAccessor.
+func (d *downloadTaskShareDataAccessor) UpsertDownloadTaskShare(ctx context.Context, downloadTaskShare DownloadTaskShare) error {
+	logger := utils.LoggerWithContext(ctx, d.logger).With(zap.Any("download_task_share", downloadTaskShare))
+	markWritten(ctx)
+
+	if _, err := d.database.
+		Insert(tableNameDownloadTaskShares).
+		Rows(downloadTaskShare).
+		OnConflict(goqu.DoUpdate(
+			ColNameDownloadTaskShareOfDownloadTaskId+", "+ColNameDownloadTaskShareOfAccountId,
+			goqu.Record{ColNameDownloadTaskShareShareLevel: downloadTaskShare.ShareLevel},
+		)).
+		Executor().
+		ExecContext(ctx); err != nil {
+		logger.With(zap.Error(err)).Error("failed to upsert download task share")
+		return status.Errorf(codes.Internal, "failed to upsert download task share")
+	}
+
+	return nil
+}
+
+// GetDownloadTaskShare implements DownloadTaskShareDataAccessor.
+func (d *downloadTaskShareDataAccessor) GetDownloadTaskShare(ctx context.Context, downloadTaskId, accountId uint64) (DownloadTaskShare, error) {
+	logger := utils.LoggerWithContext(ctx, d.logger).
+		With(zap.Uint64("download_task_id", downloadTaskId)).
+		With(zap.Uint64("account_id", accountId))
+
+	downloadTaskShare := DownloadTaskShare{}
+	found, err := d.database.
+		Select().
+		From(tableNameDownloadTaskShares).
+		Where(goqu.Ex{
+			ColNameDownloadTaskShareOfDownloadTaskId: downloadTaskId,
+			ColNameDownloadTaskShareOfAccountId:      accountId,
+		}).
+		ScanStructContext(ctx, &downloadTaskShare)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to get download task share")
+		return DownloadTaskShare{}, status.Errorf(codes.Internal, "failed to get download task share")
+	}
+
+	if !found {
+		return DownloadTaskShare{}, ErrDownloadTaskShareNotFound
+	}
+
+	return downloadTaskShare, nil
+}
+
+// DeleteDownloadTaskShare implements DownloadTaskShareDataAccessor.
+func (d *downloadTaskShareDataAccessor) DeleteDownloadTaskShare(ctx context.Context, downloadTaskId, accountId uint64) error {
+	logger := utils.LoggerWithContext(ctx, d.logger).
+		With(zap.Uint64("download_task_id", downloadTaskId)).
+		With(zap.Uint64("account_id", accountId))
+	markWritten(ctx)
+
+	if _, err := d.database.
+		Delete(tableNameDownloadTaskShares).
+		Where(goqu.Ex{
+			ColNameDownloadTaskShareOfDownloadTaskId: downloadTaskId,
+			ColNameDownloadTaskShareOfAccountId:      accountId,
+		}).
+		Executor().
+		ExecContext(ctx); err != nil {
+		logger.With(zap.Error(err)).Error("failed to delete download task share")
+		return status.Errorf(codes.Internal, "failed to delete download task share")
+	}
+
+	return nil
+}
+
+// WithDatabase implements DownloadTaskShareDataAccessor.
+func (d *downloadTaskShareDataAccessor) WithDatabase(database IDatabase) DownloadTaskShareDataAccessor {
+	return &downloadTaskShareDataAccessor{
+		database: database,
+		logger:   d.logger,
+	}
+}
@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS teams (
+	team_id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+	team_name VARCHAR(256) NOT NULL
+) ENGINE = InnoDB;
+
+CREATE TABLE IF NOT EXISTS team_members (
+	of_team_id BIGINT UNSIGNED NOT NULL,
+	of_account_id BIGINT UNSIGNED NOT NULL,
+	team_role SMALLINT NOT NULL,
+	PRIMARY KEY (of_team_id, of_account_id),
+	FOREIGN KEY (of_team_id) REFERENCES teams (team_id),
+	FOREIGN KEY (of_account_id) REFERENCES accounts (id)
+) ENGINE = InnoDB;
+
+ALTER TABLE download_tasks
+	ADD COLUMN of_team_id BIGINT UNSIGNED NULL,
+	ADD FOREIGN KEY (of_team_id) REFERENCES teams (team_id);
+
+CREATE TABLE IF NOT EXISTS download_task_shares (
+	of_download_task_id BIGINT UNSIGNED NOT NULL,
+	of_account_id BIGINT UNSIGNED NOT NULL,
+	share_level SMALLINT NOT NULL,
+	PRIMARY KEY (of_download_task_id, of_account_id),
+	FOREIGN KEY (of_download_task_id) REFERENCES download_tasks (task_id) ON DELETE CASCADE,
+	FOREIGN KEY (of_account_id) REFERENCES accounts (id)
+) ENGINE = InnoDB;
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS outbox_messages (
+	outbox_message_id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+	topic VARCHAR(256) NOT NULL,
+	payload MEDIUMBLOB NOT NULL,
+	created_time DATETIME NOT NULL,
+	sent_time DATETIME NULL,
+	INDEX (sent_time, outbox_message_id)
+) ENGINE = InnoDB;
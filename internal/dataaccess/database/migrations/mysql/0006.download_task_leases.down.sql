@@ -0,0 +1,4 @@
+ALTER TABLE download_tasks
+	DROP INDEX download_status,
+	DROP COLUMN heartbeat_time,
+	DROP COLUMN worker_id;
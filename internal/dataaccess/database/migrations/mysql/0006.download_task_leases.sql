@@ -0,0 +1,4 @@
+ALTER TABLE download_tasks
+	ADD COLUMN worker_id VARCHAR(256) NULL,
+	ADD COLUMN heartbeat_time DATETIME NULL,
+	ADD INDEX (download_status, heartbeat_time);
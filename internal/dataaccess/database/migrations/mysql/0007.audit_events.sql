@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS audit_events (
+	audit_event_id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+	actor_account_id BIGINT UNSIGNED NULL,
+	action VARCHAR(64) NOT NULL,
+	resource_type VARCHAR(64) NOT NULL,
+	resource_id BIGINT UNSIGNED NOT NULL,
+	before_value TEXT NULL,
+	after_value TEXT NULL,
+	ip_address VARCHAR(64) NOT NULL,
+	request_id VARCHAR(128) NOT NULL,
+	created_time DATETIME NOT NULL,
+	INDEX (actor_account_id, audit_event_id),
+	INDEX (resource_type, resource_id, audit_event_id)
+) ENGINE = InnoDB;
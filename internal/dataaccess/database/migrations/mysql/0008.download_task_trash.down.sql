@@ -0,0 +1,3 @@
+ALTER TABLE download_tasks
+	DROP INDEX deleted_time,
+	DROP COLUMN deleted_time;
@@ -0,0 +1,3 @@
+ALTER TABLE download_tasks
+	ADD COLUMN deleted_time DATETIME NULL,
+	ADD INDEX (deleted_time);
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS accounts (
+	id BIGSERIAL PRIMARY KEY,
+	account_name VARCHAR(256) UNIQUE NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS account_passwords (
+	of_account_id BIGINT PRIMARY KEY,
+	hashed_password VARCHAR(128) NOT NULL,
+	FOREIGN KEY (of_account_id) REFERENCES accounts (id)
+);
+
+CREATE TABLE IF NOT EXISTS download_tasks (
+	task_id BIGSERIAL PRIMARY KEY,
+	of_account_id BIGINT NOT NULL,
+	download_type SMALLINT NOT NULL,
+	url TEXT NOT NULL,
+	download_status SMALLINT NOT NULL,
+	metadata JSONB NOT NULL,
+	FOREIGN KEY (of_account_id) REFERENCES accounts (id)
+);
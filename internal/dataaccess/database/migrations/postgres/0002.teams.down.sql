@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS download_task_shares;
+
+ALTER TABLE download_tasks
+	DROP COLUMN of_team_id;
+
+DROP TABLE IF EXISTS team_members;
+
+DROP TABLE IF EXISTS teams;
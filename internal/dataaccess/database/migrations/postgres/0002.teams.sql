@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS teams (
+	team_id BIGSERIAL PRIMARY KEY,
+	team_name VARCHAR(256) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS team_members (
+	of_team_id BIGINT NOT NULL,
+	of_account_id BIGINT NOT NULL,
+	team_role SMALLINT NOT NULL,
+	PRIMARY KEY (of_team_id, of_account_id),
+	FOREIGN KEY (of_team_id) REFERENCES teams (team_id),
+	FOREIGN KEY (of_account_id) REFERENCES accounts (id)
+);
+
+ALTER TABLE download_tasks
+	ADD COLUMN of_team_id BIGINT NULL REFERENCES teams (team_id);
+
+CREATE TABLE IF NOT EXISTS download_task_shares (
+	of_download_task_id BIGINT NOT NULL,
+	of_account_id BIGINT NOT NULL,
+	share_level SMALLINT NOT NULL,
+	PRIMARY KEY (of_download_task_id, of_account_id),
+	FOREIGN KEY (of_download_task_id) REFERENCES download_tasks (task_id) ON DELETE CASCADE,
+	FOREIGN KEY (of_account_id) REFERENCES accounts (id)
+);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS outbox_messages (
+	outbox_message_id BIGSERIAL PRIMARY KEY,
+	topic VARCHAR(256) NOT NULL,
+	payload BYTEA NOT NULL,
+	created_time TIMESTAMPTZ NOT NULL,
+	sent_time TIMESTAMPTZ NULL
+);
+
+CREATE INDEX IF NOT EXISTS outbox_messages_sent_time ON outbox_messages (sent_time, outbox_message_id);
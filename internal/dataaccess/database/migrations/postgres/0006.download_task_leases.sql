@@ -0,0 +1,6 @@
+ALTER TABLE download_tasks
+	ADD COLUMN worker_id VARCHAR(256) NULL,
+	ADD COLUMN heartbeat_time TIMESTAMPTZ NULL;
+
+CREATE INDEX IF NOT EXISTS download_tasks_download_status_heartbeat_time
+	ON download_tasks (download_status, heartbeat_time);
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS audit_events (
+	audit_event_id BIGSERIAL PRIMARY KEY,
+	actor_account_id BIGINT NULL,
+	action VARCHAR(64) NOT NULL,
+	resource_type VARCHAR(64) NOT NULL,
+	resource_id BIGINT NOT NULL,
+	before_value TEXT NULL,
+	after_value TEXT NULL,
+	ip_address VARCHAR(64) NOT NULL,
+	request_id VARCHAR(128) NOT NULL,
+	created_time TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS audit_events_actor_account_id
+	ON audit_events (actor_account_id, audit_event_id);
+
+CREATE INDEX IF NOT EXISTS audit_events_resource_type_resource_id
+	ON audit_events (resource_type, resource_id, audit_event_id);
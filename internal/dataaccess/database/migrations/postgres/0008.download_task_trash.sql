@@ -0,0 +1,5 @@
+ALTER TABLE download_tasks
+	ADD COLUMN deleted_time TIMESTAMPTZ NULL;
+
+CREATE INDEX IF NOT EXISTS download_tasks_deleted_time
+	ON download_tasks (deleted_time);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS download_tasks;
+
+DROP TABLE IF EXISTS account_passwords;
+
+DROP TABLE IF EXISTS accounts;
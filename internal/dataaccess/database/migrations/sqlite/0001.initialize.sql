@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS accounts (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	account_name VARCHAR(256) UNIQUE NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS account_passwords (
+	of_account_id INTEGER PRIMARY KEY,
+	hashed_password VARCHAR(128) NOT NULL,
+	FOREIGN KEY (of_account_id) REFERENCES accounts (id)
+);
+
+CREATE TABLE IF NOT EXISTS download_tasks (
+	task_id INTEGER PRIMARY KEY AUTOINCREMENT,
+	of_account_id INTEGER NOT NULL,
+	download_type SMALLINT NOT NULL,
+	url TEXT NOT NULL,
+	download_status SMALLINT NOT NULL,
+	metadata TEXT NOT NULL,
+	FOREIGN KEY (of_account_id) REFERENCES accounts (id)
+);
@@ -0,0 +1,23 @@
+DROP TABLE IF EXISTS download_task_shares;
+
+-- SQLite can not drop a column referencing another table, so download_tasks is rebuilt without it.
+CREATE TABLE download_tasks_without_team (
+	task_id INTEGER PRIMARY KEY AUTOINCREMENT,
+	of_account_id INTEGER NOT NULL,
+	download_type SMALLINT NOT NULL,
+	url TEXT NOT NULL,
+	download_status SMALLINT NOT NULL,
+	metadata TEXT NOT NULL,
+	FOREIGN KEY (of_account_id) REFERENCES accounts (id)
+);
+
+INSERT INTO download_tasks_without_team (task_id, of_account_id, download_type, url, download_status, metadata)
+	SELECT task_id, of_account_id, download_type, url, download_status, metadata FROM download_tasks;
+
+DROP TABLE download_tasks;
+
+ALTER TABLE download_tasks_without_team RENAME TO download_tasks;
+
+DROP TABLE IF EXISTS team_members;
+
+DROP TABLE IF EXISTS teams;
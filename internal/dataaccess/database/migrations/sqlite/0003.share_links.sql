@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS share_links (
+	share_link_id INTEGER PRIMARY KEY AUTOINCREMENT,
+	of_download_task_id INTEGER NOT NULL,
+	of_account_id INTEGER NOT NULL,
+	hashed_link_token CHAR(64) UNIQUE NOT NULL,
+	hashed_password VARCHAR(128) NOT NULL,
+	expire_time DATETIME NULL,
+	max_download_count INTEGER NOT NULL,
+	download_count INTEGER NOT NULL,
+	revoked BOOLEAN NOT NULL,
+	FOREIGN KEY (of_download_task_id) REFERENCES download_tasks (task_id) ON DELETE CASCADE,
+	FOREIGN KEY (of_account_id) REFERENCES accounts (id)
+);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS outbox_messages (
+	outbox_message_id INTEGER PRIMARY KEY AUTOINCREMENT,
+	topic VARCHAR(256) NOT NULL,
+	payload BLOB NOT NULL,
+	created_time DATETIME NOT NULL,
+	sent_time DATETIME NULL
+);
+
+CREATE INDEX IF NOT EXISTS outbox_messages_sent_time ON outbox_messages (sent_time, outbox_message_id);
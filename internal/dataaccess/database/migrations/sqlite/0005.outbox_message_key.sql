@@ -0,0 +1,2 @@
+ALTER TABLE outbox_messages
+	ADD COLUMN message_key VARCHAR(256) NOT NULL DEFAULT '';
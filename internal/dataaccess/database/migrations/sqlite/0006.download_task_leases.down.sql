@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS download_tasks_download_status_heartbeat_time;
+
+ALTER TABLE download_tasks
+	DROP COLUMN heartbeat_time;
+
+ALTER TABLE download_tasks
+	DROP COLUMN worker_id;
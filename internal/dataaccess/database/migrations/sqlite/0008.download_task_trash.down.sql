@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS download_tasks_deleted_time;
+
+ALTER TABLE download_tasks
+	DROP COLUMN deleted_time;
@@ -0,0 +1,419 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/manhhung2111/go-idm/internal/config"
+	"github.com/manhhung2111/go-idm/internal/utils"
+	"go.uber.org/zap"
+)
+
+const (
+	tableNameSchemaMigrations = "schema_migrations"
+
+	// migrationLockName identifies the advisory lock serializing the migrators of the processes sharing a
+	// database. PostgreSQL advisory locks are identified by a number rather than a name.
+	migrationLockName        = "go_idm_schema_migrations"
+	migrationLockPostgresKey = 4242424242
+
+	migrationFileNameDownSuffix = ".down.sql"
+	migrationFileNameUpSuffix   = ".sql"
+)
+
+//go:embed migrations
+var migrationFS embed.FS
+
+var (
+	ErrMigrationDownMissing = errors.New("migration has no down migration")
+)
+
+// Migration is a schema change. Down reverts Up.
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      string
+	Down    string
+}
+
+type MigrationStatus struct {
+	Version     uint64
+	Name        string
+	AppliedTime *time.Time
+}
+
+// Migrator applies the migrations embedded for the configured database, and records the applied versions in
+// the schema_migrations table. Migrators of different processes take turns through an advisory lock, so
+// several pods starting at once do not race to apply the same migration.
+type Migrator interface {
+	// Up applies every migration not applied yet, in order.
+	Up(ctx context.Context) error
+	// Down reverts the last steps applied migrations, the latest first.
+	Down(ctx context.Context, steps int) error
+	Status(ctx context.Context) ([]MigrationStatus, error)
+}
+
+type migrator struct {
+	db            *sql.DB
+	databaseType  config.DatabaseType
+	migrationList []Migration
+	logger        *zap.Logger
+}
+
+func NewMigrator(
+	db *sql.DB,
+	databaseConfig config.Database,
+	logger *zap.Logger,
+) (Migrator, error) {
+	databaseType := databaseConfig.Type
+	if databaseType == "" {
+		databaseType = config.DatabaseTypeMySQL
+	}
+
+	migrationList, err := loadMigrationList(path.Join("migrations", string(databaseType)))
+	if err != nil {
+		return nil, err
+	}
+
+	return &migrator{
+		db:            db,
+		databaseType:  databaseType,
+		migrationList: migrationList,
+		logger:        logger,
+	}, nil
+}
+
+// loadMigrationList reads the migrations of a directory. A migration NNNN.name.sql is reverted by the
+// optional NNNN.name.down.sql next to it.
+func loadMigrationList(directory string) ([]Migration, error) {
+	entryList, err := fs.ReadDir(migrationFS, directory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	versionToMigrationMap := make(map[uint64]*Migration)
+	for _, entry := range entryList {
+		fileName := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(fileName, migrationFileNameUpSuffix) {
+			continue
+		}
+
+		fileNamePartList := strings.SplitN(fileName, ".", 3)
+		if len(fileNamePartList) < 3 {
+			return nil, fmt.Errorf("invalid migration file name: %s", fileName)
+		}
+
+		version, err := strconv.ParseUint(fileNamePartList[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration file name %s: %w", fileName, err)
+		}
+
+		content, err := migrationFS.ReadFile(path.Join(directory, fileName))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := versionToMigrationMap[version]
+		if !ok {
+			migration = &Migration{Version: version}
+			versionToMigrationMap[version] = migration
+		}
+
+		if strings.HasSuffix(fileName, migrationFileNameDownSuffix) {
+			migration.Down = string(content)
+			continue
+		}
+
+		if migration.Up != "" {
+			return nil, fmt.Errorf("duplicate migration version %d", version)
+		}
+
+		migration.Name = strings.TrimSuffix(fileNamePartList[1]+"."+fileNamePartList[2], migrationFileNameUpSuffix)
+		migration.Up = string(content)
+	}
+
+	migrationList := make([]Migration, 0, len(versionToMigrationMap))
+	for _, migration := range versionToMigrationMap {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %d has a down migration but no up migration", migration.Version)
+		}
+
+		migrationList = append(migrationList, *migration)
+	}
+
+	sort.Slice(migrationList, func(i, j int) bool {
+		return migrationList[i].Version < migrationList[j].Version
+	})
+
+	return migrationList, nil
+}
+
+// Up implements Migrator.
+func (m *migrator) Up(ctx context.Context) error {
+	logger := utils.LoggerWithContext(ctx, m.logger)
+
+	return m.withLock(ctx, func() error {
+		appliedVersionToTimeMap, err := m.getAppliedVersionToTimeMap(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrationList {
+			if _, ok := appliedVersionToTimeMap[migration.Version]; ok {
+				continue
+			}
+
+			migrationLogger := logger.With(zap.Uint64("version", migration.Version)).With(zap.String("name", migration.Name))
+			migrationLogger.Info("applying migration")
+
+			if err = m.applyMigration(ctx, migration.Up, func(tx *sql.Tx) error {
+				_, err := tx.ExecContext(ctx, m.rebind(fmt.Sprintf(
+					"INSERT INTO %s (version, name, applied_time) VALUES (?, ?, ?)", tableNameSchemaMigrations,
+				)), migration.Version, migration.Name, time.Now().UTC())
+				return err
+			}); err != nil {
+				migrationLogger.With(zap.Error(err)).Error("failed to apply migration")
+				return fmt.Errorf("failed to apply migration %d %s: %w", migration.Version, migration.Name, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// Down implements Migrator.
+func (m *migrator) Down(ctx context.Context, steps int) error {
+	logger := utils.LoggerWithContext(ctx, m.logger)
+
+	return m.withLock(ctx, func() error {
+		appliedVersionToTimeMap, err := m.getAppliedVersionToTimeMap(ctx)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrationList) - 1; i >= 0 && steps > 0; i-- {
+			migration := m.migrationList[i]
+			if _, ok := appliedVersionToTimeMap[migration.Version]; !ok {
+				continue
+			}
+
+			migrationLogger := logger.With(zap.Uint64("version", migration.Version)).With(zap.String("name", migration.Name))
+			if migration.Down == "" {
+				migrationLogger.Error("migration has no down migration")
+				return fmt.Errorf("%w: %d %s", ErrMigrationDownMissing, migration.Version, migration.Name)
+			}
+
+			migrationLogger.Info("reverting migration")
+			if err = m.applyMigration(ctx, migration.Down, func(tx *sql.Tx) error {
+				_, err := tx.ExecContext(ctx, m.rebind(fmt.Sprintf(
+					"DELETE FROM %s WHERE version = ?", tableNameSchemaMigrations,
+				)), migration.Version)
+				return err
+			}); err != nil {
+				migrationLogger.With(zap.Error(err)).Error("failed to revert migration")
+				return fmt.Errorf("failed to revert migration %d %s: %w", migration.Version, migration.Name, err)
+			}
+
+			steps--
+		}
+
+		return nil
+	})
+}
+
+// Status implements Migrator.
+func (m *migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	if err := m.createSchemaMigrationsTable(ctx); err != nil {
+		return nil, err
+	}
+
+	appliedVersionToTimeMap, err := m.getAppliedVersionToTimeMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	migrationStatusList := make([]MigrationStatus, 0, len(m.migrationList))
+	for _, migration := range m.migrationList {
+		migrationStatus := MigrationStatus{
+			Version: migration.Version,
+			Name:    migration.Name,
+		}
+
+		if appliedTime, ok := appliedVersionToTimeMap[migration.Version]; ok {
+			migrationStatus.AppliedTime = &appliedTime
+		}
+
+		migrationStatusList = append(migrationStatusList, migrationStatus)
+	}
+
+	return migrationStatusList, nil
+}
+
+// applyMigration runs the statements of a migration, then record, in a transaction. MySQL commits
+// implicitly after each schema change though, so a migration failing halfway through there has to be
+// cleaned up by hand.
+func (m *migrator) applyMigration(ctx context.Context, migration string, record func(tx *sql.Tx) error) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback() //nolint:errcheck // The transaction is committed on success.
+
+	for _, statement := range splitMigrationStatementList(migration) {
+		if _, err = tx.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+
+	if err = record(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// splitMigrationStatementList splits a migration into its statements, which are separated by a semicolon at
+// the end of a line, as not every driver runs several statements at once.
+func splitMigrationStatementList(migration string) []string {
+	statementList := make([]string, 0)
+	statementBuilder := new(strings.Builder)
+	for _, line := range strings.Split(migration, "\n") {
+		statementBuilder.WriteString(line)
+		statementBuilder.WriteString("\n")
+
+		if !strings.HasSuffix(strings.TrimSpace(line), ";") {
+			continue
+		}
+
+		statementList = append(statementList, strings.TrimSpace(statementBuilder.String()))
+		statementBuilder.Reset()
+	}
+
+	if statement := strings.TrimSpace(statementBuilder.String()); statement != "" {
+		statementList = append(statementList, statement)
+	}
+
+	return statementList
+}
+
+func (m *migrator) getAppliedVersionToTimeMap(ctx context.Context) (map[uint64]time.Time, error) {
+	rows, err := m.db.QueryContext(ctx, fmt.Sprintf("SELECT version, applied_time FROM %s", tableNameSchemaMigrations))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get applied migrations: %w", err)
+	}
+
+	defer rows.Close()
+
+	appliedVersionToTimeMap := make(map[uint64]time.Time)
+	for rows.Next() {
+		var (
+			version     uint64
+			appliedTime time.Time
+		)
+
+		if err = rows.Scan(&version, &appliedTime); err != nil {
+			return nil, fmt.Errorf("failed to get applied migrations: %w", err)
+		}
+
+		appliedVersionToTimeMap[version] = appliedTime
+	}
+
+	return appliedVersionToTimeMap, rows.Err()
+}
+
+func (m *migrator) createSchemaMigrationsTable(ctx context.Context) error {
+	timeColumnType := "DATETIME"
+	if m.databaseType == config.DatabaseTypePostgres {
+		timeColumnType = "TIMESTAMPTZ"
+	}
+
+	if _, err := m.db.ExecContext(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	version BIGINT NOT NULL PRIMARY KEY,
+	name VARCHAR(256) NOT NULL,
+	applied_time %s NOT NULL
+)`, tableNameSchemaMigrations, timeColumnType)); err != nil {
+		return fmt.Errorf("failed to create %s table: %w", tableNameSchemaMigrations, err)
+	}
+
+	return nil
+}
+
+// withLock runs f holding the migration lock, after making sure the schema_migrations table exists. The lock
+// is a session lock, held by a connection reserved for it. SQLite has no such lock, but its transactions take
+// the lock of the whole database, and a database file is rarely shared by several processes anyway.
+func (m *migrator) withLock(ctx context.Context, f func() error) error {
+	logger := utils.LoggerWithContext(ctx, m.logger)
+
+	connection, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer connection.Close()
+
+	switch m.databaseType {
+	case config.DatabaseTypeMySQL:
+		var acquired sql.NullInt64
+		if err = connection.QueryRowContext(ctx, "SELECT GET_LOCK(?, -1)", migrationLockName).Scan(&acquired); err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+
+		if acquired.Int64 != 1 {
+			return errors.New("failed to acquire migration lock")
+		}
+
+		defer func() {
+			if _, err := connection.ExecContext(context.WithoutCancel(ctx), "SELECT RELEASE_LOCK(?)", migrationLockName); err != nil {
+				logger.With(zap.Error(err)).Error("failed to release migration lock")
+			}
+		}()
+
+	case config.DatabaseTypePostgres:
+		if _, err = connection.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockPostgresKey); err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+
+		defer func() {
+			if _, err := connection.ExecContext(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock($1)", migrationLockPostgresKey); err != nil {
+				logger.With(zap.Error(err)).Error("failed to release migration lock")
+			}
+		}()
+	}
+
+	if err = m.createSchemaMigrationsTable(ctx); err != nil {
+		return err
+	}
+
+	return f()
+}
+
+// rebind replaces the ? placeholders of a query with the $n ones of PostgreSQL.
+func (m *migrator) rebind(query string) string {
+	if m.databaseType != config.DatabaseTypePostgres {
+		return query
+	}
+
+	queryBuilder := new(strings.Builder)
+	placeholderCount := 0
+	for _, r := range query {
+		if r != '?' {
+			queryBuilder.WriteRune(r)
+			continue
+		}
+
+		placeholderCount++
+		queryBuilder.WriteString("$" + strconv.Itoa(placeholderCount))
+	}
+
+	return queryBuilder.String()
+}
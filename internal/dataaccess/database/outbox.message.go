@@ -0,0 +1,167 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/manhhung2111/go-idm/internal/utils"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	tableNameOutboxMessages = goqu.T("outbox_messages")
+)
+
+const (
+	ColNameOutboxMessageId          = "outbox_message_id"
+	ColNameOutboxMessageTopic       = "topic"
+	ColNameOutboxMessageKey         = "message_key"
+	ColNameOutboxMessagePayload     = "payload"
+	ColNameOutboxMessageCreatedTime = "created_time"
+	ColNameOutboxMessageSentTime    = "sent_time"
+)
+
+// OutboxMessage is a message waiting to be published to the message queue. It is written in the same
+// transaction as the change it describes, so the message exists if and only if the change was committed.
+type OutboxMessage struct {
+	ID          uint64     `db:"outbox_message_id" goqu:"skipinsert,skipupdate"`
+	Topic       string     `db:"topic"`
+	Key         string     `db:"message_key"`
+	Payload     []byte     `db:"payload"`
+	CreatedTime time.Time  `db:"created_time"`
+	SentTime    *time.Time `db:"sent_time"`
+}
+
+type OutboxMessageDataAccessor interface {
+	CreateOutboxMessage(ctx context.Context, outboxMessage OutboxMessage) (uint64, error)
+	GetUnsentOutboxMessageListWithXLock(ctx context.Context, limit uint64) ([]OutboxMessage, error)
+	UpdateOutboxMessageListAsSent(ctx context.Context, idList []uint64, sentTime time.Time) error
+	// DeleteSentOutboxMessageList deletes up to limit of the oldest messages sent before sentTime, and returns
+	// how many it deleted.
+	DeleteSentOutboxMessageList(ctx context.Context, sentTime time.Time, limit uint64) (uint64, error)
+	WithDatabase(database IDatabase) OutboxMessageDataAccessor
+}
+
+type outboxMessageDataAccessor struct {
+	database IDatabase
+	logger   *zap.Logger
+}
+
+func NewOutboxMessageDataAccessor(
+	database *goqu.Database,
+	logger *zap.Logger,
+) OutboxMessageDataAccessor {
+	return &outboxMessageDataAccessor{
+		database: database,
+		logger:   logger,
+	}
+}
+
+// CreateOutboxMessage implements OutboxMessageDataAccessor.
+func (o *outboxMessageDataAccessor) CreateOutboxMessage(ctx context.Context, outboxMessage OutboxMessage) (uint64, error) {
+	logger := utils.LoggerWithContext(ctx, o.logger).With(zap.String("topic", outboxMessage.Topic))
+	markWritten(ctx)
+
+	// The payload is binary, it is bound as a parameter rather than written in the query as a string literal.
+	id, err := insertAndGetId(ctx, o.database.
+		Insert(tableNameOutboxMessages).
+		Prepared(true).
+		Rows(outboxMessage), ColNameOutboxMessageId)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to create outbox message")
+		return 0, status.Errorf(codes.Internal, "failed to create outbox message")
+	}
+
+	return id, nil
+}
+
+// GetUnsentOutboxMessageListWithXLock returns the oldest unsent outbox messages, locked until the end of the
+// transaction. The ones already locked are skipped rather than waited for, in case a relay that lost the relay
+// lock is still finishing its batch.
+func (o *outboxMessageDataAccessor) GetUnsentOutboxMessageListWithXLock(ctx context.Context, limit uint64) ([]OutboxMessage, error) {
+	logger := utils.LoggerWithContext(ctx, o.logger).With(zap.Uint64("limit", limit))
+
+	outboxMessageList := make([]OutboxMessage, 0)
+	if err := o.database.
+		Select().
+		From(tableNameOutboxMessages).
+		Where(goqu.C(ColNameOutboxMessageSentTime).IsNull()).
+		Order(goqu.C(ColNameOutboxMessageId).Asc()).
+		Limit(uint(limit)).
+		ForUpdate(goqu.SkipLocked).
+		Executor().
+		ScanStructsContext(ctx, &outboxMessageList); err != nil {
+		logger.With(zap.Error(err)).Error("failed to get unsent outbox message list")
+		return nil, status.Errorf(codes.Internal, "failed to get unsent outbox message list")
+	}
+
+	return outboxMessageList, nil
+}
+
+// UpdateOutboxMessageListAsSent implements OutboxMessageDataAccessor.
+func (o *outboxMessageDataAccessor) UpdateOutboxMessageListAsSent(ctx context.Context, idList []uint64, sentTime time.Time) error {
+	logger := utils.LoggerWithContext(ctx, o.logger).With(zap.Uint64s("id_list", idList))
+	markWritten(ctx)
+
+	if len(idList) == 0 {
+		return nil
+	}
+
+	if _, err := o.database.
+		Update(tableNameOutboxMessages).
+		Set(goqu.Record{ColNameOutboxMessageSentTime: sentTime}).
+		Where(goqu.Ex{ColNameOutboxMessageId: idList}).
+		Executor().
+		ExecContext(ctx); err != nil {
+		logger.With(zap.Error(err)).Error("failed to update outbox message list as sent")
+		return status.Errorf(codes.Internal, "failed to update outbox message list as sent")
+	}
+
+	return nil
+}
+
+// DeleteSentOutboxMessageList implements OutboxMessageDataAccessor. The messages are picked first, then deleted
+// by id, as not every database supports a limit on a delete.
+func (o *outboxMessageDataAccessor) DeleteSentOutboxMessageList(ctx context.Context, sentTime time.Time, limit uint64) (uint64, error) {
+	logger := utils.LoggerWithContext(ctx, o.logger).With(zap.Time("sent_time", sentTime))
+	markWritten(ctx)
+
+	idList := make([]uint64, 0)
+	if err := o.database.
+		From(tableNameOutboxMessages).
+		Select(ColNameOutboxMessageId).
+		Where(goqu.C(ColNameOutboxMessageSentTime).Lt(sentTime)).
+		Order(goqu.C(ColNameOutboxMessageId).Asc()).
+		Limit(uint(limit)).
+		Executor().
+		ScanValsContext(ctx, &idList); err != nil {
+		logger.With(zap.Error(err)).Error("failed to get sent outbox message list")
+		return 0, status.Errorf(codes.Internal, "failed to get sent outbox message list")
+	}
+
+	if len(idList) == 0 {
+		return 0, nil
+	}
+
+	if _, err := o.database.
+		Delete(tableNameOutboxMessages).
+		Where(goqu.Ex{ColNameOutboxMessageId: idList}).
+		Executor().
+		ExecContext(ctx); err != nil {
+		logger.With(zap.Error(err)).Error("failed to delete sent outbox message list")
+		return 0, status.Errorf(codes.Internal, "failed to delete sent outbox message list")
+	}
+
+	return uint64(len(idList)), nil
+}
+
+// WithDatabase implements OutboxMessageDataAccessor.
+func (o *outboxMessageDataAccessor) WithDatabase(database IDatabase) OutboxMessageDataAccessor {
+	return &outboxMessageDataAccessor{
+		database: database,
+		logger:   o.logger,
+	}
+}
@@ -0,0 +1,84 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/manhhung2111/go-idm/internal/config"
+)
+
+// ReadReplicaDatabase is the set of read replicas of the database, picked from in turn. It is empty if no
+// replica is configured, in which case every query runs on the primary.
+type ReadReplicaDatabase struct {
+	databaseList []*goqu.Database
+	nextIndex    *atomic.Uint64
+}
+
+func InitializeReadReplicaDB(databaseConfig config.Database) (*ReadReplicaDatabase, func(), error) {
+	readReplicaDatabase := &ReadReplicaDatabase{
+		databaseList: make([]*goqu.Database, 0, len(databaseConfig.Replicas)),
+		nextIndex:    new(atomic.Uint64),
+	}
+
+	if len(databaseConfig.Replicas) > 0 && databaseConfig.Type == config.DatabaseTypeSQLite {
+		return nil, nil, errors.New("read replicas are not supported with sqlite")
+	}
+
+	cleanupFuncList := make([]func(), 0, len(databaseConfig.Replicas))
+	cleanupFunc := func() {
+		for _, f := range cleanupFuncList {
+			f()
+		}
+	}
+
+	for _, replicaConfig := range databaseConfig.Replicas {
+		db, replicaCleanupFunc, err := InitializeDB(replicaConfig.Apply(databaseConfig))
+		if err != nil {
+			cleanupFunc()
+			return nil, nil, err
+		}
+
+		cleanupFuncList = append(cleanupFuncList, replicaCleanupFunc)
+		readReplicaDatabase.databaseList = append(readReplicaDatabase.databaseList, InitializeGoquDB(db, databaseConfig))
+	}
+
+	return readReplicaDatabase, cleanupFunc, nil
+}
+
+func (r *ReadReplicaDatabase) next() IDatabase {
+	return r.databaseList[r.nextIndex.Add(1)%uint64(len(r.databaseList))]
+}
+
+type primaryStickinessContextKey struct{}
+
+// WithPrimaryStickiness returns a context for a request, in which the reads that follow a write run on the
+// primary, so that the request reads what it wrote whatever the lag of the replicas. Without it, the reads
+// of a request run on the replicas even after a write.
+func WithPrimaryStickiness(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryStickinessContextKey{}, new(atomic.Bool))
+}
+
+// markWritten makes the rest of the request read from the primary. Writes call it before executing, so that
+// a write that fails after reaching the database counts as well.
+func markWritten(ctx context.Context) {
+	if written, ok := ctx.Value(primaryStickinessContextKey{}).(*atomic.Bool); ok {
+		written.Store(true)
+	}
+}
+
+// readDatabase returns the database a non-locking read runs on: a replica if there is one and the request has
+// not written yet, the primary otherwise. Data accessors bound to a transaction have no replica, their reads
+// stay in the transaction.
+func readDatabase(ctx context.Context, primaryDatabase IDatabase, readReplicaDatabase *ReadReplicaDatabase) IDatabase {
+	if readReplicaDatabase == nil || len(readReplicaDatabase.databaseList) == 0 {
+		return primaryDatabase
+	}
+
+	if written, ok := ctx.Value(primaryStickinessContextKey{}).(*atomic.Bool); ok && written.Load() {
+		return primaryDatabase
+	}
+
+	return readReplicaDatabase.next()
+}
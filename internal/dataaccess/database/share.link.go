@@ -0,0 +1,174 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/manhhung2111/go-idm/internal/utils"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	tableNameShareLinks  = goqu.T("share_links")
+	ErrShareLinkNotFound = status.Error(codes.NotFound, "share link not found")
+)
+
+const (
+	ColNameShareLinkId               = "share_link_id"
+	ColNameShareLinkOfDownloadTaskId = "of_download_task_id"
+	ColNameShareLinkOfAccountId      = "of_account_id"
+	ColNameShareLinkHashedLinkToken  = "hashed_link_token"
+	ColNameShareLinkHashedPassword   = "hashed_password"
+	ColNameShareLinkExpireTime       = "expire_time"
+	ColNameShareLinkMaxDownloadCount = "max_download_count"
+	ColNameShareLinkDownloadCount    = "download_count"
+	ColNameShareLinkRevoked          = "revoked"
+)
+
+type ShareLink struct {
+	ID               uint64     `db:"share_link_id" goqu:"skipinsert,skipupdate"`
+	OfDownloadTaskID uint64     `db:"of_download_task_id" goqu:"skipupdate"`
+	OfAccountID      uint64     `db:"of_account_id" goqu:"skipupdate"`
+	HashedLinkToken  string     `db:"hashed_link_token" goqu:"skipupdate"`
+	HashedPassword   string     `db:"hashed_password"`
+	ExpireTime       *time.Time `db:"expire_time"`
+	MaxDownloadCount uint64     `db:"max_download_count"`
+	DownloadCount    uint64     `db:"download_count"`
+	Revoked          bool       `db:"revoked"`
+}
+
+type ShareLinkDataAccessor interface {
+	CreateShareLink(ctx context.Context, shareLink ShareLink) (uint64, error)
+	GetShareLink(ctx context.Context, id uint64) (ShareLink, error)
+	GetShareLinkByHashedLinkToken(ctx context.Context, hashedLinkToken string) (ShareLink, error)
+	IncreaseShareLinkDownloadCount(ctx context.Context, id uint64) (bool, error)
+	RevokeShareLink(ctx context.Context, id uint64) error
+	WithDatabase(database IDatabase) ShareLinkDataAccessor
+}
+
+type shareLinkDataAccessor struct {
+	database IDatabase
+	logger   *zap.Logger
+}
+
+func NewShareLinkDataAccessor(
+	database *goqu.Database,
+	logger *zap.Logger,
+) ShareLinkDataAccessor {
+	return &shareLinkDataAccessor{
+		database: database,
+		logger:   logger,
+	}
+}
+
+// CreateShareLink implements ShareLinkDataAccessor.
+func (s *shareLinkDataAccessor) CreateShareLink(ctx context.Context, shareLink ShareLink) (uint64, error) {
+	logger := utils.LoggerWithContext(ctx, s.logger).
+		With(zap.Uint64("download_task_id", shareLink.OfDownloadTaskID))
+	markWritten(ctx)
+
+	id, err := insertAndGetId(ctx, s.database.
+		Insert(tableNameShareLinks).
+		Rows(shareLink), ColNameShareLinkId)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to create share link")
+		return 0, status.Errorf(codes.Internal, "failed to create share link")
+	}
+
+	return id, nil
+}
+
+// GetShareLink implements ShareLinkDataAccessor.
+func (s *shareLinkDataAccessor) GetShareLink(ctx context.Context, id uint64) (ShareLink, error) {
+	return s.getShareLink(ctx, goqu.Ex{ColNameShareLinkId: id})
+}
+
+// GetShareLinkByHashedLinkToken implements ShareLinkDataAccessor.
+func (s *shareLinkDataAccessor) GetShareLinkByHashedLinkToken(ctx context.Context, hashedLinkToken string) (ShareLink, error) {
+	return s.getShareLink(ctx, goqu.Ex{ColNameShareLinkHashedLinkToken: hashedLinkToken})
+}
+
+func (s *shareLinkDataAccessor) getShareLink(ctx context.Context, where goqu.Ex) (ShareLink, error) {
+	logger := utils.LoggerWithContext(ctx, s.logger)
+
+	shareLink := ShareLink{}
+	found, err := s.database.
+		Select().
+		From(tableNameShareLinks).
+		Where(where).
+		ScanStructContext(ctx, &shareLink)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to get share link")
+		return ShareLink{}, status.Errorf(codes.Internal, "failed to get share link")
+	}
+
+	if !found {
+		logger.Warn("share link not found")
+		return ShareLink{}, ErrShareLinkNotFound
+	}
+
+	return shareLink, nil
+}
+
+// IncreaseShareLinkDownloadCount atomically counts one more download of the share link, returning false
+// without changing anything if the link has already reached its maximum download count.
+func (s *shareLinkDataAccessor) IncreaseShareLinkDownloadCount(ctx context.Context, id uint64) (bool, error) {
+	logger := utils.LoggerWithContext(ctx, s.logger).With(zap.Uint64("id", id))
+	markWritten(ctx)
+
+	result, err := s.database.
+		Update(tableNameShareLinks).
+		Set(goqu.Record{
+			ColNameShareLinkDownloadCount: goqu.L("? + 1", goqu.C(ColNameShareLinkDownloadCount)),
+		}).
+		Where(
+			goqu.C(ColNameShareLinkId).Eq(id),
+			goqu.Or(
+				goqu.C(ColNameShareLinkMaxDownloadCount).Eq(0),
+				goqu.C(ColNameShareLinkDownloadCount).Lt(goqu.C(ColNameShareLinkMaxDownloadCount)),
+			),
+		).
+		Executor().
+		ExecContext(ctx)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to increase share link download count")
+		return false, status.Errorf(codes.Internal, "failed to increase share link download count")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to get rows affected")
+		return false, status.Errorf(codes.Internal, "failed to get rows affected")
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// RevokeShareLink implements ShareLinkDataAccessor.
+func (s *shareLinkDataAccessor) RevokeShareLink(ctx context.Context, id uint64) error {
+	logger := utils.LoggerWithContext(ctx, s.logger).With(zap.Uint64("id", id))
+	markWritten(ctx)
+
+	if _, err := s.database.
+		Update(tableNameShareLinks).
+		Set(goqu.Record{ColNameShareLinkRevoked: true}).
+		Where(goqu.Ex{ColNameShareLinkId: id}).
+		Executor().
+		ExecContext(ctx); err != nil {
+		logger.With(zap.Error(err)).Error("failed to revoke share link")
+		return status.Errorf(codes.Internal, "failed to revoke share link")
+	}
+
+	return nil
+}
+
+// WithDatabase implements ShareLinkDataAccessor.
+func (s *shareLinkDataAccessor) WithDatabase(database IDatabase) ShareLinkDataAccessor {
+	return &shareLinkDataAccessor{
+		database: database,
+		logger:   s.logger,
+	}
+}
@@ -0,0 +1,54 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/dialect/sqlite3"
+	"github.com/manhhung2111/go-idm/internal/config"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const (
+	dialectSQLite = "go_idm_sqlite3"
+
+	// sqliteBusyTimeoutInMilliseconds is how long a transaction waits for the one holding the write lock.
+	sqliteBusyTimeoutInMilliseconds = 5000
+)
+
+func init() {
+	// SQLite compares times as text, so they are written with a fixed width and without a time zone, in UTC, for
+	// the comparison to order them.
+	dialectOptions := sqlite3.DialectOptions()
+	dialectOptions.TimeFormat = "2006-01-02 15:04:05.000000000"
+
+	// Locking clauses are left out, transactions hold the lock of the whole database instead.
+	dialectOptions.SkipLockedFragment = []byte("")
+	goqu.RegisterDialect(dialectSQLite, dialectOptions)
+}
+
+// openSQLiteDB opens the SQLite database of the config, creating its file if it does not exist. SQLite
+// has no row lock, so every transaction takes the write lock of the whole database as it begins, in place of
+// the row locks taken by the MySQL queries.
+func openSQLiteDB(databaseConfig config.Database) (*sql.DB, error) {
+	databasePath := databaseConfig.SQLite.GetPath()
+	if err := os.MkdirAll(filepath.Dir(databasePath), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create sqlite database directory: %w", err)
+	}
+
+	connectionParams := url.Values{}
+	connectionParams.Set("_txlock", "immediate")
+	connectionParams.Set("_busy_timeout", strconv.Itoa(sqliteBusyTimeoutInMilliseconds))
+	connectionParams.Set("_foreign_keys", "on")
+	connectionParams.Set("_journal_mode", "WAL")
+	for key, value := range databaseConfig.Params {
+		connectionParams.Set(key, value)
+	}
+
+	return sql.Open("sqlite3", "file:"+databasePath+"?"+connectionParams.Encode())
+}
@@ -0,0 +1,117 @@
+package database
+
+import (
+	"context"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/manhhung2111/go-idm/internal/utils"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	tableNameTeams  = goqu.T("teams")
+	ErrTeamNotFound = status.Error(codes.NotFound, "team not found")
+)
+
+const (
+	ColNameTeamId   = "team_id"
+	ColNameTeamName = "team_name"
+)
+
+type Team struct {
+	ID       uint64 `db:"team_id" goqu:"skipinsert,skipupdate"`
+	TeamName string `db:"team_name"`
+}
+
+type TeamDataAccessor interface {
+	CreateTeam(ctx context.Context, team Team) (uint64, error)
+	GetTeam(ctx context.Context, id uint64) (Team, error)
+	GetTeamList(ctx context.Context, idList []uint64) ([]Team, error)
+	WithDatabase(database IDatabase) TeamDataAccessor
+}
+
+type teamDataAccessor struct {
+	database IDatabase
+	logger   *zap.Logger
+}
+
+func NewTeamDataAccessor(
+	database *goqu.Database,
+	logger *zap.Logger,
+) TeamDataAccessor {
+	return &teamDataAccessor{
+		database: database,
+		logger:   logger,
+	}
+}
+
+// CreateTeam implements TeamDataAccessor.
+func (t *teamDataAccessor) CreateTeam(ctx context.Context, team Team) (uint64, error) {
+	logger := utils.LoggerWithContext(ctx, t.logger).With(zap.Any("team", team))
+	markWritten(ctx)
+
+	id, err := insertAndGetId(ctx, t.database.
+		Insert(tableNameTeams).
+		Rows(team), ColNameTeamId)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to create team")
+		return 0, status.Errorf(codes.Internal, "failed to create team")
+	}
+
+	return id, nil
+}
+
+// GetTeam implements TeamDataAccessor.
+func (t *teamDataAccessor) GetTeam(ctx context.Context, id uint64) (Team, error) {
+	logger := utils.LoggerWithContext(ctx, t.logger).With(zap.Uint64("id", id))
+
+	team := Team{}
+	found, err := t.database.
+		Select().
+		From(tableNameTeams).
+		Where(goqu.Ex{ColNameTeamId: id}).
+		ScanStructContext(ctx, &team)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to get team")
+		return Team{}, status.Errorf(codes.Internal, "failed to get team")
+	}
+
+	if !found {
+		logger.Warn("team not found")
+		return Team{}, ErrTeamNotFound
+	}
+
+	return team, nil
+}
+
+// GetTeamList implements TeamDataAccessor.
+func (t *teamDataAccessor) GetTeamList(ctx context.Context, idList []uint64) ([]Team, error) {
+	logger := utils.LoggerWithContext(ctx, t.logger).With(zap.Uint64s("id_list", idList))
+
+	teamList := make([]Team, 0)
+	if len(idList) == 0 {
+		return teamList, nil
+	}
+
+	if err := t.database.
+		Select().
+		From(tableNameTeams).
+		Where(goqu.Ex{ColNameTeamId: idList}).
+		Executor().
+		ScanStructsContext(ctx, &teamList); err != nil {
+		logger.With(zap.Error(err)).Error("failed to get team list")
+		return nil, status.Errorf(codes.Internal, "failed to get team list")
+	}
+
+	return teamList, nil
+}
+
+// WithDatabase implements TeamDataAccessor.
+func (t *teamDataAccessor) WithDatabase(database IDatabase) TeamDataAccessor {
+	return &teamDataAccessor{
+		database: database,
+		logger:   t.logger,
+	}
+}
@@ -0,0 +1,178 @@
+package database
+
+import (
+	"context"
+
+	"github.com/doug-martin/goqu/v9"
+	go_idm_v1 "github.com/manhhung2111/go-idm/internal/generated/proto"
+	"github.com/manhhung2111/go-idm/internal/utils"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	tableNameTeamMembers  = goqu.T("team_members")
+	ErrTeamMemberNotFound = status.Error(codes.NotFound, "team member not found")
+)
+
+const (
+	ColNameTeamMemberOfTeamId    = "of_team_id"
+	ColNameTeamMemberOfAccountId = "of_account_id"
+	ColNameTeamMemberTeamRole    = "team_role"
+)
+
+type TeamMember struct {
+	OfTeamID    uint64             `db:"of_team_id" goqu:"skipupdate"`
+	OfAccountID uint64             `db:"of_account_id" goqu:"skipupdate"`
+	TeamRole    go_idm_v1.TeamRole `db:"team_role"`
+}
+
+type TeamMemberDataAccessor interface {
+	UpsertTeamMember(ctx context.Context, teamMember TeamMember) error
+	GetTeamMember(ctx context.Context, teamId, accountId uint64) (TeamMember, error)
+	GetTeamMemberListOfAccount(ctx context.Context, accountId uint64) ([]TeamMember, error)
+	GetTeamMemberListOfTeamRoleWithXLock(ctx context.Context, teamId uint64, teamRole go_idm_v1.TeamRole) ([]TeamMember, error)
+	DeleteTeamMember(ctx context.Context, teamId, accountId uint64) error
+	WithDatabase(database IDatabase) TeamMemberDataAccessor
+}
+
+type teamMemberDataAccessor struct {
+	database IDatabase
+	logger   *zap.Logger
+}
+
+func NewTeamMemberDataAccessor(
+	database *goqu.Database,
+	logger *zap.Logger,
+) TeamMemberDataAccessor {
+	return &teamMemberDataAccessor{
+		database: database,
+		logger:   logger,
+	}
+}
+
+// UpsertTeamMember implements TeamMemberDataAccessor.
+func (t *teamMemberDataAccessor) UpsertTeamMember(ctx context.Context, teamMember TeamMember) error {
+	logger := utils.LoggerWithContext(ctx, t.logger).With(zap.Any("team_member", teamMember))
+	markWritten(ctx)
+
+	if _, err := t.database.
+		Insert(tableNameTeamMembers).
+		Rows(teamMember).
+		OnConflict(goqu.DoUpdate(
+			ColNameTeamMemberOfTeamId+", "+ColNameTeamMemberOfAccountId,
+			goqu.Record{ColNameTeamMemberTeamRole: teamMember.TeamRole},
+		)).
+		Executor().
+		ExecContext(ctx); err != nil {
+		logger.With(zap.Error(err)).Error("failed to upsert team member")
+		return status.Errorf(codes.Internal, "failed to upsert team member")
+	}
+
+	return nil
+}
+
+// GetTeamMember implements TeamMemberDataAccessor.
+func (t *teamMemberDataAccessor) GetTeamMember(ctx context.Context, teamId, accountId uint64) (TeamMember, error) {
+	logger := utils.LoggerWithContext(ctx, t.logger).
+		With(zap.Uint64("team_id", teamId)).
+		With(zap.Uint64("account_id", accountId))
+
+	teamMember := TeamMember{}
+	found, err := t.database.
+		Select().
+		From(tableNameTeamMembers).
+		Where(goqu.Ex{
+			ColNameTeamMemberOfTeamId:    teamId,
+			ColNameTeamMemberOfAccountId: accountId,
+		}).
+		ScanStructContext(ctx, &teamMember)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to get team member")
+		return TeamMember{}, status.Errorf(codes.Internal, "failed to get team member")
+	}
+
+	if !found {
+		return TeamMember{}, ErrTeamMemberNotFound
+	}
+
+	return teamMember, nil
+}
+
+// GetTeamMemberListOfAccount implements TeamMemberDataAccessor.
+func (t *teamMemberDataAccessor) GetTeamMemberListOfAccount(ctx context.Context, accountId uint64) ([]TeamMember, error) {
+	logger := utils.LoggerWithContext(ctx, t.logger).With(zap.Uint64("account_id", accountId))
+
+	teamMemberList := make([]TeamMember, 0)
+	if err := t.database.
+		Select().
+		From(tableNameTeamMembers).
+		Where(goqu.Ex{ColNameTeamMemberOfAccountId: accountId}).
+		Executor().
+		ScanStructsContext(ctx, &teamMemberList); err != nil {
+		logger.With(zap.Error(err)).Error("failed to get team member list of account")
+		return nil, status.Errorf(codes.Internal, "failed to get team member list of account")
+	}
+
+	return teamMemberList, nil
+}
+
+// GetTeamMemberListOfTeamRoleWithXLock returns the members of a team with a role, locked until the end of the
+// transaction so that concurrent changes of their roles are serialized.
+func (t *teamMemberDataAccessor) GetTeamMemberListOfTeamRoleWithXLock(
+	ctx context.Context,
+	teamId uint64,
+	teamRole go_idm_v1.TeamRole,
+) ([]TeamMember, error) {
+	logger := utils.LoggerWithContext(ctx, t.logger).
+		With(zap.Uint64("team_id", teamId)).
+		With(zap.Any("team_role", teamRole))
+
+	teamMemberList := make([]TeamMember, 0)
+	if err := t.database.
+		Select().
+		From(tableNameTeamMembers).
+		Where(goqu.Ex{
+			ColNameTeamMemberOfTeamId: teamId,
+			ColNameTeamMemberTeamRole: teamRole,
+		}).
+		ForUpdate(goqu.Wait).
+		Executor().
+		ScanStructsContext(ctx, &teamMemberList); err != nil {
+		logger.With(zap.Error(err)).Error("failed to get team member list of team role")
+		return nil, status.Errorf(codes.Internal, "failed to get team member list of team role")
+	}
+
+	return teamMemberList, nil
+}
+
+// DeleteTeamMember implements TeamMemberDataAccessor.
+func (t *teamMemberDataAccessor) DeleteTeamMember(ctx context.Context, teamId, accountId uint64) error {
+	logger := utils.LoggerWithContext(ctx, t.logger).
+		With(zap.Uint64("team_id", teamId)).
+		With(zap.Uint64("account_id", accountId))
+	markWritten(ctx)
+
+	if _, err := t.database.
+		Delete(tableNameTeamMembers).
+		Where(goqu.Ex{
+			ColNameTeamMemberOfTeamId:    teamId,
+			ColNameTeamMemberOfAccountId: accountId,
+		}).
+		Executor().
+		ExecContext(ctx); err != nil {
+		logger.With(zap.Error(err)).Error("failed to delete team member")
+		return status.Errorf(codes.Internal, "failed to delete team member")
+	}
+
+	return nil
+}
+
+// WithDatabase implements TeamMemberDataAccessor.
+func (t *teamMemberDataAccessor) WithDatabase(database IDatabase) TeamMemberDataAccessor {
+	return &teamMemberDataAccessor{
+		database: database,
+		logger:   t.logger,
+	}
+}
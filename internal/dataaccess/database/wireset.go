@@ -5,7 +5,15 @@ import "github.com/google/wire"
 var WireSet = wire.NewSet(
 	InitializeDB,
 	InitializeGoquDB,
+	InitializeReadReplicaDB,
+	NewMigrator,
 	NewAccountDataAccessor,
 	NewAccountPasswordDataAccessor,
 	NewDownloadTaskDataAccessor,
+	NewDownloadTaskShareDataAccessor,
+	NewTeamDataAccessor,
+	NewTeamMemberDataAccessor,
+	NewShareLinkDataAccessor,
+	NewOutboxMessageDataAccessor,
+	NewAuditEventDataAccessor,
 )
\ No newline at end of file
@@ -4,26 +4,72 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/manhhung2111/go-idm/internal/config"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+var (
+	ErrFileNotFound     = status.Error(codes.NotFound, "file not found")
+	ErrInvalidFilePath  = status.Error(codes.InvalidArgument, "invalid file path")
+	ErrNotEnoughSpace   = status.Error(codes.ResourceExhausted, "not enough free space to write file")
+	ErrOffsetOutOfRange = status.Error(codes.OutOfRange, "offset is past the end of the file")
+)
+
+// FileInfo describes a stored file. Path is relative to the storage, as given to the methods of Client.
+type FileInfo struct {
+	Path         string
+	Size         uint64
+	ModifiedTime time.Time
+}
+
+// FileWriter writes a file. The file is only complete once Close returns successfully, Abort discards what
+// was written instead, leaving the file as it was before the write. Either of them can be called after the
+// other, or again, and then does nothing.
+type FileWriter interface {
+	io.WriteCloser
+	Abort() error
+}
+
 type Client interface {
-	Write(ctx context.Context, filePath string) (io.WriteCloser, error)
+	Write(ctx context.Context, filePath string) (FileWriter, error)
 	Read(ctx context.Context, filePath string) (io.ReadCloser, error)
+	// ReadRange reads length bytes of a file from offset, or up to its end if length is 0.
+	ReadRange(ctx context.Context, filePath string, offset uint64, length uint64) (io.ReadCloser, error)
+	// Delete deletes a file, succeeding if it does not exist.
+	Delete(ctx context.Context, filePath string) error
+	// Stat returns the info of a file, or ErrFileNotFound if it does not exist.
+	Stat(ctx context.Context, filePath string) (FileInfo, error)
+	// Walk calls fn with every stored file, in no particular order, stopping at the first error fn returns.
+	Walk(ctx context.Context, fn func(fileInfo FileInfo) error) error
+}
+
+// SizedWriteClient is implemented by the clients that can prepare to write a file whose size is known in
+// advance. WriteSized fails with ErrNotEnoughSpace if the storage can not hold size more bytes, and may
+// reserve the space of the file before it is written. The file can still end up smaller or larger than size.
+type SizedWriteClient interface {
+	WriteSized(ctx context.Context, filePath string, size uint64) (FileWriter, error)
+}
+
+// PresignedURLClient is implemented by the clients that can hand out a time-limited URL to download a file
+// directly from the storage, without streaming it through go-idm.
+type PresignedURLClient interface {
+	GetPresignedURL(ctx context.Context, filePath string, expiresIn time.Duration) (string, error)
 }
 
 func NewClient(
-	downloadConfig config.Download,
+	storageConfig config.DownloadStorage,
 	logger *zap.Logger,
 ) (Client, error) {
-	switch downloadConfig.Mode {
+	switch storageConfig.Mode {
 	case config.DownloadModeLocal:
-		return NewLocalClient(downloadConfig, logger)
+		return NewLocalClient(storageConfig, logger)
 	case config.DownloadModeS3:
-		return NewS3Client(downloadConfig, logger)
+		return NewS3Client(storageConfig, logger)
 	default:
-		return nil, fmt.Errorf("unsupported download mode: %s", downloadConfig.Mode)
+		return nil, fmt.Errorf("unsupported download mode: %s", storageConfig.Mode)
 	}
 }
\ No newline at end of file
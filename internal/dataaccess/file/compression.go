@@ -0,0 +1,115 @@
+package file
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/manhhung2111/go-idm/internal/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NewCompressingWriter returns a writer compressing what is written to it with codec into writer. Closing it
+// flushes the compressed content, it does not close writer.
+func NewCompressingWriter(writer io.Writer, codec config.DownloadCompressionCodec) (io.WriteCloser, error) {
+	switch codec {
+	case config.DownloadCompressionCodecZstd:
+		return zstd.NewWriter(writer)
+	case config.DownloadCompressionCodecGzip:
+		return gzip.NewWriter(writer), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression codec: %s", codec)
+	}
+}
+
+// NewDecompressingReader returns a reader decompressing the content of readCloser, compressed with codec.
+// Closing it closes readCloser.
+func NewDecompressingReader(readCloser io.ReadCloser, codec config.DownloadCompressionCodec) (io.ReadCloser, error) {
+	var decompressor io.ReadCloser
+	switch codec {
+	case config.DownloadCompressionCodecZstd:
+		decoder, err := zstd.NewReader(readCloser, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+
+		decompressor = decoder.IOReadCloser()
+	case config.DownloadCompressionCodecGzip:
+		gzipReader, err := gzip.NewReader(readCloser)
+		if err != nil {
+			return nil, err
+		}
+
+		decompressor = gzipReader
+	default:
+		return nil, fmt.Errorf("unsupported compression codec: %s", codec)
+	}
+
+	return &decompressingReader{
+		reader:       decompressor,
+		decompressor: decompressor,
+		readCloser:   readCloser,
+	}, nil
+}
+
+// ReadDecompressedRange reads length bytes from offset of the decompressed content of a file compressed with
+// codec, up to its end if length is 0. A compressed file can not be read from the middle, the content before
+// offset is decompressed and skipped, so that reading a range costs as much as reading the file up to its end.
+// It returns an OutOfRange error if offset is past the end of the content.
+func ReadDecompressedRange(
+	ctx context.Context,
+	client Client,
+	filePath string,
+	codec config.DownloadCompressionCodec,
+	offset uint64,
+	length uint64,
+) (io.ReadCloser, error) {
+	readCloser, err := client.Read(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	decompressingReadCloser, err := NewDecompressingReader(readCloser, codec)
+	if err != nil {
+		_ = readCloser.Close()
+		return nil, status.Error(codes.Internal, "failed to decompress file")
+	}
+
+	if offset == 0 && length == 0 {
+		return decompressingReadCloser, nil
+	}
+
+	reader := decompressingReadCloser.(*decompressingReader)
+	if _, err = io.CopyN(io.Discard, reader.reader, int64(offset)); err != nil {
+		_ = reader.Close()
+		if errors.Is(err, io.EOF) {
+			return nil, ErrOffsetOutOfRange
+		}
+
+		return nil, status.Error(codes.Internal, "failed to decompress file")
+	}
+
+	if length > 0 {
+		reader.reader = io.LimitReader(reader.reader, int64(length))
+	}
+
+	return reader, nil
+}
+
+type decompressingReader struct {
+	reader       io.Reader
+	decompressor io.Closer
+	readCloser   io.ReadCloser
+}
+
+func (d *decompressingReader) Read(p []byte) (int, error) {
+	return d.reader.Read(p)
+}
+
+func (d *decompressingReader) Close() error {
+	return errors.Join(d.decompressor.Close(), d.readCloser.Close())
+}
@@ -0,0 +1,621 @@
+package file
+
+import (
+	"bufio"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/manhhung2111/go-idm/internal/config"
+	"github.com/manhhung2111/go-idm/internal/utils"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	encryptedFileMagic = "IDMENC01"
+	// encryptedFileFixedHeaderSize is the size of the part of the header before the data key.
+	encryptedFileFixedHeaderSize = len(encryptedFileMagic) + 4 + 2
+	encryptedFileMaxHeaderSize   = 1024
+	dataKeySize                  = 32
+	aesGCMTagSize                = 16
+	chunkNonceLastFlagIndex      = 11
+)
+
+var (
+	errFileDecryptionFailed = status.Error(codes.Internal, "failed to decrypt file")
+)
+
+// DataKeyRewrapClient is implemented by the clients encrypting files, to wrap the data keys of the stored
+// files again with the current master key, after it was rotated.
+type DataKeyRewrapClient interface {
+	// RewrapDataKeys wraps the data keys not wrapped by the current master key with it, and returns how many
+	// it wrapped again.
+	RewrapDataKeys(ctx context.Context) (uint64, error)
+}
+
+// dataKeyFile is the data key of an encrypted file, wrapped by a master key, as stored in its header.
+type dataKeyFile struct {
+	MasterKeyID    string `json:"master_key_id"`
+	WrappedDataKey []byte `json:"wrapped_data_key"`
+}
+
+// encryptedFileHeader is the header an encrypted file starts with. size is the size of the header itself.
+type encryptedFileHeader struct {
+	chunkSize uint32
+	keyFile   dataKeyFile
+	size      uint64
+}
+
+// EncryptedClient encrypts the files written through the client it wraps. Each file is encrypted with its own
+// random data key using AES-GCM, chunk by chunk, so that a range of a file can be decrypted without reading
+// it from the start. Its data key, wrapped by the master key, is stored in its header, so that the key and
+// the content of a file are always written together. The files written before encryption was enabled have
+// no header and are read as they are. It does not implement PresignedURLClient, a presigned URL would serve
+// the encrypted content.
+//
+// An encrypted file starts with a header made of encryptedFileMagic, the chunk size, and the size and JSON of
+// its dataKeyFile, followed by the sealed chunks. The nonce of a chunk is its index, with a flag on the last
+// one so that a truncated file does not decrypt.
+type EncryptedClient struct {
+	client       Client
+	masterKeyMap map[string][]byte
+	masterKeyID  string
+	chunkSize    uint32
+	logger       *zap.Logger
+}
+
+func NewEncryptedClient(
+	client Client,
+	encryptionConfig config.DownloadEncryption,
+	logger *zap.Logger,
+) (Client, error) {
+	masterKeyMap, err := encryptionConfig.GetMasterKeyMap()
+	if err != nil {
+		return nil, err
+	}
+
+	chunkSize, err := encryptionConfig.GetChunkSizeInBytes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse encryption chunk_size: %w", err)
+	}
+
+	if chunkSize == 0 || chunkSize > 1<<30 {
+		return nil, fmt.Errorf("invalid encryption chunk_size: %d", chunkSize)
+	}
+
+	return &EncryptedClient{
+		client:       client,
+		masterKeyMap: masterKeyMap,
+		masterKeyID:  encryptionConfig.MasterKeyID,
+		chunkSize:    uint32(chunkSize),
+		logger:       logger,
+	}, nil
+}
+
+// Write implements Client.
+func (e *EncryptedClient) Write(ctx context.Context, filePath string) (FileWriter, error) {
+	return e.write(ctx, filePath, func() (FileWriter, error) {
+		return e.client.Write(ctx, filePath)
+	})
+}
+
+// WriteSized implements SizedWriteClient, with the size of the encrypted file. It writes the file with Write
+// if the wrapped client does not implement SizedWriteClient.
+func (e *EncryptedClient) WriteSized(ctx context.Context, filePath string, size uint64) (FileWriter, error) {
+	sizedWriteClient, ok := e.client.(SizedWriteClient)
+	if !ok {
+		return e.Write(ctx, filePath)
+	}
+
+	encryptedSize := uint64(encryptedFileMaxHeaderSize) + size + (size/uint64(e.chunkSize)+1)*aesGCMTagSize
+	return e.write(ctx, filePath, func() (FileWriter, error) {
+		return sizedWriteClient.WriteSized(ctx, filePath, encryptedSize)
+	})
+}
+
+func (e *EncryptedClient) write(
+	ctx context.Context,
+	filePath string,
+	openWriteCloser func() (FileWriter, error),
+) (FileWriter, error) {
+	logger := utils.LoggerWithContext(ctx, e.logger).With(zap.String("file_path", filePath))
+
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		logger.With(zap.Error(err)).Error("failed to generate data key")
+		return nil, status.Error(codes.Internal, "failed to generate data key")
+	}
+
+	keyFile, err := e.wrapDataKey(ctx, filePath, dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := marshalEncryptedFileHeader(e.chunkSize, keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	dataAEAD, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	writeCloser, err := openWriteCloser()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = writeCloser.Write(header); err != nil {
+		_ = writeCloser.Abort()
+		return nil, err
+	}
+
+	return &encryptingWriter{
+		writeCloser: writeCloser,
+		aead:        dataAEAD,
+		plaintext:   make([]byte, 0, e.chunkSize),
+		ciphertext:  make([]byte, 0, int(e.chunkSize)+dataAEAD.Overhead()),
+	}, nil
+}
+
+// Read implements Client.
+func (e *EncryptedClient) Read(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	return e.ReadRange(ctx, filePath, 0, 0)
+}
+
+// ReadRange implements Client. Only the chunks the range overlaps are read and decrypted.
+func (e *EncryptedClient) ReadRange(ctx context.Context, filePath string, offset uint64, length uint64) (io.ReadCloser, error) {
+	header, encrypted, err := e.readHeader(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if !encrypted {
+		return e.client.ReadRange(ctx, filePath, offset, length)
+	}
+
+	dataKey, err := e.unwrapDataKey(filePath, header.keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	dataAEAD, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	chunkIndex := offset / uint64(header.chunkSize)
+	sealedChunkSize := uint64(header.chunkSize) + uint64(dataAEAD.Overhead())
+	readCloser, err := e.client.ReadRange(ctx, filePath, header.size+chunkIndex*sealedChunkSize, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &decryptingReader{
+		readCloser:     readCloser,
+		bufferedReader: bufio.NewReaderSize(readCloser, int(sealedChunkSize)),
+		aead:           dataAEAD,
+		ciphertext:     make([]byte, sealedChunkSize),
+		chunkIndex:     chunkIndex,
+		skippedCount:   offset % uint64(header.chunkSize),
+		remainingCount: length,
+		limited:        length > 0,
+	}, nil
+}
+
+// Delete implements Client.
+func (e *EncryptedClient) Delete(ctx context.Context, filePath string) error {
+	return e.client.Delete(ctx, filePath)
+}
+
+// Stat implements Client. The size of an encrypted file is the size of its content once decrypted.
+func (e *EncryptedClient) Stat(ctx context.Context, filePath string) (FileInfo, error) {
+	fileInfo, err := e.client.Stat(ctx, filePath)
+	if err != nil {
+		return FileInfo{}, err
+	}
+
+	return e.getPlaintextFileInfo(ctx, fileInfo)
+}
+
+// Walk implements Client.
+func (e *EncryptedClient) Walk(ctx context.Context, fn func(fileInfo FileInfo) error) error {
+	return e.client.Walk(ctx, func(fileInfo FileInfo) error {
+		plaintextFileInfo, err := e.getPlaintextFileInfo(ctx, fileInfo)
+		if err != nil {
+			// The file was deleted since it was listed.
+			if _, statErr := e.client.Stat(ctx, fileInfo.Path); errors.Is(statErr, ErrFileNotFound) {
+				return nil
+			}
+
+			return err
+		}
+
+		return fn(plaintextFileInfo)
+	})
+}
+
+// RewrapDataKeys implements DataKeyRewrapClient. The data key is in the header of a file, so the file is
+// written again with the new header followed by its sealed chunks as they are, which are not decrypted.
+func (e *EncryptedClient) RewrapDataKeys(ctx context.Context) (uint64, error) {
+	var rewrappedCount uint64
+	err := e.client.Walk(ctx, func(fileInfo FileInfo) error {
+		header, encrypted, err := e.readHeader(ctx, fileInfo.Path)
+		if err != nil {
+			return err
+		}
+
+		if !encrypted || header.keyFile.MasterKeyID == e.masterKeyID {
+			return nil
+		}
+
+		if err = e.rewrapDataKey(ctx, fileInfo.Path, header); err != nil {
+			return err
+		}
+
+		rewrappedCount++
+		return nil
+	})
+
+	return rewrappedCount, err
+}
+
+func (e *EncryptedClient) rewrapDataKey(ctx context.Context, filePath string, header encryptedFileHeader) error {
+	logger := utils.LoggerWithContext(ctx, e.logger).With(zap.String("file_path", filePath))
+
+	dataKey, err := e.unwrapDataKey(filePath, header.keyFile)
+	if err != nil {
+		return err
+	}
+
+	keyFile, err := e.wrapDataKey(ctx, filePath, dataKey)
+	if err != nil {
+		return err
+	}
+
+	newHeader, err := marshalEncryptedFileHeader(header.chunkSize, keyFile)
+	if err != nil {
+		return err
+	}
+
+	readCloser, err := e.client.ReadRange(ctx, filePath, header.size, 0)
+	if err != nil {
+		return err
+	}
+
+	defer readCloser.Close()
+
+	writeCloser, err := e.client.Write(ctx, filePath)
+	if err != nil {
+		return err
+	}
+
+	if _, err = writeCloser.Write(newHeader); err == nil {
+		_, err = io.Copy(writeCloser, readCloser)
+	}
+
+	if err != nil {
+		_ = writeCloser.Abort()
+		logger.With(zap.Error(err)).Error("failed to write file with data key wrapped again")
+		return status.Error(codes.Internal, "failed to write file with data key wrapped again")
+	}
+
+	return writeCloser.Close()
+}
+
+// getPlaintextFileInfo turns the info of a stored file into the info of its decrypted content.
+func (e *EncryptedClient) getPlaintextFileInfo(ctx context.Context, fileInfo FileInfo) (FileInfo, error) {
+	header, encrypted, err := e.readHeader(ctx, fileInfo.Path)
+	if err != nil {
+		return FileInfo{}, err
+	}
+
+	if !encrypted {
+		return fileInfo, nil
+	}
+
+	// Every chunk, including the last one which may be empty, is sealed with a tag.
+	tagSize := uint64(aesGCMTagSize)
+	sealedChunkSize := uint64(header.chunkSize) + tagSize
+	sealedSize := fileInfo.Size - min(fileInfo.Size, header.size)
+	chunkCount := (sealedSize + sealedChunkSize - 1) / sealedChunkSize
+	fileInfo.Size = sealedSize - min(sealedSize, chunkCount*tagSize)
+	return fileInfo, nil
+}
+
+// readHeader reads the header of a file, returning false if the file does not start with one, in which case
+// it is not encrypted.
+func (e *EncryptedClient) readHeader(ctx context.Context, filePath string) (encryptedFileHeader, bool, error) {
+	logger := utils.LoggerWithContext(ctx, e.logger).With(zap.String("file_path", filePath))
+
+	readCloser, err := e.client.ReadRange(ctx, filePath, 0, encryptedFileMaxHeaderSize)
+	if err != nil {
+		return encryptedFileHeader{}, false, err
+	}
+
+	defer readCloser.Close()
+
+	fixedHeader := make([]byte, encryptedFileFixedHeaderSize)
+	if _, err = io.ReadFull(readCloser, fixedHeader); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return encryptedFileHeader{}, false, nil
+		}
+
+		// Some storages fail to read a range of an empty file rather than reading nothing.
+		if fileInfo, statErr := e.client.Stat(ctx, filePath); statErr == nil &&
+			fileInfo.Size < uint64(encryptedFileFixedHeaderSize) {
+			return encryptedFileHeader{}, false, nil
+		}
+
+		return encryptedFileHeader{}, false, err
+	}
+
+	if string(fixedHeader[:len(encryptedFileMagic)]) != encryptedFileMagic {
+		return encryptedFileHeader{}, false, nil
+	}
+
+	chunkSize := binary.BigEndian.Uint32(fixedHeader[len(encryptedFileMagic):])
+	keyFileSize := binary.BigEndian.Uint16(fixedHeader[len(encryptedFileMagic)+4:])
+	if chunkSize == 0 || int(keyFileSize) > encryptedFileMaxHeaderSize-encryptedFileFixedHeaderSize {
+		logger.Error("invalid encrypted file header")
+		return encryptedFileHeader{}, false, errFileDecryptionFailed
+	}
+
+	keyFileContent := make([]byte, keyFileSize)
+	if _, err = io.ReadFull(readCloser, keyFileContent); err != nil {
+		logger.With(zap.Error(err)).Error("failed to read data key of encrypted file header")
+		return encryptedFileHeader{}, false, errFileDecryptionFailed
+	}
+
+	var keyFile dataKeyFile
+	if err = json.Unmarshal(keyFileContent, &keyFile); err != nil {
+		logger.With(zap.Error(err)).Error("failed to read data key of encrypted file header")
+		return encryptedFileHeader{}, false, errFileDecryptionFailed
+	}
+
+	return encryptedFileHeader{
+		chunkSize: chunkSize,
+		keyFile:   keyFile,
+		size:      uint64(encryptedFileFixedHeaderSize) + uint64(keyFileSize),
+	}, true, nil
+}
+
+func marshalEncryptedFileHeader(chunkSize uint32, keyFile dataKeyFile) ([]byte, error) {
+	keyFileContent, err := json.Marshal(keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, encryptedFileFixedHeaderSize, encryptedFileFixedHeaderSize+len(keyFileContent))
+	copy(header, encryptedFileMagic)
+	binary.BigEndian.PutUint32(header[len(encryptedFileMagic):], chunkSize)
+	binary.BigEndian.PutUint16(header[len(encryptedFileMagic)+4:], uint16(len(keyFileContent)))
+	return append(header, keyFileContent...), nil
+}
+
+// unwrapDataKey decrypts a data key with the master key it was wrapped by. The path of the file is
+// authenticated along with it, so that the data key of a file can not be used for another.
+func (e *EncryptedClient) unwrapDataKey(filePath string, keyFile dataKeyFile) ([]byte, error) {
+	logger := e.logger.With(zap.String("file_path", filePath)).With(zap.String("master_key_id", keyFile.MasterKeyID))
+
+	masterKey, ok := e.masterKeyMap[keyFile.MasterKeyID]
+	if !ok {
+		logger.Error("data key is wrapped by a master key that is not configured")
+		return nil, errFileDecryptionFailed
+	}
+
+	masterAEAD, err := newAEAD(masterKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keyFile.WrappedDataKey) < masterAEAD.NonceSize() {
+		logger.Error("wrapped data key is too short")
+		return nil, errFileDecryptionFailed
+	}
+
+	nonce, wrappedDataKey := keyFile.WrappedDataKey[:masterAEAD.NonceSize()], keyFile.WrappedDataKey[masterAEAD.NonceSize():]
+	dataKey, err := masterAEAD.Open(nil, nonce, wrappedDataKey, []byte(filePath))
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to unwrap data key")
+		return nil, errFileDecryptionFailed
+	}
+
+	return dataKey, nil
+}
+
+// wrapDataKey encrypts a data key with the current master key.
+func (e *EncryptedClient) wrapDataKey(ctx context.Context, filePath string, dataKey []byte) (dataKeyFile, error) {
+	logger := utils.LoggerWithContext(ctx, e.logger).With(zap.String("file_path", filePath))
+
+	masterAEAD, err := newAEAD(e.masterKeyMap[e.masterKeyID])
+	if err != nil {
+		return dataKeyFile{}, err
+	}
+
+	nonce := make([]byte, masterAEAD.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		logger.With(zap.Error(err)).Error("failed to generate nonce")
+		return dataKeyFile{}, status.Error(codes.Internal, "failed to generate nonce")
+	}
+
+	return dataKeyFile{
+		MasterKeyID:    e.masterKeyID,
+		WrappedDataKey: masterAEAD.Seal(nonce, nonce, dataKey, []byte(filePath)),
+	}, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to create cipher")
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func getChunkNonce(aead cipher.AEAD, chunkIndex uint64, last bool) []byte {
+	nonce := make([]byte, aead.NonceSize())
+	binary.BigEndian.PutUint64(nonce, chunkIndex)
+	if last {
+		nonce[chunkNonceLastFlagIndex] = 1
+	}
+
+	return nonce
+}
+
+// encryptingWriter seals the content written to it chunk by chunk. A full chunk is only sealed once more
+// content comes, the chunk left when it is closed is sealed as the last one.
+type encryptingWriter struct {
+	writeCloser FileWriter
+	aead        cipher.AEAD
+	plaintext   []byte
+	ciphertext  []byte
+	chunkIndex  uint64
+	closed      bool
+}
+
+func (e *encryptingWriter) Write(p []byte) (int, error) {
+	writtenCount := 0
+	for len(p) > 0 {
+		if len(e.plaintext) == cap(e.plaintext) {
+			if err := e.sealChunk(false); err != nil {
+				return writtenCount, err
+			}
+		}
+
+		copiedCount := copy(e.plaintext[len(e.plaintext):cap(e.plaintext)], p)
+		e.plaintext = e.plaintext[:len(e.plaintext)+copiedCount]
+		p = p[copiedCount:]
+		writtenCount += copiedCount
+	}
+
+	return writtenCount, nil
+}
+
+func (e *encryptingWriter) Close() error {
+	if e.closed {
+		return nil
+	}
+
+	e.closed = true
+	if err := e.sealChunk(true); err != nil {
+		return errors.Join(err, e.writeCloser.Abort())
+	}
+
+	return e.writeCloser.Close()
+}
+
+// Abort implements FileWriter.
+func (e *encryptingWriter) Abort() error {
+	if e.closed {
+		return nil
+	}
+
+	e.closed = true
+	return e.writeCloser.Abort()
+}
+
+func (e *encryptingWriter) sealChunk(last bool) error {
+	e.ciphertext = e.aead.Seal(e.ciphertext[:0], getChunkNonce(e.aead, e.chunkIndex, last), e.plaintext, nil)
+	if _, err := e.writeCloser.Write(e.ciphertext); err != nil {
+		return err
+	}
+
+	e.chunkIndex++
+	e.plaintext = e.plaintext[:0]
+	return nil
+}
+
+// decryptingReader opens the sealed chunks read from readCloser, starting at chunkIndex. It skips the first
+// skippedCount bytes of content and, if limited, stops after remainingCount bytes.
+type decryptingReader struct {
+	readCloser     io.ReadCloser
+	bufferedReader *bufio.Reader
+	aead           cipher.AEAD
+	ciphertext     []byte
+	plaintext      []byte
+	chunkIndex     uint64
+	skippedCount   uint64
+	remainingCount uint64
+	limited        bool
+	openedChunk    bool
+	done           bool
+}
+
+func (d *decryptingReader) Read(p []byte) (int, error) {
+	if d.limited && d.remainingCount == 0 {
+		return 0, io.EOF
+	}
+
+	for len(d.plaintext) == 0 {
+		if d.done {
+			return 0, io.EOF
+		}
+
+		if err := d.openChunk(); err != nil {
+			return 0, err
+		}
+	}
+
+	if d.limited {
+		p = p[:min(uint64(len(p)), d.remainingCount)]
+	}
+
+	readCount := copy(p, d.plaintext)
+	d.plaintext = d.plaintext[readCount:]
+	d.remainingCount -= min(d.remainingCount, uint64(readCount))
+	return readCount, nil
+}
+
+func (d *decryptingReader) Close() error {
+	return d.readCloser.Close()
+}
+
+func (d *decryptingReader) openChunk() error {
+	readCount, err := io.ReadFull(d.bufferedReader, d.ciphertext)
+	last := false
+	switch {
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		last = true
+	case errors.Is(err, io.EOF):
+		// The range starts past the end of the file, otherwise the file ended without its last chunk.
+		if !d.openedChunk {
+			d.done = true
+			return nil
+		}
+
+		return errFileDecryptionFailed
+	case err != nil:
+		return err
+	default:
+		if _, err = d.bufferedReader.Peek(1); errors.Is(err, io.EOF) {
+			last = true
+		} else if err != nil {
+			return err
+		}
+	}
+
+	plaintext, err := d.aead.Open(
+		d.ciphertext[:0], getChunkNonce(d.aead, d.chunkIndex, last), d.ciphertext[:readCount], nil,
+	)
+	if err != nil {
+		return errFileDecryptionFailed
+	}
+
+	d.chunkIndex++
+	d.openedChunk = true
+	d.done = last
+	d.plaintext = plaintext[min(uint64(len(plaintext)), d.skippedCount):]
+	d.skippedCount = 0
+	return nil
+}
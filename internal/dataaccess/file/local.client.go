@@ -6,8 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
-	"path"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/manhhung2111/go-idm/internal/config"
 	"github.com/manhhung2111/go-idm/internal/utils"
@@ -38,51 +41,441 @@ func (b *bufferedFileReader) Read(p []byte) (int, error) {
 	return b.bufferedReader.Read(p)
 }
 
+const (
+	localTempFilePrefix = "."
+	localTempFileSuffix = ".tmp"
+	// localStaleTempFileAge is how long a temporary file has to go unmodified to be considered left over by a
+	// process that was killed while writing it.
+	localStaleTempFileAge = 24 * time.Hour
+)
+
+// LocalClient stores files in a directory of the local disk. A file is written to a temporary file of the
+// directory, which is synced then renamed to the file once closed, so that a file is never seen partially
+// written. The paths given to it have to stay within the directory, symbolic links included. The stale
+// temporary files, left over by a process killed while writing, are deleted when the client is created.
 type LocalClient struct {
-	downloadDirectory string
-	logger            *zap.Logger
+	downloadDirectory         string
+	resolvedDownloadDirectory string
+	preallocate               bool
+	logger                    *zap.Logger
 }
 
 func NewLocalClient(
-	downloadConfig config.Download,
+	storageConfig config.DownloadStorage,
 	logger *zap.Logger,
 ) (Client, error) {
-	if err := os.MkdirAll(downloadConfig.DownloadDirectory, os.ModeDir); err != nil {
-		if !errors.Is(err, os.ErrExist) {
-			return nil, fmt.Errorf("failed to create download directory: %w", err)
+	if err := os.MkdirAll(storageConfig.DownloadDirectory, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create download directory: %w", err)
+	}
+
+	resolvedDownloadDirectory, err := resolvePath(storageConfig.DownloadDirectory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve download directory: %w", err)
+	}
+
+	localClient := &LocalClient{
+		downloadDirectory:         storageConfig.DownloadDirectory,
+		resolvedDownloadDirectory: resolvedDownloadDirectory,
+		preallocate:               storageConfig.Preallocate,
+		logger:                    logger,
+	}
+
+	if err := localClient.deleteStaleTempFiles(); err != nil {
+		logger.With(zap.Error(err)).Warn("failed to delete stale temporary files of download directory")
+	}
+
+	return localClient, nil
+}
+
+// deleteStaleTempFiles deletes the temporary files not modified for localStaleTempFileAge. The ones of the
+// writes still in progress, by this process or another one sharing the directory, are modified as they go.
+func (l *LocalClient) deleteStaleTempFiles() error {
+	logger := l.logger.With(zap.String("download_directory", l.downloadDirectory))
+
+	deletedCount := 0
+	err := filepath.WalkDir(l.downloadDirectory, func(absolutePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() || !isLocalTempFile(entry.Name()) {
+			return nil
 		}
+
+		stat, err := entry.Info()
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+
+			return err
+		}
+
+		if time.Since(stat.ModTime()) < localStaleTempFileAge {
+			return nil
+		}
+
+		if err = os.Remove(absolutePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			logger.With(zap.String("path", absolutePath)).With(zap.Error(err)).Warn("failed to delete stale temporary file")
+			return nil
+		}
+
+		deletedCount++
+		return nil
+	})
+
+	if deletedCount > 0 {
+		logger.With(zap.Int("count", deletedCount)).Info("deleted stale temporary files")
 	}
 
-	return &LocalClient{
-		downloadDirectory: downloadConfig.DownloadDirectory,
-		logger:            logger,
-	}, nil
+	return err
+}
+
+// getAbsolutePath returns the path of a file within the download directory, or ErrInvalidFilePath if the
+// path is absolute, goes up out of the download directory, or leads out of it through a symbolic link. With
+// followFile false, a symbolic link named by the path itself is not followed, for the operations acting on the
+// link rather than on what it points to.
+func (l *LocalClient) getAbsolutePath(filePath string, followFile bool) (string, error) {
+	localFilePath := filepath.FromSlash(filePath)
+	if !filepath.IsLocal(localFilePath) {
+		return "", ErrInvalidFilePath
+	}
+
+	absolutePath := filepath.Join(l.downloadDirectory, localFilePath)
+	checkedPath := absolutePath
+	if !followFile {
+		checkedPath = filepath.Dir(absolutePath)
+	}
+
+	// The part of the path that does not exist yet can not hold a symbolic link, only the longest part that
+	// exists is resolved.
+	for {
+		resolvedPath, err := resolvePath(checkedPath)
+		if err == nil {
+			relativePath, err := filepath.Rel(l.resolvedDownloadDirectory, resolvedPath)
+			if err != nil || !filepath.IsLocal(relativePath) {
+				return "", ErrInvalidFilePath
+			}
+
+			return absolutePath, nil
+		}
+
+		if !errors.Is(err, fs.ErrNotExist) {
+			l.logger.With(zap.String("file_path", filePath)).With(zap.Error(err)).Error("failed to resolve file path")
+			return "", status.Error(codes.Internal, "failed to resolve file path")
+		}
+
+		if checkedPath == filepath.Clean(l.downloadDirectory) {
+			return absolutePath, nil
+		}
+
+		checkedPath = filepath.Dir(checkedPath)
+	}
+}
+
+// resolvePath returns the absolute path of a file with the symbolic links on the way followed.
+func resolvePath(path string) (string, error) {
+	resolvedPath, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Abs(resolvedPath)
 }
 
 // Read implements Client.
 func (l *LocalClient) Read(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	return l.ReadRange(ctx, filePath, 0, 0)
+}
+
+// ReadRange implements Client.
+func (l *LocalClient) ReadRange(ctx context.Context, filePath string, offset uint64, length uint64) (io.ReadCloser, error) {
 	logger := utils.LoggerWithContext(ctx, l.logger).With(zap.String("file_path", filePath))
 
-	absolutePath := path.Join(l.downloadDirectory, filePath)
+	absolutePath, err := l.getAbsolutePath(filePath, true)
+	if err != nil {
+		return nil, err
+	}
+
 	file, err := os.Open(absolutePath)
 	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrFileNotFound
+		}
+
 		logger.With(zap.Error(err)).Error("failed to open file")
 		return nil, status.Error(codes.Internal, "failed to open file")
 	}
 
-	return newBufferedFileReader(file), nil
+	if offset > 0 {
+		fileInfo, err := file.Stat()
+		if err != nil {
+			_ = file.Close()
+			logger.With(zap.Error(err)).Error("failed to stat file")
+			return nil, status.Error(codes.Internal, "failed to stat file")
+		}
+
+		if offset > uint64(fileInfo.Size()) {
+			_ = file.Close()
+			return nil, ErrOffsetOutOfRange
+		}
+
+		if _, err = file.Seek(int64(offset), io.SeekStart); err != nil {
+			_ = file.Close()
+			logger.With(zap.Error(err)).Error("failed to seek file")
+			return nil, status.Error(codes.Internal, "failed to seek file")
+		}
+	}
+
+	if length == 0 {
+		return newBufferedFileReader(file), nil
+	}
+
+	return &bufferedFileReader{
+		file:           file,
+		bufferedReader: io.LimitReader(bufio.NewReader(file), int64(length)),
+	}, nil
 }
 
 // Write implements Client.
-func (l *LocalClient) Write(ctx context.Context, filePath string) (io.WriteCloser, error) {
+func (l *LocalClient) Write(ctx context.Context, filePath string) (FileWriter, error) {
+	return l.write(ctx, filePath, 0)
+}
+
+// WriteSized implements SizedWriteClient. The space of the file is allocated up front if preallocation is
+// enabled and supported, so that the file is not fragmented and the disk can not run out of space midway.
+func (l *LocalClient) WriteSized(ctx context.Context, filePath string, size uint64) (FileWriter, error) {
 	logger := utils.LoggerWithContext(ctx, l.logger).With(zap.String("file_path", filePath))
 
-	absolutePath := path.Join(l.downloadDirectory, filePath)
-	file, err := os.Create(absolutePath)
+	freeSpace, ok, err := getFreeSpace(l.downloadDirectory)
+	if err != nil {
+		logger.With(zap.Error(err)).Warn("failed to get free space of download directory, will write file anyway")
+	} else if ok && freeSpace < size {
+		logger.With(zap.Uint64("size", size)).With(zap.Uint64("free_space", freeSpace)).Error("not enough free space to write file")
+		return nil, ErrNotEnoughSpace
+	}
+
+	return l.write(ctx, filePath, size)
+}
+
+func (l *LocalClient) write(ctx context.Context, filePath string, size uint64) (FileWriter, error) {
+	logger := utils.LoggerWithContext(ctx, l.logger).With(zap.String("file_path", filePath))
+
+	absolutePath, err := l.getAbsolutePath(filePath, false)
+	if err != nil {
+		return nil, err
+	}
+
+	tempFile, err := os.CreateTemp(
+		filepath.Dir(absolutePath), localTempFilePrefix+filepath.Base(absolutePath)+".*"+localTempFileSuffix,
+	)
 	if err != nil {
 		logger.With(zap.Error(err)).Error("failed to open file")
 		return nil, status.Error(codes.Internal, "failed to open file")
 	}
 
-	return file, nil
+	preallocated := false
+	if l.preallocate && size > 0 {
+		if err = preallocateFile(tempFile, size); err != nil {
+			logger.With(zap.Error(err)).Warn("failed to preallocate file")
+		} else {
+			preallocated = true
+		}
+	}
+
+	return &localFileWriter{
+		ctx:          ctx,
+		tempFile:     tempFile,
+		absolutePath: absolutePath,
+		preallocated: preallocated,
+		logger:       logger,
+	}, nil
+}
+
+// localFileWriter writes a file of LocalClient to its temporary file. Closing it syncs the temporary file and
+// renames it to the file, unless the context it was opened with is done, in which case the write is
+// abandoned as if aborted. Aborting it deletes the temporary file.
+type localFileWriter struct {
+	ctx          context.Context
+	tempFile     *os.File
+	absolutePath string
+	preallocated bool
+	writtenCount int64
+	closed       bool
+	logger       *zap.Logger
+}
+
+func (l *localFileWriter) Write(p []byte) (int, error) {
+	writtenCount, err := l.tempFile.Write(p)
+	l.writtenCount += int64(writtenCount)
+	return writtenCount, err
+}
+
+func (l *localFileWriter) Close() error {
+	if l.closed {
+		return nil
+	}
+
+	l.closed = true
+	if err := l.commit(); err != nil {
+		l.discard()
+		return err
+	}
+
+	return nil
+}
+
+// Abort implements FileWriter.
+func (l *localFileWriter) Abort() error {
+	if l.closed {
+		return nil
+	}
+
+	l.closed = true
+	l.discard()
+	return nil
+}
+
+func (l *localFileWriter) discard() {
+	_ = l.tempFile.Close()
+	if err := os.Remove(l.tempFile.Name()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		l.logger.With(zap.Error(err)).Warn("failed to delete temporary file")
+	}
+}
+
+func (l *localFileWriter) commit() error {
+	if err := l.ctx.Err(); err != nil {
+		return err
+	}
+
+	// The space preallocated past what was written is given back.
+	if l.preallocated {
+		if err := l.tempFile.Truncate(l.writtenCount); err != nil {
+			l.logger.With(zap.Error(err)).Error("failed to truncate file")
+			return status.Error(codes.Internal, "failed to write file")
+		}
+	}
+
+	if err := l.tempFile.Sync(); err != nil {
+		l.logger.With(zap.Error(err)).Error("failed to sync file")
+		return status.Error(codes.Internal, "failed to write file")
+	}
+
+	if err := l.tempFile.Close(); err != nil {
+		l.logger.With(zap.Error(err)).Error("failed to close file")
+		return status.Error(codes.Internal, "failed to write file")
+	}
+
+	if err := os.Rename(l.tempFile.Name(), l.absolutePath); err != nil {
+		l.logger.With(zap.Error(err)).Error("failed to rename file")
+		return status.Error(codes.Internal, "failed to write file")
+	}
+
+	// The rename is only durable once the directory is synced.
+	if err := syncDirectory(filepath.Dir(l.absolutePath)); err != nil {
+		l.logger.With(zap.Error(err)).Warn("failed to sync download directory")
+	}
+
+	return nil
+}
+
+func syncDirectory(directory string) error {
+	directoryFile, err := os.Open(directory)
+	if err != nil {
+		return err
+	}
+
+	defer directoryFile.Close()
+	return directoryFile.Sync()
+}
+
+// isLocalTempFile returns whether a file is the temporary file of a write in progress, or of a write that
+// was interrupted.
+func isLocalTempFile(fileName string) bool {
+	return strings.HasPrefix(fileName, localTempFilePrefix) && strings.HasSuffix(fileName, localTempFileSuffix)
+}
+
+// Delete implements Client.
+func (l *LocalClient) Delete(ctx context.Context, filePath string) error {
+	logger := utils.LoggerWithContext(ctx, l.logger).With(zap.String("file_path", filePath))
+
+	absolutePath, err := l.getAbsolutePath(filePath, false)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(absolutePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		logger.With(zap.Error(err)).Error("failed to delete file")
+		return status.Error(codes.Internal, "failed to delete file")
+	}
+
+	return nil
+}
+
+// Stat implements Client.
+func (l *LocalClient) Stat(ctx context.Context, filePath string) (FileInfo, error) {
+	logger := utils.LoggerWithContext(ctx, l.logger).With(zap.String("file_path", filePath))
+
+	absolutePath, err := l.getAbsolutePath(filePath, true)
+	if err != nil {
+		return FileInfo{}, err
+	}
+
+	stat, err := os.Stat(absolutePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return FileInfo{}, ErrFileNotFound
+		}
+
+		logger.With(zap.Error(err)).Error("failed to stat file")
+		return FileInfo{}, status.Error(codes.Internal, "failed to stat file")
+	}
+
+	return FileInfo{
+		Path:         filePath,
+		Size:         uint64(stat.Size()),
+		ModifiedTime: stat.ModTime(),
+	}, nil
+}
+
+// Walk implements Client. The temporary files of the writes are skipped.
+func (l *LocalClient) Walk(ctx context.Context, fn func(fileInfo FileInfo) error) error {
+	logger := utils.LoggerWithContext(ctx, l.logger)
+
+	return filepath.WalkDir(l.downloadDirectory, func(absolutePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			logger.With(zap.String("path", absolutePath)).With(zap.Error(err)).Error("failed to walk download directory")
+			return status.Error(codes.Internal, "failed to walk download directory")
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if entry.IsDir() || isLocalTempFile(entry.Name()) {
+			return nil
+		}
+
+		stat, err := entry.Info()
+		if err != nil {
+			// The file was deleted since the directory was read.
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+
+			logger.With(zap.String("path", absolutePath)).With(zap.Error(err)).Error("failed to stat file")
+			return status.Error(codes.Internal, "failed to stat file")
+		}
+
+		filePath, err := filepath.Rel(l.downloadDirectory, absolutePath)
+		if err != nil {
+			return err
+		}
+
+		return fn(FileInfo{
+			Path:         filepath.ToSlash(filePath),
+			Size:         uint64(stat.Size()),
+			ModifiedTime: stat.ModTime(),
+		})
+	})
 }
@@ -0,0 +1,23 @@
+//go:build linux
+
+package file
+
+import (
+	"os"
+	"syscall"
+)
+
+// getFreeSpace returns the space available to write files in directory.
+func getFreeSpace(directory string) (uint64, bool, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(directory, &stat); err != nil {
+		return 0, false, err
+	}
+
+	return stat.Bavail * uint64(stat.Bsize), true, nil
+}
+
+// preallocateFile allocates the disk space of size bytes of file with fallocate.
+func preallocateFile(file *os.File, size uint64) error {
+	return syscall.Fallocate(int(file.Fd()), 0, 0, int64(size))
+}
@@ -0,0 +1,17 @@
+//go:build !linux
+
+package file
+
+import (
+	"os"
+)
+
+// getFreeSpace does not know the free space outside of Linux, files are written whatever their size.
+func getFreeSpace(directory string) (uint64, bool, error) {
+	return 0, false, nil
+}
+
+// preallocateFile does nothing outside of Linux, where fallocate is not available.
+func preallocateFile(file *os.File, size uint64) error {
+	return nil
+}
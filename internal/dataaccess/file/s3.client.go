@@ -2,7 +2,9 @@ package file
 
 import (
 	"context"
+	"errors"
 	"io"
+	"time"
 
 	"github.com/manhhung2111/go-idm/internal/config"
 	"github.com/manhhung2111/go-idm/internal/utils"
@@ -13,15 +15,19 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+var (
+	errFileWriteAborted = errors.New("file write aborted")
+)
+
 type S3Client struct {
 	minioClient *minio.Client
 	bucket      string
 	logger      *zap.Logger
 }
 
-func NewS3Client(downloadConfig config.Download, logger *zap.Logger) (Client, error) {
-	minioClient, err := minio.New(downloadConfig.Address, &minio.Options{
-		Creds:  credentials.NewStaticV4(downloadConfig.Username, downloadConfig.Password, ""),
+func NewS3Client(storageConfig config.DownloadStorage, logger *zap.Logger) (Client, error) {
+	minioClient, err := minio.New(storageConfig.Address, &minio.Options{
+		Creds:  credentials.NewStaticV4(storageConfig.Username, storageConfig.Password, ""),
 		Secure: false,
 	})
 
@@ -32,7 +38,7 @@ func NewS3Client(downloadConfig config.Download, logger *zap.Logger) (Client, er
 
 	return &S3Client{
 		minioClient: minioClient,
-		bucket:      downloadConfig.Bucket,
+		bucket:      storageConfig.Bucket,
 		logger:      logger,
 	}, nil
 }
@@ -54,10 +60,37 @@ func (s S3Client) Read(ctx context.Context, filePath string) (io.ReadCloser, err
 	return obj, nil
 }
 
-func (s S3Client) Write(ctx context.Context, filePath string) (io.WriteCloser, error) {
+// ReadRange implements Client.
+func (s S3Client) ReadRange(ctx context.Context, filePath string, offset uint64, length uint64) (io.ReadCloser, error) {
+	logger := utils.LoggerWithContext(ctx, s.logger).With(zap.String("file_path", filePath))
+
+	getObjectOptions := minio.GetObjectOptions{}
+	var err error
+	if length > 0 {
+		err = getObjectOptions.SetRange(int64(offset), int64(offset+length-1))
+	} else if offset > 0 {
+		err = getObjectOptions.SetRange(int64(offset), 0)
+	}
+
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to set s3 object range")
+		return nil, status.Error(codes.InvalidArgument, "invalid file range")
+	}
+
+	obj, err := s.minioClient.GetObject(ctx, s.bucket, filePath, getObjectOptions)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to get s3 object")
+		return nil, status.Error(codes.Internal, "failed to get s3 object")
+	}
+
+	return obj, nil
+}
+
+func (s S3Client) Write(ctx context.Context, filePath string) (FileWriter, error) {
 	logger := utils.LoggerWithContext(ctx, s.logger).With(zap.String("file_path", filePath))
 
 	pr, pw := io.Pipe()
+	uploadErrChan := make(chan error, 1)
 
 	go func() {
 		defer pr.Close()
@@ -76,13 +109,129 @@ func (s S3Client) Write(ctx context.Context, filePath string) (io.WriteCloser, e
 		)
 
 		if err != nil {
-			logger.With(zap.Error(err)).Error("failed to upload to s3")
+			if !errors.Is(err, errFileWriteAborted) {
+				logger.With(zap.Error(err)).Error("failed to upload to s3")
+			}
+
 			_ = pw.CloseWithError(err)
+			uploadErrChan <- status.Error(codes.Internal, "failed to upload to s3")
 			return
 		}
 
 		_ = pw.Close()
+		uploadErrChan <- nil
 	}()
 
-	return pw, nil
+	return &s3ObjectWriter{
+		pipeWriter:    pw,
+		uploadErrChan: uploadErrChan,
+	}, nil
+}
+
+// s3ObjectWriter streams a file to an S3 object. Closing it waits for the upload to complete, so that the
+// object can be read as soon as Close returns. S3 only creates the object once the upload completes.
+type s3ObjectWriter struct {
+	pipeWriter    *io.PipeWriter
+	uploadErrChan chan error
+	uploadErr     error
+	closed        bool
+}
+
+func (s *s3ObjectWriter) Write(p []byte) (int, error) {
+	return s.pipeWriter.Write(p)
+}
+
+func (s *s3ObjectWriter) Close() error {
+	if s.closed {
+		return s.uploadErr
+	}
+
+	s.closed = true
+	_ = s.pipeWriter.Close()
+	s.uploadErr = <-s.uploadErrChan
+	return s.uploadErr
+}
+
+// Abort implements FileWriter. The upload fails with the content cut short, so the object is not created.
+func (s *s3ObjectWriter) Abort() error {
+	if s.closed {
+		return nil
+	}
+
+	s.closed = true
+	_ = s.pipeWriter.CloseWithError(errFileWriteAborted)
+	<-s.uploadErrChan
+	return nil
+}
+
+// Delete implements Client. Deleting an object that does not exist succeeds in S3.
+func (s S3Client) Delete(ctx context.Context, filePath string) error {
+	logger := utils.LoggerWithContext(ctx, s.logger).With(zap.String("file_path", filePath))
+
+	if err := s.minioClient.RemoveObject(ctx, s.bucket, filePath, minio.RemoveObjectOptions{}); err != nil {
+		logger.With(zap.Error(err)).Error("failed to delete s3 object")
+		return status.Error(codes.Internal, "failed to delete s3 object")
+	}
+
+	return nil
+}
+
+// Stat implements Client.
+func (s S3Client) Stat(ctx context.Context, filePath string) (FileInfo, error) {
+	logger := utils.LoggerWithContext(ctx, s.logger).With(zap.String("file_path", filePath))
+
+	objectInfo, err := s.minioClient.StatObject(ctx, s.bucket, filePath, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == minio.NoSuchKey {
+			return FileInfo{}, ErrFileNotFound
+		}
+
+		logger.With(zap.Error(err)).Error("failed to stat s3 object")
+		return FileInfo{}, status.Error(codes.Internal, "failed to stat s3 object")
+	}
+
+	return FileInfo{
+		Path:         filePath,
+		Size:         uint64(objectInfo.Size),
+		ModifiedTime: objectInfo.LastModified,
+	}, nil
+}
+
+// Walk implements Client.
+func (s S3Client) Walk(ctx context.Context, fn func(fileInfo FileInfo) error) error {
+	logger := utils.LoggerWithContext(ctx, s.logger)
+
+	// Canceling the listing when returning early releases the goroutine feeding the channel.
+	listCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for objectInfo := range s.minioClient.ListObjects(listCtx, s.bucket, minio.ListObjectsOptions{Recursive: true}) {
+		if objectInfo.Err != nil {
+			logger.With(zap.Error(objectInfo.Err)).Error("failed to list s3 objects")
+			return status.Error(codes.Internal, "failed to list s3 objects")
+		}
+
+		if err := fn(FileInfo{
+			Path:         objectInfo.Key,
+			Size:         uint64(objectInfo.Size),
+			ModifiedTime: objectInfo.LastModified,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return ctx.Err()
+}
+
+// GetPresignedURL implements PresignedURLClient.
+func (s S3Client) GetPresignedURL(ctx context.Context, filePath string, expiresIn time.Duration) (string, error) {
+	logger := utils.LoggerWithContext(ctx, s.logger).With(zap.String("file_path", filePath))
+
+	presignedURL, err := s.minioClient.PresignedGetObject(ctx, s.bucket, filePath, expiresIn, nil)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to presign s3 object url")
+		return "", status.Error(codes.Internal, "failed to presign s3 object url")
+	}
+
+	return presignedURL.String(), nil
 }
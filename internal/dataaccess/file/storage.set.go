@@ -0,0 +1,140 @@
+package file
+
+import (
+	"fmt"
+
+	"github.com/manhhung2111/go-idm/internal/config"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// StorageSet holds the client of every named storage files are downloaded to, encrypting the files if
+// encryption is enabled.
+type StorageSet interface {
+	// GetClient returns the client of a storage by its name.
+	GetClient(storageName string) (Client, error)
+	// GetStorageNameList returns the names of the storages, in the order they are configured.
+	GetStorageNameList() []string
+	// GetDefaultStorageName returns the storage files are downloaded to when no placement rule matches.
+	GetDefaultStorageName() string
+	// GetLegacyStorageName returns the storage of the files downloaded before the storage of their download
+	// task was recorded, empty if it is not configured.
+	GetLegacyStorageName() string
+	// ResolveStorageName returns storageNameOrMode if it is the name of a storage, or else the name of the only
+	// storage of that mode, for a storage to be given as local or s3 when there is one of each.
+	ResolveStorageName(storageNameOrMode string) (string, error)
+}
+
+type storageSet struct {
+	clientMap          map[string]Client
+	storageNameList    []string
+	storageModeMap     map[string]config.DownloadMode
+	defaultStorageName string
+	legacyStorageName  string
+}
+
+func NewStorageSet(
+	downloadConfig config.Download,
+	logger *zap.Logger,
+) (StorageSet, error) {
+	storageConfigList := downloadConfig.GetStorages()
+	clientMap := make(map[string]Client, len(storageConfigList))
+	storageNameList := make([]string, 0, len(storageConfigList))
+	storageModeMap := make(map[string]config.DownloadMode, len(storageConfigList))
+	for _, storageConfig := range storageConfigList {
+		if storageConfig.Name == "" {
+			return nil, fmt.Errorf("download storage of mode %s has no name", storageConfig.Mode)
+		}
+
+		if _, ok := clientMap[storageConfig.Name]; ok {
+			return nil, fmt.Errorf("duplicate download storage name: %s", storageConfig.Name)
+		}
+
+		storageLogger := logger.With(zap.String("storage", storageConfig.Name))
+		client, err := NewClient(storageConfig, storageLogger)
+		if err != nil {
+			return nil, err
+		}
+
+		if downloadConfig.Encryption.IsEnabled() {
+			client, err = NewEncryptedClient(client, downloadConfig.Encryption, storageLogger)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		clientMap[storageConfig.Name] = client
+		storageNameList = append(storageNameList, storageConfig.Name)
+		storageModeMap[storageConfig.Name] = storageConfig.Mode
+	}
+
+	defaultStorageName := downloadConfig.GetDefaultStorageName()
+	if _, ok := clientMap[defaultStorageName]; !ok {
+		return nil, fmt.Errorf("default download storage %s is not configured", defaultStorageName)
+	}
+
+	legacyStorageName := downloadConfig.GetLegacyStorageName()
+	if _, ok := clientMap[legacyStorageName]; legacyStorageName != "" && !ok {
+		return nil, fmt.Errorf("legacy download storage %s is not configured", legacyStorageName)
+	}
+
+	return &storageSet{
+		clientMap:          clientMap,
+		storageNameList:    storageNameList,
+		storageModeMap:     storageModeMap,
+		defaultStorageName: defaultStorageName,
+		legacyStorageName:  legacyStorageName,
+	}, nil
+}
+
+// GetClient implements StorageSet.
+func (s storageSet) GetClient(storageName string) (Client, error) {
+	client, ok := s.clientMap[storageName]
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "download storage %s is not configured", storageName)
+	}
+
+	return client, nil
+}
+
+// GetStorageNameList implements StorageSet.
+func (s storageSet) GetStorageNameList() []string {
+	return s.storageNameList
+}
+
+// GetDefaultStorageName implements StorageSet.
+func (s storageSet) GetDefaultStorageName() string {
+	return s.defaultStorageName
+}
+
+// GetLegacyStorageName implements StorageSet.
+func (s storageSet) GetLegacyStorageName() string {
+	return s.legacyStorageName
+}
+
+// ResolveStorageName implements StorageSet.
+func (s storageSet) ResolveStorageName(storageNameOrMode string) (string, error) {
+	if _, ok := s.clientMap[storageNameOrMode]; ok {
+		return storageNameOrMode, nil
+	}
+
+	storageNameList := make([]string, 0)
+	for _, storageName := range s.storageNameList {
+		if s.storageModeMap[storageName] == config.DownloadMode(storageNameOrMode) {
+			storageNameList = append(storageNameList, storageName)
+		}
+	}
+
+	switch len(storageNameList) {
+	case 0:
+		return "", status.Errorf(codes.Internal, "download storage %s is not configured", storageNameOrMode)
+	case 1:
+		return storageNameList[0], nil
+	default:
+		return "", status.Errorf(
+			codes.InvalidArgument, "several download storages are of mode %s, give one by its name: %v",
+			storageNameOrMode, storageNameList,
+		)
+	}
+}
@@ -3,5 +3,5 @@ package file
 import "github.com/google/wire"
 
 var WireSet = wire.NewSet(
-	NewClient,
+	NewStorageSet,
 )
\ No newline at end of file
@@ -2,102 +2,337 @@ package consumer
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"os"
-	"os/signal"
+	"sync"
 
 	"github.com/IBM/sarama"
 	"github.com/manhhung2111/go-idm/internal/config"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/kafka/inprocess"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/kafka/producer"
 	"github.com/manhhung2111/go-idm/internal/utils"
 	"go.uber.org/zap"
 )
 
 type HandlerFunc func(ctx context.Context, topic string, payload []byte) error
 
+// Consumer consumes the registered topics from the configured message queue backend. Every backend retries
+// failed messages and sends them to dead letter topics following the kafka retry policies.
 type Consumer interface {
 	RegisterHandler(topic string, handlerFunc HandlerFunc)
 	Start(ctx context.Context) error
+	// Stop stops reading new messages and waits for the messages being handled, canceling their handlers
+	// once ctx is done. Start returns nil after a Stop.
+	Stop(ctx context.Context) error
 }
 
-type partitionConsumerAndHandlerFunc struct {
-	topic             string
-	partitionConsumer sarama.PartitionConsumer
-	handlerFunc       HandlerFunc
+func NewConsumer(
+	queueConfig config.Queue,
+	kafkaConfig config.Kafka,
+	broker inprocess.Broker,
+	producerClient producer.Client,
+	workerPool WorkerPool,
+	logger *zap.Logger,
+) (Consumer, error) {
+	if err := validateRetryPolicies(kafkaConfig.RetryPolicies); err != nil {
+		return nil, err
+	}
+
+	switch queueConfig.Type {
+	case config.QueueTypeKafka, "":
+		return NewKafkaConsumer(kafkaConfig, producerClient, workerPool, logger)
+
+	case config.QueueTypeInProcess:
+		return NewInProcessConsumer(kafkaConfig, broker, producerClient, workerPool, logger), nil
+
+	case config.QueueTypeRedis:
+		return NewRedisConsumer(queueConfig, kafkaConfig, producerClient, workerPool, logger)
+
+	default:
+		return nil, fmt.Errorf("unsupported queue type: %s", queueConfig.Type)
+	}
 }
 
-type consumer struct {
-	saramaConsumer        sarama.Consumer
-	topicToHandlerFuncMap map[string]HandlerFunc
-	logger                *zap.Logger
+type kafkaConsumer struct {
+	saramaConsumerGroup sarama.ConsumerGroup
+	producerClient      producer.Client
+	workerPool          WorkerPool
+	retryPolicies       map[string]config.KafkaRetryPolicy
+	topicToHandlerMap   map[string]topicHandler
+	drainer             *drainer
+	logger              *zap.Logger
 }
 
-func newSaramaConfig(kafkaConfig config.Kafka) *sarama.Config {
+func newSaramaConfig(kafkaConfig config.Kafka) (*sarama.Config, error) {
 	saramaConfig := sarama.NewConfig()
 	saramaConfig.ClientID = kafkaConfig.ClientId
 	saramaConfig.Metadata.Full = true
-	return saramaConfig
+	saramaConfig.Consumer.Return.Errors = true
+	saramaConfig.Consumer.Offsets.Initial = sarama.OffsetOldest
+	saramaConfig.Consumer.Offsets.AutoCommit.Enable = true
+
+	balanceStrategy, err := getSaramaBalanceStrategy(kafkaConfig.Consumer.RebalanceStrategy)
+	if err != nil {
+		return nil, err
+	}
+
+	saramaConfig.Consumer.Group.Rebalance.GroupStrategies = []sarama.BalanceStrategy{balanceStrategy}
+	return saramaConfig, nil
 }
 
-func NewConsumer(
+func getSaramaBalanceStrategy(rebalanceStrategy config.KafkaRebalanceStrategy) (sarama.BalanceStrategy, error) {
+	switch rebalanceStrategy {
+	case config.KafkaRebalanceStrategyRange, "":
+		return sarama.NewBalanceStrategyRange(), nil
+	case config.KafkaRebalanceStrategyRoundRobin:
+		return sarama.NewBalanceStrategyRoundRobin(), nil
+	case config.KafkaRebalanceStrategySticky:
+		return sarama.NewBalanceStrategySticky(), nil
+	default:
+		return nil, fmt.Errorf("unsupported kafka rebalance strategy: %s", rebalanceStrategy)
+	}
+}
+
+func NewKafkaConsumer(
 	kafkaConfig config.Kafka,
+	producerClient producer.Client,
+	workerPool WorkerPool,
 	logger *zap.Logger,
 ) (Consumer, error) {
+	saramaConfig, err := newSaramaConfig(kafkaConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	address := kafkaConfig.Host + ":" + kafkaConfig.Port
-	saramaConsumer, err := sarama.NewConsumer([]string{address}, newSaramaConfig(kafkaConfig))
+	saramaConsumerGroup, err := sarama.NewConsumerGroup(
+		[]string{address},
+		kafkaConfig.Consumer.GetGroupId(kafkaConfig.ClientId),
+		saramaConfig,
+	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create sarama consumer: %w", err)
+		return nil, fmt.Errorf("failed to create sarama consumer group: %w", err)
 	}
 
-	return &consumer{
-		saramaConsumer: saramaConsumer,
-		logger:         logger,
-		topicToHandlerFuncMap: make(map[string]HandlerFunc),
+	return &kafkaConsumer{
+		saramaConsumerGroup: saramaConsumerGroup,
+		producerClient:      producerClient,
+		workerPool:          workerPool,
+		retryPolicies:       kafkaConfig.RetryPolicies,
+		topicToHandlerMap:   make(map[string]topicHandler),
+		drainer:             newDrainer(),
+		logger:              logger,
 	}, nil
 }
 
-func (c *consumer) RegisterHandler(topic string, handlerFunc HandlerFunc) {
-	c.topicToHandlerFuncMap[topic] = handlerFunc
+// RegisterHandler implements Consumer.
+func (c *kafkaConsumer) RegisterHandler(topic string, handlerFunc HandlerFunc) {
+	registerTopicHandler(c.topicToHandlerMap, c.retryPolicies, topic, handlerFunc)
 }
 
-func (c *consumer) consume(topic string, handlerFunc HandlerFunc, exitSignalChannel chan os.Signal) error {
-	logger := c.logger.With(zap.String("topic", topic))
+// Start joins the consumer group and consumes every partition assigned to this process until ctx is done or
+// the consumer is stopped. Consume returns on each rebalance, so it is called again in a loop to rejoin the
+// group.
+//
+// The session is run with handleCtx rather than consumeCtx: sarama ends the session of every claim as soon as
+// one of them returns, so the claims are left to stop on their own once their messages are done with.
+func (c *kafkaConsumer) Start(ctx context.Context) error {
+	logger := utils.LoggerWithContext(ctx, c.logger)
+	defer c.drainer.start(ctx)()
 
-	partitionConsumer, err := c.saramaConsumer.ConsumePartition(topic, 0, sarama.OffsetOldest)
-	if err != nil {
-		return fmt.Errorf("failed to create sarama partition consumer: %w", err)
+	topicList := make([]string, 0, len(c.topicToHandlerMap))
+	for topic := range c.topicToHandlerMap {
+		topicList = append(topicList, topic)
+	}
+
+	go func() {
+		for err := range c.saramaConsumerGroup.Errors() {
+			logger.With(zap.Error(err)).Error("consumer group error")
+		}
+	}()
+
+	defer c.saramaConsumerGroup.Close()
+
+	handler := &consumerGroupHandler{
+		topicToHandlerMap: c.topicToHandlerMap,
+		producerClient:    c.producerClient,
+		workerPool:        c.workerPool,
+		drainer:           c.drainer,
+		logger:            c.logger,
 	}
 
 	for {
-		select {
-		case message := <-partitionConsumer.Messages():
-			err = handlerFunc(context.Background(), topic, message.Value)
-			if err != nil {
-				logger.With(zap.Error(err)).Error("failed to handle message")
+		if err := c.saramaConsumerGroup.Consume(c.drainer.handleCtx, topicList, handler); err != nil {
+			if errors.Is(err, sarama.ErrClosedConsumerGroup) {
+				return nil
 			}
 
-		case <-exitSignalChannel:
-			break
+			logger.With(zap.Error(err)).Error("failed to consume from consumer group")
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if c.drainer.consumeCtx.Err() != nil {
+			return nil
 		}
 	}
 }
 
-func (c consumer) Start(ctx context.Context) error {
-	logger := utils.LoggerWithContext(ctx, c.logger)
+// Stop implements Consumer. Offsets of the messages done with are committed when the session ends.
+func (c *kafkaConsumer) Stop(ctx context.Context) error {
+	return c.drainer.stop(ctx)
+}
+
+type consumerGroupHandler struct {
+	topicToHandlerMap map[string]topicHandler
+	producerClient    producer.Client
+	workerPool        WorkerPool
+	drainer           *drainer
+	logger            *zap.Logger
+}
+
+// Setup implements sarama.ConsumerGroupHandler.
+func (c consumerGroupHandler) Setup(session sarama.ConsumerGroupSession) error {
+	c.logger.With(zap.Any("claims", session.Claims())).Info("consumer group session started")
+	return nil
+}
+
+// Cleanup implements sarama.ConsumerGroupHandler.
+func (c consumerGroupHandler) Cleanup(session sarama.ConsumerGroupSession) error {
+	c.logger.With(zap.Any("claims", session.Claims())).Info("consumer group session ended")
+	return nil
+}
 
-	exitSignalChannel := make(chan os.Signal, 1)
-	signal.Notify(exitSignalChannel, os.Interrupt)
+// ConsumeClaim implements sarama.ConsumerGroupHandler. Messages of the claim are handled concurrently by the
+// worker pool, but their offsets are marked, and later committed, in order: an offset is only marked once the
+// messages before it are done with, so a crash never skips a message that was still being handled.
+//
+// Once a message can not be acknowledged, no later offset is marked and the claim ends, which ends the
+// session. The next session resumes from the committed offset, so the message is consumed again.
+//
+// A rebalance cancels the handlers of the claim, while a stop of the consumer lets them finish.
+func (c consumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	logger := c.logger.
+		With(zap.String("topic", claim.Topic())).
+		With(zap.Int32("partition", claim.Partition()))
+
+	handler, ok := c.topicToHandlerMap[claim.Topic()]
+	if !ok {
+		return fmt.Errorf("no handler registered for topic %s", claim.Topic())
+	}
+
+	handleCtx, stopHandling := context.WithCancel(c.drainer.handleCtx)
+	defer stopHandling()
+
+	stopAfterFunc := context.AfterFunc(session.Context(), func() {
+		if c.drainer.consumeCtx.Err() == nil {
+			stopHandling()
+		}
+	})
+	defer stopAfterFunc()
+
+	dispatchCtx, stopDispatching := context.WithCancel(session.Context())
+	defer stopDispatching()
+
+	stopDispatchingAfterFunc := context.AfterFunc(c.drainer.consumeCtx, stopDispatching)
+	defer stopDispatchingAfterFunc()
+
+	waitGroup := new(sync.WaitGroup)
+	defer waitGroup.Wait()
+
+	delayedMessageLimiter := utils.NewLimiter(maxDelayedMessageCount)
+	offsetMarker := newClaimOffsetMarker(session, func() {
+		if dispatchCtx.Err() == nil {
+			logger.Warn("message could not be acknowledged, ending claim for it to be consumed again")
+		}
+
+		stopDispatching()
+	})
+	for {
+		select {
+		case message, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
 
-	for topic, handlerFunc := range c.topicToHandlerFuncMap {
-		go func(topic string, handlerFunc HandlerFunc) {
-			if err := c.consume(topic, handlerFunc, exitSignalChannel); err != nil {
-				logger.
-					With(zap.String("topic", topic)).
-					With(zap.Error(err)).
-					Error("failed to consume message from topic")
+			inFlightMessage := offsetMarker.add(message)
+			if !dispatchMessage(
+				dispatchCtx,
+				handleCtx,
+				c.workerPool,
+				delayedMessageLimiter,
+				waitGroup,
+				handler,
+				c.producerClient,
+				string(message.Key),
+				message.Value,
+				getMessageHeaders(message),
+				logger.With(zap.Int64("offset", message.Offset)),
+				func(acknowledgeable bool) {
+					offsetMarker.done(inFlightMessage, acknowledgeable)
+				},
+			) {
+				return nil
 			}
-		}(topic, handlerFunc)
+
+		case <-dispatchCtx.Done():
+			return nil
+		}
 	}
+}
 
-	<-exitSignalChannel
-	return nil
+type inFlightMessage struct {
+	message         *sarama.ConsumerMessage
+	done            bool
+	acknowledgeable bool
+}
+
+// claimOffsetMarker marks the offsets of the messages of a claim in the order they were consumed, whatever
+// the order their handling completes in. Kafka commits offsets cumulatively, so marking stops for good at the
+// first message that can not be acknowledged, calling onUnacknowledged, and the message stays in the list.
+type claimOffsetMarker struct {
+	session             sarama.ConsumerGroupSession
+	inFlightMessageList []*inFlightMessage
+	onUnacknowledged    func()
+	unacknowledged      bool
+	mutex               *sync.Mutex
+}
+
+func newClaimOffsetMarker(session sarama.ConsumerGroupSession, onUnacknowledged func()) *claimOffsetMarker {
+	return &claimOffsetMarker{
+		session:             session,
+		inFlightMessageList: make([]*inFlightMessage, 0),
+		onUnacknowledged:    onUnacknowledged,
+		mutex:               new(sync.Mutex),
+	}
+}
+
+func (c *claimOffsetMarker) add(message *sarama.ConsumerMessage) *inFlightMessage {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	inFlightMessage := &inFlightMessage{message: message}
+	c.inFlightMessageList = append(c.inFlightMessageList, inFlightMessage)
+	return inFlightMessage
+}
+
+func (c *claimOffsetMarker) done(inFlightMessage *inFlightMessage, acknowledgeable bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	inFlightMessage.done = true
+	inFlightMessage.acknowledgeable = acknowledgeable
+
+	for !c.unacknowledged && len(c.inFlightMessageList) > 0 && c.inFlightMessageList[0].done {
+		if !c.inFlightMessageList[0].acknowledgeable {
+			c.unacknowledged = true
+			c.onUnacknowledged()
+			return
+		}
+
+		c.session.MarkMessage(c.inFlightMessageList[0].message, "")
+		c.inFlightMessageList = c.inFlightMessageList[1:]
+	}
 }
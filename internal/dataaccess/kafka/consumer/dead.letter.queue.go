@@ -0,0 +1,233 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/manhhung2111/go-idm/internal/config"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/kafka/producer"
+	"github.com/manhhung2111/go-idm/internal/utils"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// deadLetterReadTimeout bounds reading a range of a dead letter partition, in which some offsets may hold
+	// no message to be read, such as the offsets of transaction markers.
+	deadLetterReadTimeout = 10 * time.Second
+)
+
+type DeadLetterMessage struct {
+	Partition     int32
+	Offset        int64
+	Key           string
+	OriginalTopic string
+	Attempt       int
+	Error         string
+	FailedTime    string
+	Payload       []byte
+}
+
+// DeadLetterQueue inspects the dead letter topic of a topic, and replays the messages in it by sending their
+// original key and payload back to the original topic, where they start again with a fresh retry policy.
+type DeadLetterQueue interface {
+	GetDeadLetterMessageList(ctx context.Context, topic string, limit int64) ([]DeadLetterMessage, error)
+	ReplayDeadLetterMessage(ctx context.Context, topic string, partition int32, offset int64) error
+}
+
+type deadLetterQueue struct {
+	saramaClient   sarama.Client
+	producerClient producer.Client
+	retryPolicies  map[string]config.KafkaRetryPolicy
+	logger         *zap.Logger
+}
+
+func NewDeadLetterQueue(
+	queueConfig config.Queue,
+	kafkaConfig config.Kafka,
+	producerClient producer.Client,
+	logger *zap.Logger,
+) (DeadLetterQueue, func(), error) {
+	if queueConfig.Type != config.QueueTypeKafka && queueConfig.Type != "" {
+		return nil, nil, fmt.Errorf("dead letter queue is not supported for queue type: %s", queueConfig.Type)
+	}
+
+	saramaConfig, err := newSaramaConfig(kafkaConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	address := kafkaConfig.Host + ":" + kafkaConfig.Port
+	saramaClient, err := sarama.NewClient([]string{address}, saramaConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create sarama client: %w", err)
+	}
+
+	cleanup := func() {
+		saramaClient.Close()
+	}
+
+	return &deadLetterQueue{
+		saramaClient:   saramaClient,
+		producerClient: producerClient,
+		retryPolicies:  kafkaConfig.RetryPolicies,
+		logger:         logger,
+	}, cleanup, nil
+}
+
+// GetDeadLetterMessageList returns up to limit of the most recent messages of each partition of the dead
+// letter topic of topic.
+func (d *deadLetterQueue) GetDeadLetterMessageList(ctx context.Context, topic string, limit int64) ([]DeadLetterMessage, error) {
+	deadLetterTopic := d.retryPolicies[topic].GetDeadLetterTopic(topic)
+	logger := utils.LoggerWithContext(ctx, d.logger).With(zap.String("dead_letter_topic", deadLetterTopic))
+
+	partitionList, err := d.saramaClient.Partitions(deadLetterTopic)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to get dead letter topic partitions")
+		return nil, err
+	}
+
+	saramaConsumer, err := sarama.NewConsumerFromClient(d.saramaClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sarama consumer: %w", err)
+	}
+
+	defer saramaConsumer.Close()
+
+	deadLetterMessageList := make([]DeadLetterMessage, 0)
+	for _, partition := range partitionList {
+		oldestOffset, err := d.saramaClient.GetOffset(deadLetterTopic, partition, sarama.OffsetOldest)
+		if err != nil {
+			return nil, err
+		}
+
+		newestOffset, err := d.saramaClient.GetOffset(deadLetterTopic, partition, sarama.OffsetNewest)
+		if err != nil {
+			return nil, err
+		}
+
+		startOffset := max(oldestOffset, newestOffset-limit)
+		if startOffset >= newestOffset {
+			continue
+		}
+
+		partitionMessageList, err := d.readPartition(ctx, saramaConsumer, deadLetterTopic, partition, startOffset, newestOffset)
+		if err != nil {
+			logger.With(zap.Int32("partition", partition)).With(zap.Error(err)).Error("failed to read dead letter partition")
+			return nil, err
+		}
+
+		deadLetterMessageList = append(deadLetterMessageList, partitionMessageList...)
+	}
+
+	return deadLetterMessageList, nil
+}
+
+// ReplayDeadLetterMessage implements DeadLetterQueue.
+func (d *deadLetterQueue) ReplayDeadLetterMessage(ctx context.Context, topic string, partition int32, offset int64) error {
+	deadLetterTopic := d.retryPolicies[topic].GetDeadLetterTopic(topic)
+	logger := utils.LoggerWithContext(ctx, d.logger).
+		With(zap.String("dead_letter_topic", deadLetterTopic)).
+		With(zap.Int32("partition", partition)).
+		With(zap.Int64("offset", offset))
+
+	oldestOffset, err := d.saramaClient.GetOffset(deadLetterTopic, partition, sarama.OffsetOldest)
+	if err != nil {
+		return err
+	}
+
+	newestOffset, err := d.saramaClient.GetOffset(deadLetterTopic, partition, sarama.OffsetNewest)
+	if err != nil {
+		return err
+	}
+
+	if offset < oldestOffset || offset >= newestOffset {
+		logger.Error("dead letter message not found")
+		return status.Errorf(codes.NotFound, "dead letter message at offset %d of partition %d not found", offset, partition)
+	}
+
+	saramaConsumer, err := sarama.NewConsumerFromClient(d.saramaClient)
+	if err != nil {
+		return fmt.Errorf("failed to create sarama consumer: %w", err)
+	}
+
+	defer saramaConsumer.Close()
+
+	deadLetterMessageList, err := d.readPartition(ctx, saramaConsumer, deadLetterTopic, partition, offset, offset+1)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to read dead letter message")
+		return err
+	}
+
+	if len(deadLetterMessageList) == 0 || deadLetterMessageList[0].Offset != offset {
+		logger.Error("dead letter message not found")
+		return status.Errorf(codes.NotFound, "dead letter message at offset %d of partition %d not found", offset, partition)
+	}
+
+	deadLetterMessage := deadLetterMessageList[0]
+	if err = d.producerClient.SendWithKey(ctx, topic, deadLetterMessage.Key, deadLetterMessage.Payload, nil); err != nil {
+		logger.With(zap.Error(err)).Error("failed to replay dead letter message")
+		return err
+	}
+
+	logger.Info("dead letter message replayed")
+	return nil
+}
+
+// readPartition returns the messages of a partition from startOffset up to endOffset, which must be at most
+// the newest offset of the partition. It stops early, returning the messages read so far, if no message
+// reaching endOffset arrives within deadLetterReadTimeout.
+func (d *deadLetterQueue) readPartition(
+	ctx context.Context,
+	saramaConsumer sarama.Consumer,
+	topic string,
+	partition int32,
+	startOffset int64,
+	endOffset int64,
+) ([]DeadLetterMessage, error) {
+	partitionConsumer, err := saramaConsumer.ConsumePartition(topic, partition, startOffset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sarama partition consumer: %w", err)
+	}
+
+	defer partitionConsumer.Close()
+
+	readCtx, cancel := context.WithTimeout(ctx, deadLetterReadTimeout)
+	defer cancel()
+
+	deadLetterMessageList := make([]DeadLetterMessage, 0, endOffset-startOffset)
+	for {
+		select {
+		case <-readCtx.Done():
+			if ctx.Err() == nil && errors.Is(readCtx.Err(), context.DeadlineExceeded) {
+				return deadLetterMessageList, nil
+			}
+
+			return nil, ctx.Err()
+
+		case message := <-partitionConsumer.Messages():
+			headers := getMessageHeaders(message)
+			attempt, _ := strconv.Atoi(headers[HeaderAttempt])
+
+			deadLetterMessageList = append(deadLetterMessageList, DeadLetterMessage{
+				Partition:     message.Partition,
+				Offset:        message.Offset,
+				Key:           string(message.Key),
+				OriginalTopic: headers[HeaderOriginalTopic],
+				Attempt:       attempt,
+				Error:         headers[HeaderError],
+				FailedTime:    headers[HeaderFailedTime],
+				Payload:       message.Value,
+			})
+
+			if message.Offset+1 >= endOffset {
+				return deadLetterMessageList, nil
+			}
+		}
+	}
+}
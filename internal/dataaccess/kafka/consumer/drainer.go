@@ -0,0 +1,68 @@
+package consumer
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// drainer coordinates the stop of a consumer. Stopping first cancels consumeCtx, so that the consumer reads
+// no new message, then waits for the messages being handled to be done with. handleCtx, which the handlers
+// run with, is only canceled if that takes longer than the deadline of the stop, or if the consumer is
+// stopped abruptly by the context it was started with.
+type drainer struct {
+	consumeCtx    context.Context
+	stopConsuming context.CancelFunc
+	handleCtx     context.Context
+	stopHandling  context.CancelFunc
+	started       *atomic.Bool
+	doneChannel   chan struct{}
+}
+
+func newDrainer() *drainer {
+	consumeCtx, stopConsuming := context.WithCancel(context.Background())
+	handleCtx, stopHandling := context.WithCancel(context.Background())
+
+	return &drainer{
+		consumeCtx:    consumeCtx,
+		stopConsuming: stopConsuming,
+		handleCtx:     handleCtx,
+		stopHandling:  stopHandling,
+		started:       new(atomic.Bool),
+		doneChannel:   make(chan struct{}),
+	}
+}
+
+// start must be called when the consumer starts, with the context it was started with. The returned function
+// must be called once the consumer stopped and every handler returned.
+func (d *drainer) start(ctx context.Context) func() {
+	d.started.Store(true)
+	stopAfterFunc := context.AfterFunc(ctx, func() {
+		d.stopConsuming()
+		d.stopHandling()
+	})
+
+	return func() {
+		stopAfterFunc()
+		d.stopConsuming()
+		d.stopHandling()
+		close(d.doneChannel)
+	}
+}
+
+// stop stops the consumer and waits for it, canceling the handlers still running once ctx is done.
+func (d *drainer) stop(ctx context.Context) error {
+	d.stopConsuming()
+	if !d.started.Load() {
+		return nil
+	}
+
+	select {
+	case <-d.doneChannel:
+		return nil
+	case <-ctx.Done():
+	}
+
+	d.stopHandling()
+	<-d.doneChannel
+	return ctx.Err()
+}
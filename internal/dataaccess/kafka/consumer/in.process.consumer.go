@@ -0,0 +1,103 @@
+package consumer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/manhhung2111/go-idm/internal/config"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/kafka/inprocess"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/kafka/producer"
+	"github.com/manhhung2111/go-idm/internal/utils"
+	"go.uber.org/zap"
+)
+
+type inProcessConsumer struct {
+	broker            inprocess.Broker
+	producerClient    producer.Client
+	workerPool        WorkerPool
+	retryPolicies     map[string]config.KafkaRetryPolicy
+	topicToHandlerMap map[string]topicHandler
+	drainer           *drainer
+	logger            *zap.Logger
+}
+
+func NewInProcessConsumer(
+	kafkaConfig config.Kafka,
+	broker inprocess.Broker,
+	producerClient producer.Client,
+	workerPool WorkerPool,
+	logger *zap.Logger,
+) Consumer {
+	return &inProcessConsumer{
+		broker:            broker,
+		producerClient:    producerClient,
+		workerPool:        workerPool,
+		retryPolicies:     kafkaConfig.RetryPolicies,
+		topicToHandlerMap: make(map[string]topicHandler),
+		drainer:           newDrainer(),
+		logger:            logger,
+	}
+}
+
+// RegisterHandler implements Consumer.
+func (c *inProcessConsumer) RegisterHandler(topic string, handlerFunc HandlerFunc) {
+	registerTopicHandler(c.topicToHandlerMap, c.retryPolicies, topic, handlerFunc)
+}
+
+// Start implements Consumer. Each topic is consumed by its own goroutine, so that a retry topic waiting for
+// its delay does not hold back the other topics.
+func (c *inProcessConsumer) Start(ctx context.Context) error {
+	defer c.drainer.start(ctx)()
+
+	waitGroup := new(sync.WaitGroup)
+	for topic, handler := range c.topicToHandlerMap {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			c.consumeTopic(c.drainer.consumeCtx, c.drainer.handleCtx, topic, handler)
+		}()
+	}
+
+	waitGroup.Wait()
+	return ctx.Err()
+}
+
+// Stop implements Consumer. Messages still buffered in the in-process queue are lost.
+func (c *inProcessConsumer) Stop(ctx context.Context) error {
+	return c.drainer.stop(ctx)
+}
+
+func (c *inProcessConsumer) consumeTopic(
+	ctx context.Context,
+	handleCtx context.Context,
+	topic string,
+	handler topicHandler,
+) {
+	logger := utils.LoggerWithContext(ctx, c.logger).With(zap.String("topic", topic))
+	messageChannel := c.broker.Subscribe(topic)
+
+	delayedMessageLimiter := utils.NewLimiter(maxDelayedMessageCount)
+	waitGroup := new(sync.WaitGroup)
+	defer waitGroup.Wait()
+
+	for {
+		select {
+		case message := <-messageChannel:
+			if !dispatchMessage(
+				ctx, handleCtx, c.workerPool, delayedMessageLimiter, waitGroup, handler, c.producerClient, "",
+				message.Payload, message.Headers, logger,
+				func(acknowledgeable bool) {
+					if !acknowledgeable {
+						logger.Error("message dropped, in process queue can not redeliver it")
+					}
+				},
+			) {
+				logger.Error("message dropped, in process queue can not redeliver it")
+				return
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
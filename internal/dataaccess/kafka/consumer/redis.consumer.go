@@ -0,0 +1,374 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/manhhung2111/go-idm/internal/config"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/kafka/producer"
+	"github.com/manhhung2111/go-idm/internal/utils"
+	"go.uber.org/zap"
+)
+
+const (
+	redisConsumerReadCount = 10
+)
+
+type redisConsumer struct {
+	redisClient       *redis.Client
+	producerClient    producer.Client
+	workerPool        WorkerPool
+	groupId           string
+	consumerName      string
+	blockTimeout      time.Duration
+	reclaimInterval   time.Duration
+	reclaimIdleTime   time.Duration
+	retryPolicies     map[string]config.KafkaRetryPolicy
+	topicToHandlerMap map[string]topicHandler
+	drainer           *drainer
+	logger            *zap.Logger
+}
+
+func NewRedisConsumer(
+	queueConfig config.Queue,
+	kafkaConfig config.Kafka,
+	producerClient producer.Client,
+	workerPool WorkerPool,
+	logger *zap.Logger,
+) (Consumer, error) {
+	blockTimeout, err := queueConfig.Redis.GetBlockTimeoutDuration()
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to parse redis queue block_timeout")
+		return nil, err
+	}
+
+	reclaimInterval, err := queueConfig.Redis.GetReclaimIntervalDuration()
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to parse redis queue reclaim_interval")
+		return nil, err
+	}
+
+	reclaimIdleTime, err := queueConfig.Redis.GetReclaimIdleTimeDuration()
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to parse redis queue reclaim_idle_time")
+		return nil, err
+	}
+
+	if reclaimIdleTime < 2*time.Millisecond {
+		return nil, fmt.Errorf("redis queue reclaim_idle_time must be at least 2ms, got %s", reclaimIdleTime)
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hostname: %w", err)
+	}
+
+	return &redisConsumer{
+		redisClient: redis.NewClient(&redis.Options{
+			Addr:     queueConfig.Redis.Address,
+			Username: queueConfig.Redis.Username,
+			Password: queueConfig.Redis.Password,
+		}),
+		producerClient:    producerClient,
+		workerPool:        workerPool,
+		groupId:           kafkaConfig.Consumer.GetGroupId(kafkaConfig.ClientId),
+		consumerName:      fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		blockTimeout:      blockTimeout,
+		reclaimInterval:   reclaimInterval,
+		reclaimIdleTime:   reclaimIdleTime,
+		retryPolicies:     kafkaConfig.RetryPolicies,
+		topicToHandlerMap: make(map[string]topicHandler),
+		drainer:           newDrainer(),
+		logger:            logger,
+	}, nil
+}
+
+// RegisterHandler implements Consumer.
+func (c *redisConsumer) RegisterHandler(topic string, handlerFunc HandlerFunc) {
+	registerTopicHandler(c.topicToHandlerMap, c.retryPolicies, topic, handlerFunc)
+}
+
+// Start implements Consumer. The consumer group is created on the stream of every topic if it does not exist
+// yet, then each topic is consumed by its own goroutine.
+func (c *redisConsumer) Start(ctx context.Context) error {
+	logger := utils.LoggerWithContext(ctx, c.logger).
+		With(zap.String("group_id", c.groupId)).
+		With(zap.String("consumer_name", c.consumerName))
+
+	defer c.redisClient.Close()
+	defer c.drainer.start(ctx)()
+
+	for topic := range c.topicToHandlerMap {
+		if err := c.redisClient.XGroupCreateMkStream(ctx, topic, c.groupId, "0").Err(); err != nil &&
+			!strings.HasPrefix(err.Error(), "BUSYGROUP") {
+			logger.With(zap.String("topic", topic)).With(zap.Error(err)).Error("failed to create consumer group")
+			return err
+		}
+	}
+
+	waitGroup := new(sync.WaitGroup)
+	for topic, handler := range c.topicToHandlerMap {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			c.consumeTopic(c.drainer.consumeCtx, c.drainer.handleCtx, topic, handler)
+		}()
+	}
+
+	waitGroup.Wait()
+	return ctx.Err()
+}
+
+// Stop implements Consumer. Entries read but not dispatched yet stay pending, and are reclaimed later on.
+func (c *redisConsumer) Stop(ctx context.Context) error {
+	return c.drainer.stop(ctx)
+}
+
+// consumeTopic reads new entries of the stream of topic until ctx is done. An entry is only acknowledged with
+// XACK once it is done with, so the entries of a consumer that crashed stay pending and are reclaimed by
+// another consumer of the group after reclaimIdleTime.
+func (c *redisConsumer) consumeTopic(
+	ctx context.Context,
+	handleCtx context.Context,
+	topic string,
+	handler topicHandler,
+) {
+	logger := utils.LoggerWithContext(ctx, c.logger).With(zap.String("topic", topic))
+	lastReclaimTime := time.Time{}
+	lastRefreshTime := time.Now()
+	refreshInterval := c.reclaimIdleTime / 2
+
+	delayedMessageLimiter := utils.NewLimiter(maxDelayedMessageCount)
+	inFlightEntryIdSet := newRedisEntryIdSet()
+	waitGroup := new(sync.WaitGroup)
+	defer waitGroup.Wait()
+
+	for ctx.Err() == nil {
+		if time.Since(lastRefreshTime) >= refreshInterval {
+			c.refreshInFlightEntries(ctx, topic, inFlightEntryIdSet, logger)
+			lastRefreshTime = time.Now()
+		}
+
+		if time.Since(lastReclaimTime) >= c.reclaimInterval {
+			c.reclaimPendingEntries(
+				ctx, handleCtx, topic, handler, delayedMessageLimiter, inFlightEntryIdSet, waitGroup, logger,
+			)
+			lastReclaimTime = time.Now()
+		}
+
+		streamList, err := c.redisClient.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    c.groupId,
+			Consumer: c.consumerName,
+			Streams:  []string{topic, ">"},
+			Count:    redisConsumerReadCount,
+			Block:    min(c.blockTimeout, c.reclaimInterval, refreshInterval),
+		}).Result()
+		if err != nil {
+			if errors.Is(err, redis.Nil) || ctx.Err() != nil {
+				continue
+			}
+
+			logger.With(zap.Error(err)).Error("failed to read from consumer group")
+			time.Sleep(c.blockTimeout)
+			continue
+		}
+
+		for _, stream := range streamList {
+			c.dispatchEntryList(
+				ctx, handleCtx, topic, handler, delayedMessageLimiter, inFlightEntryIdSet, waitGroup, stream.Messages,
+				logger,
+			)
+		}
+	}
+}
+
+// refreshInFlightEntries resets the idle time of the entries this consumer is handling or holding until their
+// retry time, claiming them again for itself, so that the other consumers of the group do not reclaim them.
+func (c *redisConsumer) refreshInFlightEntries(
+	ctx context.Context,
+	topic string,
+	inFlightEntryIdSet *redisEntryIdSet,
+	logger *zap.Logger,
+) {
+	entryIdList := inFlightEntryIdSet.list()
+	if len(entryIdList) == 0 {
+		return
+	}
+
+	if err := c.redisClient.XClaimJustID(ctx, &redis.XClaimArgs{
+		Stream:   topic,
+		Group:    c.groupId,
+		Consumer: c.consumerName,
+		Messages: entryIdList,
+	}).Err(); err != nil && ctx.Err() == nil {
+		logger.With(zap.Error(err)).Error("failed to refresh in flight entries")
+	}
+}
+
+// reclaimPendingEntries claims and handles the entries of the stream of topic that were delivered to a
+// consumer of the group but not acknowledged for longer than reclaimIdleTime, including the entries of this
+// consumer left over from before a restart. The entries this consumer is still handling are claimed too, as
+// their idle time is only refreshed every so often, but they are not dispatched again.
+func (c *redisConsumer) reclaimPendingEntries(
+	ctx context.Context,
+	handleCtx context.Context,
+	topic string,
+	handler topicHandler,
+	delayedMessageLimiter utils.Limiter,
+	inFlightEntryIdSet *redisEntryIdSet,
+	waitGroup *sync.WaitGroup,
+	logger *zap.Logger,
+) {
+	start := "0-0"
+	for {
+		entryList, nextStart, err := c.redisClient.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+			Stream:   topic,
+			Group:    c.groupId,
+			MinIdle:  c.reclaimIdleTime,
+			Start:    start,
+			Count:    redisConsumerReadCount,
+			Consumer: c.consumerName,
+		}).Result()
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.With(zap.Error(err)).Error("failed to reclaim pending entries")
+			}
+
+			return
+		}
+
+		if len(entryList) > 0 {
+			logger.With(zap.Int("count", len(entryList))).Info("reclaimed pending entries")
+		}
+
+		c.dispatchEntryList(
+			ctx, handleCtx, topic, handler, delayedMessageLimiter, inFlightEntryIdSet, waitGroup, entryList, logger,
+		)
+
+		if nextStart == "0-0" || ctx.Err() != nil {
+			return
+		}
+
+		start = nextStart
+	}
+}
+
+// dispatchEntryList hands the entries over to the worker pool, blocking while it is full, skipping the ones
+// already in flight. Entries are acknowledged independently of each other as their handling completes.
+func (c *redisConsumer) dispatchEntryList(
+	ctx context.Context,
+	handleCtx context.Context,
+	topic string,
+	handler topicHandler,
+	delayedMessageLimiter utils.Limiter,
+	inFlightEntryIdSet *redisEntryIdSet,
+	waitGroup *sync.WaitGroup,
+	entryList []redis.XMessage,
+	logger *zap.Logger,
+) {
+	for _, entry := range entryList {
+		entryLogger := logger.With(zap.String("entry_id", entry.ID))
+
+		if !inFlightEntryIdSet.add(entry.ID) {
+			continue
+		}
+
+		key, payload, headers := getRedisEntryMessage(entry)
+		if !dispatchMessage(
+			ctx, handleCtx, c.workerPool, delayedMessageLimiter, waitGroup, handler, c.producerClient, key, payload,
+			headers, entryLogger,
+			func(acknowledgeable bool) {
+				defer inFlightEntryIdSet.remove(entry.ID)
+
+				if !acknowledgeable {
+					return
+				}
+
+				// Acknowledge even if the handler was canceled by a stop, the entry is done with.
+				if err := c.redisClient.XAck(
+					context.WithoutCancel(handleCtx), topic, c.groupId, entry.ID,
+				).Err(); err != nil {
+					entryLogger.With(zap.Error(err)).Error("failed to acknowledge entry")
+				}
+			},
+		) {
+			inFlightEntryIdSet.remove(entry.ID)
+			return
+		}
+	}
+}
+
+// redisEntryIdSet holds the ids of the entries of a stream a consumer is handling or holding until their
+// retry time.
+type redisEntryIdSet struct {
+	mutex sync.Mutex
+	idSet map[string]struct{}
+}
+
+func newRedisEntryIdSet() *redisEntryIdSet {
+	return &redisEntryIdSet{idSet: make(map[string]struct{})}
+}
+
+// add adds id to the set, returning false if it was in it already.
+func (r *redisEntryIdSet) add(id string) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, ok := r.idSet[id]; ok {
+		return false
+	}
+
+	r.idSet[id] = struct{}{}
+	return true
+}
+
+func (r *redisEntryIdSet) remove(id string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.idSet, id)
+}
+
+func (r *redisEntryIdSet) list() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	idList := make([]string, 0, len(r.idSet))
+	for id := range r.idSet {
+		idList = append(idList, id)
+	}
+
+	return idList
+}
+
+// getRedisEntryMessage returns the key, payload and headers of the message stored in a stream entry.
+func getRedisEntryMessage(entry redis.XMessage) (string, []byte, map[string]string) {
+	var key string
+	var payload []byte
+	headers := make(map[string]string)
+
+	for field, value := range entry.Values {
+		stringValue, _ := value.(string)
+		switch field {
+		case producer.RedisStreamFieldPayload:
+			payload = []byte(stringValue)
+			continue
+
+		case producer.RedisStreamFieldKey:
+			key = stringValue
+			continue
+		}
+
+		if key, ok := strings.CutPrefix(field, producer.RedisStreamFieldHeaderPrefix); ok {
+			headers[key] = stringValue
+		}
+	}
+
+	return key, payload, headers
+}
@@ -0,0 +1,175 @@
+package consumer
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/manhhung2111/go-idm/internal/config"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/kafka/producer"
+	"go.uber.org/zap"
+)
+
+const (
+	HeaderAttempt       = "x-go-idm-attempt"
+	HeaderOriginalTopic = "x-go-idm-original-topic"
+	HeaderError         = "x-go-idm-error"
+	HeaderFailedTime    = "x-go-idm-failed-time"
+	HeaderRetryTime     = "x-go-idm-retry-time"
+)
+
+const (
+	handOverRetryInitialDelay = time.Second
+	handOverRetryMaxDelay     = time.Minute
+)
+
+// topicHandler is what a consumed topic is mapped to. Retry topics map to the handler of their original
+// topic, their messages carry the time they are due to be retried at in the HeaderRetryTime header.
+type topicHandler struct {
+	originalTopic string
+	handlerFunc   HandlerFunc
+	retryPolicy   config.KafkaRetryPolicy
+}
+
+// registerTopicHandler maps topic, and the retry topics of the topic's retry policy if it has one, to
+// handlerFunc. Handlers always receive the original topic, even for messages consumed from a retry topic.
+func registerTopicHandler(
+	topicToHandlerMap map[string]topicHandler,
+	retryPolicies map[string]config.KafkaRetryPolicy,
+	topic string,
+	handlerFunc HandlerFunc,
+) {
+	retryPolicy := retryPolicies[topic]
+	handler := topicHandler{
+		originalTopic: topic,
+		handlerFunc:   handlerFunc,
+		retryPolicy:   retryPolicy,
+	}
+
+	topicToHandlerMap[topic] = handler
+	for attempt := range retryPolicy.Delays {
+		topicToHandlerMap[retryPolicy.GetRetryTopic(topic, attempt)] = handler
+	}
+}
+
+func validateRetryPolicies(retryPolicies map[string]config.KafkaRetryPolicy) error {
+	for topic, retryPolicy := range retryPolicies {
+		if _, err := retryPolicy.GetDelayDurations(); err != nil {
+			return fmt.Errorf("failed to parse retry delays of topic %s: %w", topic, err)
+		}
+	}
+
+	return nil
+}
+
+// handleMessage calls the handler on a message, handing it over to a retry or dead letter topic if the
+// handler fails. The hand over is retried with an exponential backoff until it succeeds, as acknowledging the
+// message without it would lose it. It returns whether the message is done with and can be acknowledged,
+// which is only not the case if ctx is done before the hand over succeeded.
+func (t topicHandler) handleMessage(
+	ctx context.Context,
+	producerClient producer.Client,
+	key string,
+	payload []byte,
+	headers map[string]string,
+	logger *zap.Logger,
+) bool {
+	handlerErr := t.handlerFunc(ctx, t.originalTopic, payload)
+	if handlerErr == nil {
+		return true
+	}
+
+	logger.With(zap.Error(handlerErr)).Error("failed to handle message")
+
+	retryDelay := handOverRetryInitialDelay
+	for {
+		// The hand over is not canceled along with the handler, the message is done with once it succeeds.
+		err := t.handleFailure(context.WithoutCancel(ctx), producerClient, key, payload, headers, handlerErr)
+		if err == nil {
+			return true
+		}
+
+		logger.With(zap.Error(err)).With(zap.Duration("retry_delay", retryDelay)).
+			Error("failed to hand failed message over to retry or dead letter topic, retrying")
+
+		timer := time.NewTimer(retryDelay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return false
+		case <-timer.C:
+		}
+
+		retryDelay = min(retryDelay*2, handOverRetryMaxDelay)
+	}
+}
+
+// handleFailure sends a message its handler failed on to the next retry topic of the retry policy, or to the
+// dead letter topic once every retry was attempted. The original key and payload are kept as is, so that the
+// retried messages of a key stay on the same partition.
+func (t topicHandler) handleFailure(
+	ctx context.Context,
+	producerClient producer.Client,
+	key string,
+	payload []byte,
+	headers map[string]string,
+	handlerErr error,
+) error {
+	attempt, _ := strconv.Atoi(headers[HeaderAttempt])
+	failedTime := time.Now()
+
+	retryHeaders := map[string]string{
+		HeaderAttempt:       strconv.Itoa(attempt + 1),
+		HeaderOriginalTopic: t.originalTopic,
+		HeaderError:         handlerErr.Error(),
+		HeaderFailedTime:    failedTime.Format(time.RFC3339Nano),
+	}
+
+	if attempt < len(t.retryPolicy.Delays) {
+		delayDurations, err := t.retryPolicy.GetDelayDurations()
+		if err != nil {
+			return err
+		}
+
+		retryHeaders[HeaderRetryTime] = failedTime.Add(delayDurations[attempt]).Format(time.RFC3339Nano)
+		return producerClient.SendWithKey(ctx, t.retryPolicy.GetRetryTopic(t.originalTopic, attempt), key, payload, retryHeaders)
+	}
+
+	return producerClient.SendWithKey(ctx, t.retryPolicy.GetDeadLetterTopic(t.originalTopic), key, payload, retryHeaders)
+}
+
+func getMessageHeaders(message *sarama.ConsumerMessage) map[string]string {
+	headers := make(map[string]string, len(message.Headers))
+	for _, recordHeader := range message.Headers {
+		headers[string(recordHeader.Key)] = string(recordHeader.Value)
+	}
+
+	return headers
+}
+
+// getRetryTime returns the time a message consumed from a retry topic is due to be retried at, or the zero
+// time for the other messages.
+func getRetryTime(headers map[string]string) time.Time {
+	retryTime, err := time.Parse(time.RFC3339Nano, headers[HeaderRetryTime])
+	if err != nil {
+		return time.Time{}
+	}
+
+	return retryTime
+}
+
+// waitUntilRetryTime blocks until a message consumed from a retry topic is due to be retried. It returns
+// false if ctx is done before that.
+func waitUntilRetryTime(ctx context.Context, headers map[string]string) bool {
+	timer := time.NewTimer(time.Until(getRetryTime(headers)))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
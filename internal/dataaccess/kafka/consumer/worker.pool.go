@@ -0,0 +1,96 @@
+package consumer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/manhhung2111/go-idm/internal/config"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/kafka/producer"
+	"github.com/manhhung2111/go-idm/internal/utils"
+	"go.uber.org/zap"
+)
+
+// WorkerPool bounds the messages handled at the same time by the consumer of a process, each of which is a
+// download. Consumers only read the next message once a worker is free, so a full pool pauses consumption.
+type WorkerPool interface {
+	utils.Limiter
+}
+
+func NewWorkerPool(
+	downloadConfig config.Download,
+) WorkerPool {
+	return utils.NewLimiter(downloadConfig.WorkerPool.GetMaxConcurrentDownloads())
+}
+
+const (
+	// maxDelayedMessageCount bounds the messages of a topic, or of a partition with Kafka, waiting for their
+	// retry time at the same time. Reading the topic pauses once it is reached.
+	maxDelayedMessageCount = 256
+)
+
+// dispatchMessage hands the message over to the background once a worker is free, where it is handled with
+// handleCtx, calling onDone with whether it can be acknowledged once it is done. A message that is not due to
+// be retried yet waits for its retry time in the background without holding a worker, taking a slot of
+// delayedMessageLimiter instead, so that it does not hold back the messages read after it. It returns false
+// without dispatching the message if ctx is done first. waitGroup is used to wait for the dispatched messages.
+func dispatchMessage(
+	ctx context.Context,
+	handleCtx context.Context,
+	workerPool WorkerPool,
+	delayedMessageLimiter utils.Limiter,
+	waitGroup *sync.WaitGroup,
+	handler topicHandler,
+	producerClient producer.Client,
+	key string,
+	payload []byte,
+	headers map[string]string,
+	logger *zap.Logger,
+	onDone func(acknowledgeable bool),
+) bool {
+	if time.Until(getRetryTime(headers)) > 0 {
+		if err := delayedMessageLimiter.Acquire(ctx); err != nil {
+			return false
+		}
+
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+
+			acknowledgeable := waitUntilRetryTime(ctx, headers)
+			delayedMessageLimiter.Release()
+			if acknowledgeable {
+				acknowledgeable = workerPool.Acquire(ctx) == nil
+			}
+
+			if !acknowledgeable {
+				onDone(false)
+				return
+			}
+
+			defer workerPool.Release()
+			onDone(handler.handleMessage(handleCtx, producerClient, key, payload, headers, logger))
+		}()
+
+		return true
+	}
+
+	if err := workerPool.Acquire(ctx); err != nil {
+		return false
+	}
+
+	if ctx.Err() != nil {
+		workerPool.Release()
+		return false
+	}
+
+	waitGroup.Add(1)
+	go func() {
+		defer waitGroup.Done()
+		defer workerPool.Release()
+
+		onDone(handler.handleMessage(handleCtx, producerClient, key, payload, headers, logger))
+	}()
+
+	return true
+}
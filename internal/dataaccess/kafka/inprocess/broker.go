@@ -0,0 +1,67 @@
+package inprocess
+
+import (
+	"context"
+	"sync"
+
+	"github.com/manhhung2111/go-idm/internal/config"
+)
+
+type Message struct {
+	Topic   string
+	Payload []byte
+	Headers map[string]string
+}
+
+// Broker is the in-process message queue backend, where each topic is a buffered channel shared by the
+// producer and the consumer of the process. Messages are lost if the process exits before they are consumed,
+// so it is only meant for development and single process deployments.
+type Broker interface {
+	Publish(ctx context.Context, message Message) error
+	Subscribe(topic string) <-chan Message
+}
+
+type broker struct {
+	topicToChannelMap map[string]chan Message
+	topicMutex        *sync.Mutex
+	bufferSize        uint64
+}
+
+func NewBroker(
+	queueConfig config.Queue,
+) Broker {
+	return &broker{
+		topicToChannelMap: make(map[string]chan Message),
+		topicMutex:        new(sync.Mutex),
+		bufferSize:        queueConfig.InProcess.GetBufferSize(),
+	}
+}
+
+// Publish implements Broker. It blocks while the buffer of the topic is full, until ctx is done.
+func (b *broker) Publish(ctx context.Context, message Message) error {
+	select {
+	case b.getChannel(message.Topic) <- message:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Subscribe implements Broker. Every subscriber of a topic competes for its messages, in the same way as the
+// members of a consumer group.
+func (b *broker) Subscribe(topic string) <-chan Message {
+	return b.getChannel(topic)
+}
+
+func (b *broker) getChannel(topic string) chan Message {
+	b.topicMutex.Lock()
+	defer b.topicMutex.Unlock()
+
+	channel, ok := b.topicToChannelMap[topic]
+	if !ok {
+		channel = make(chan Message, b.bufferSize)
+		b.topicToChannelMap[topic] = channel
+	}
+
+	return channel
+}
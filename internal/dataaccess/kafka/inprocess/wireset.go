@@ -0,0 +1,7 @@
+package inprocess
+
+import "github.com/google/wire"
+
+var WireSet = wire.NewSet(
+	NewBroker,
+)
@@ -6,17 +6,44 @@ import (
 
 	"github.com/IBM/sarama"
 	"github.com/manhhung2111/go-idm/internal/config"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/kafka/inprocess"
 	"github.com/manhhung2111/go-idm/internal/utils"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// Client publishes messages to the configured message queue backend. Messages sent with the same key are
+// consumed in the order they were sent.
 type Client interface {
 	Send(ctx context.Context, topic string, payload []byte) error
+	SendWithHeaders(ctx context.Context, topic string, payload []byte, headers map[string]string) error
+	SendWithKey(ctx context.Context, topic string, key string, payload []byte, headers map[string]string) error
 }
 
-type client struct {
+func NewClient(
+	queueConfig config.Queue,
+	kafkaConfig config.Kafka,
+	broker inprocess.Broker,
+	logger *zap.Logger,
+) (Client, func(), error) {
+	switch queueConfig.Type {
+	case config.QueueTypeKafka, "":
+		return NewKafkaClient(kafkaConfig, logger)
+
+	case config.QueueTypeInProcess:
+		return NewInProcessClient(broker, logger), func() {}, nil
+
+	case config.QueueTypeRedis:
+		client, cleanupFunc := NewRedisClient(queueConfig, logger)
+		return client, cleanupFunc, nil
+
+	default:
+		return nil, nil, fmt.Errorf("unsupported queue type: %s", queueConfig.Type)
+	}
+}
+
+type kafkaClient struct {
 	saramaSyncProducer sarama.SyncProducer
 	logger             *zap.Logger
 }
@@ -31,34 +58,67 @@ func newSaramaConfig(kafkaConfig config.Kafka) *sarama.Config {
 	return saramaConfig
 }
 
-func NewClient(
+func NewKafkaClient(
 	kafkaConfig config.Kafka,
 	logger *zap.Logger,
-) (Client, error) {
+) (Client, func(), error) {
 	address := kafkaConfig.Host + ":" + kafkaConfig.Port
 	saramaSyncProducer, err := sarama.NewSyncProducer([]string{address}, newSaramaConfig(kafkaConfig))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create sarama sync producer: %w", err)
+		return nil, nil, fmt.Errorf("failed to create sarama sync producer: %w", err)
+	}
+
+	// Messages are sent synchronously, so closing the producer has nothing left to flush but in-flight retries.
+	cleanupFunc := func() {
+		if err := saramaSyncProducer.Close(); err != nil {
+			logger.With(zap.Error(err)).Error("failed to close sarama sync producer")
+		}
 	}
 
-	return &client{
+	return &kafkaClient{
 		saramaSyncProducer: saramaSyncProducer,
 		logger:             logger,
-	}, nil
+	}, cleanupFunc, nil
+}
+
+func (c kafkaClient) Send(ctx context.Context, topic string, payload []byte) error {
+	return c.SendWithHeaders(ctx, topic, payload, nil)
 }
 
-func (c client) Send(ctx context.Context, topic string, payload []byte) error {
+func (c kafkaClient) SendWithHeaders(ctx context.Context, topic string, payload []byte, headers map[string]string) error {
+	return c.SendWithKey(ctx, topic, "", payload, headers)
+}
+
+// SendWithKey implements Client. Messages are assigned to partitions by the hash of their key, so messages
+// with the same key end up in the same partition.
+func (c kafkaClient) SendWithKey(ctx context.Context, topic string, key string, payload []byte, headers map[string]string) error {
 	logger := utils.LoggerWithContext(ctx, c.logger).
 		With(zap.String("topic", topic)).
-		With(zap.ByteString("payload", payload))
+		With(zap.String("key", key)).
+		With(zap.ByteString("payload", payload)).
+		With(zap.Any("headers", headers))
 
-	if _, _, err := c.saramaSyncProducer.SendMessage(&sarama.ProducerMessage{
-		Topic: topic,
-		Value: sarama.ByteEncoder(payload),
-	}); err != nil {
+	recordHeaderList := make([]sarama.RecordHeader, 0, len(headers))
+	for key, value := range headers {
+		recordHeaderList = append(recordHeaderList, sarama.RecordHeader{
+			Key:   []byte(key),
+			Value: []byte(value),
+		})
+	}
+
+	producerMessage := &sarama.ProducerMessage{
+		Topic:   topic,
+		Value:   sarama.ByteEncoder(payload),
+		Headers: recordHeaderList,
+	}
+	if key != "" {
+		producerMessage.Key = sarama.StringEncoder(key)
+	}
+
+	if _, _, err := c.saramaSyncProducer.SendMessage(producerMessage); err != nil {
 		logger.With(zap.Error(err)).Error("failed to produce message")
 		return status.Errorf(codes.Internal, "failed to produce message: %+v", err)
 	}
 
 	return nil
-}
\ No newline at end of file
+}
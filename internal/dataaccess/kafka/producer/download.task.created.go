@@ -3,7 +3,9 @@ package producer
 import (
 	"context"
 	"encoding/json"
+	"time"
 
+	"github.com/manhhung2111/go-idm/internal/dataaccess/database"
 	"github.com/manhhung2111/go-idm/internal/utils"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
@@ -18,22 +20,25 @@ type DownloadTaskCreated struct {
 	Id uint64 `json:"id"`
 }
 
+// DownloadTaskCreatedProducer writes download task created events to the outbox. Use WithDatabase to write
+// them in the same transaction as the download task, the OutboxRelay publishes them once it is committed.
 type DownloadTaskCreatedProducer interface {
 	Send(ctx context.Context, event DownloadTaskCreated) error
+	WithDatabase(database database.IDatabase) DownloadTaskCreatedProducer
 }
 
 type downloadTaskCreatedProducer struct {
-	client Client
-	logger *zap.Logger
+	outboxMessageDataAccessor database.OutboxMessageDataAccessor
+	logger                    *zap.Logger
 }
 
 func NewDownloadTaskCreatedProducer(
-	client Client,
+	outboxMessageDataAccessor database.OutboxMessageDataAccessor,
 	logger *zap.Logger,
 ) DownloadTaskCreatedProducer {
 	return &downloadTaskCreatedProducer{
-		client: client,
-		logger: logger,
+		outboxMessageDataAccessor: outboxMessageDataAccessor,
+		logger:                    logger,
 	}
 }
 
@@ -47,7 +52,11 @@ func (d *downloadTaskCreatedProducer) Send(ctx context.Context, event DownloadTa
 		return status.Errorf(codes.Internal, "failed to marshal download task created event: %+v", err)
 	}
 
-	err = d.client.Send(ctx, DownloadTaskCreatedTopic, eventBytes)
+	_, err = d.outboxMessageDataAccessor.CreateOutboxMessage(ctx, database.OutboxMessage{
+		Topic:       DownloadTaskCreatedTopic,
+		Payload:     eventBytes,
+		CreatedTime: time.Now(),
+	})
 	if err != nil {
 		logger.With(zap.Error(err)).Error("failed to send download task created event")
 		return status.Errorf(codes.Internal, "failed to send download task created event: %+v", err)
@@ -55,3 +64,11 @@ func (d *downloadTaskCreatedProducer) Send(ctx context.Context, event DownloadTa
 
 	return nil
 }
+
+// WithDatabase implements DownloadTaskCreatedProducer.
+func (d *downloadTaskCreatedProducer) WithDatabase(database database.IDatabase) DownloadTaskCreatedProducer {
+	return &downloadTaskCreatedProducer{
+		outboxMessageDataAccessor: d.outboxMessageDataAccessor.WithDatabase(database),
+		logger:                    d.logger,
+	}
+}
@@ -0,0 +1,116 @@
+package producer
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/manhhung2111/go-idm/internal/dataaccess/database"
+	go_idm_v1 "github.com/manhhung2111/go-idm/internal/generated/proto"
+	"github.com/manhhung2111/go-idm/internal/utils"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	DownloadTaskStatusChangedTopic = "download.task.status_changed"
+	DownloadTaskCompletedTopic     = "download.task.completed"
+	DownloadTaskFailedTopic        = "download.task.failed"
+	DownloadTaskDeletedTopic       = "download.task.deleted"
+
+	// DownloadTaskEventVersion is the schema version of the download task lifecycle events, defined in
+	// proto/events.proto.
+	DownloadTaskEventVersion = 1
+)
+
+// DownloadTaskEventProducer writes the download task lifecycle events to the outbox, protobuf encoded and keyed
+// by the id of the account owning the download task. Like DownloadTaskCreatedProducer, use WithDatabase to
+// write them in the same transaction as the change they describe.
+type DownloadTaskEventProducer interface {
+	SendStatusChanged(ctx context.Context, event *go_idm_v1.DownloadTaskStatusChangedEvent) error
+	SendCompleted(ctx context.Context, event *go_idm_v1.DownloadTaskCompletedEvent) error
+	SendFailed(ctx context.Context, event *go_idm_v1.DownloadTaskFailedEvent) error
+	SendDeleted(ctx context.Context, event *go_idm_v1.DownloadTaskDeletedEvent) error
+	WithDatabase(database database.IDatabase) DownloadTaskEventProducer
+}
+
+type downloadTaskEventProducer struct {
+	outboxMessageDataAccessor database.OutboxMessageDataAccessor
+	logger                    *zap.Logger
+}
+
+func NewDownloadTaskEventProducer(
+	outboxMessageDataAccessor database.OutboxMessageDataAccessor,
+	logger *zap.Logger,
+) DownloadTaskEventProducer {
+	return &downloadTaskEventProducer{
+		outboxMessageDataAccessor: outboxMessageDataAccessor,
+		logger:                    logger,
+	}
+}
+
+// SendStatusChanged implements DownloadTaskEventProducer.
+func (d *downloadTaskEventProducer) SendStatusChanged(
+	ctx context.Context,
+	event *go_idm_v1.DownloadTaskStatusChangedEvent,
+) error {
+	event.Version = DownloadTaskEventVersion
+	event.EventTime = uint64(time.Now().UnixMilli())
+	return d.send(ctx, DownloadTaskStatusChangedTopic, event.GetOfAccountId(), event)
+}
+
+// SendCompleted implements DownloadTaskEventProducer.
+func (d *downloadTaskEventProducer) SendCompleted(ctx context.Context, event *go_idm_v1.DownloadTaskCompletedEvent) error {
+	event.Version = DownloadTaskEventVersion
+	event.EventTime = uint64(time.Now().UnixMilli())
+	return d.send(ctx, DownloadTaskCompletedTopic, event.GetOfAccountId(), event)
+}
+
+// SendFailed implements DownloadTaskEventProducer.
+func (d *downloadTaskEventProducer) SendFailed(ctx context.Context, event *go_idm_v1.DownloadTaskFailedEvent) error {
+	event.Version = DownloadTaskEventVersion
+	event.EventTime = uint64(time.Now().UnixMilli())
+	return d.send(ctx, DownloadTaskFailedTopic, event.GetOfAccountId(), event)
+}
+
+// SendDeleted implements DownloadTaskEventProducer.
+func (d *downloadTaskEventProducer) SendDeleted(ctx context.Context, event *go_idm_v1.DownloadTaskDeletedEvent) error {
+	event.Version = DownloadTaskEventVersion
+	event.EventTime = uint64(time.Now().UnixMilli())
+	return d.send(ctx, DownloadTaskDeletedTopic, event.GetOfAccountId(), event)
+}
+
+func (d *downloadTaskEventProducer) send(ctx context.Context, topic string, accountId uint64, event proto.Message) error {
+	logger := utils.LoggerWithContext(ctx, d.logger).
+		With(zap.String("topic", topic)).
+		With(zap.Uint64("account_id", accountId))
+
+	eventBytes, err := proto.Marshal(event)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to marshal download task event")
+		return status.Errorf(codes.Internal, "failed to marshal download task event: %+v", err)
+	}
+
+	_, err = d.outboxMessageDataAccessor.CreateOutboxMessage(ctx, database.OutboxMessage{
+		Topic:       topic,
+		Key:         strconv.FormatUint(accountId, 10),
+		Payload:     eventBytes,
+		CreatedTime: time.Now(),
+	})
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to send download task event")
+		return status.Errorf(codes.Internal, "failed to send download task event: %+v", err)
+	}
+
+	return nil
+}
+
+// WithDatabase implements DownloadTaskEventProducer.
+func (d *downloadTaskEventProducer) WithDatabase(database database.IDatabase) DownloadTaskEventProducer {
+	return &downloadTaskEventProducer{
+		outboxMessageDataAccessor: d.outboxMessageDataAccessor.WithDatabase(database),
+		logger:                    d.logger,
+	}
+}
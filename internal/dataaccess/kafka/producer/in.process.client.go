@@ -0,0 +1,55 @@
+package producer
+
+import (
+	"context"
+
+	"github.com/manhhung2111/go-idm/internal/dataaccess/kafka/inprocess"
+	"github.com/manhhung2111/go-idm/internal/utils"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type inProcessClient struct {
+	broker inprocess.Broker
+	logger *zap.Logger
+}
+
+func NewInProcessClient(
+	broker inprocess.Broker,
+	logger *zap.Logger,
+) Client {
+	return &inProcessClient{
+		broker: broker,
+		logger: logger,
+	}
+}
+
+func (c inProcessClient) Send(ctx context.Context, topic string, payload []byte) error {
+	return c.SendWithHeaders(ctx, topic, payload, nil)
+}
+
+func (c inProcessClient) SendWithHeaders(ctx context.Context, topic string, payload []byte, headers map[string]string) error {
+	return c.SendWithKey(ctx, topic, "", payload, headers)
+}
+
+// SendWithKey implements Client. The key is ignored, as every message of a topic goes through the same
+// channel and is consumed in order anyway.
+func (c inProcessClient) SendWithKey(ctx context.Context, topic string, key string, payload []byte, headers map[string]string) error {
+	logger := utils.LoggerWithContext(ctx, c.logger).
+		With(zap.String("topic", topic)).
+		With(zap.String("key", key)).
+		With(zap.ByteString("payload", payload)).
+		With(zap.Any("headers", headers))
+
+	if err := c.broker.Publish(ctx, inprocess.Message{
+		Topic:   topic,
+		Payload: payload,
+		Headers: headers,
+	}); err != nil {
+		logger.With(zap.Error(err)).Error("failed to produce message")
+		return status.Errorf(codes.Internal, "failed to produce message: %+v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,221 @@
+package producer
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/manhhung2111/go-idm/internal/config"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/database"
+	"github.com/manhhung2111/go-idm/internal/utils"
+	"go.uber.org/zap"
+)
+
+const (
+	outboxPurgeInterval = 10 * time.Minute
+
+	// outboxRelayLockName identifies the advisory lock electing the relay of the processes sharing a database.
+	outboxRelayLockName        = "go_idm_outbox_relay"
+	outboxRelayLockPostgresKey = 4242424243
+)
+
+// OutboxRelay publishes the messages written to the outbox to the message queue. A message is only marked as
+// sent after the queue acknowledged it, so it may be published more than once but is never lost. The sent
+// messages are deleted once older than the outbox retention.
+//
+// Every Server and Worker process runs a relay, but only the one holding the outbox relay advisory lock
+// publishes, so that the messages are published one batch after the other in the order they were written.
+// The others try to take the lock over at each poll.
+type OutboxRelay interface {
+	Start(ctx context.Context) error
+}
+
+type outboxRelay struct {
+	db                        *sql.DB
+	databaseConfig            config.Database
+	client                    Client
+	outboxMessageDataAccessor database.OutboxMessageDataAccessor
+	goquDatabase              *goqu.Database
+	pollInterval              time.Duration
+	batchSize                 uint64
+	retention                 time.Duration
+	logger                    *zap.Logger
+}
+
+func NewOutboxRelay(
+	db *sql.DB,
+	databaseConfig config.Database,
+	client Client,
+	outboxMessageDataAccessor database.OutboxMessageDataAccessor,
+	goquDatabase *goqu.Database,
+	kafkaConfig config.Kafka,
+	logger *zap.Logger,
+) (OutboxRelay, error) {
+	pollInterval, err := kafkaConfig.Outbox.GetPollIntervalDuration()
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to parse outbox poll_interval")
+		return nil, err
+	}
+
+	retention, err := kafkaConfig.Outbox.GetRetentionDuration()
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to parse outbox retention")
+		return nil, err
+	}
+
+	return &outboxRelay{
+		db:                        db,
+		databaseConfig:            databaseConfig,
+		client:                    client,
+		outboxMessageDataAccessor: outboxMessageDataAccessor,
+		goquDatabase:              goquDatabase,
+		pollInterval:              pollInterval,
+		batchSize:                 kafkaConfig.Outbox.GetBatchSize(),
+		retention:                 retention,
+		logger:                    logger,
+	}, nil
+}
+
+// Start implements OutboxRelay.
+func (o *outboxRelay) Start(ctx context.Context) error {
+	logger := utils.LoggerWithContext(ctx, o.logger)
+
+	ticker := time.NewTicker(o.pollInterval)
+	defer ticker.Stop()
+
+	var relayLock *database.AdvisoryLock
+	defer func() {
+		if relayLock != nil {
+			if err := relayLock.Release(context.WithoutCancel(ctx)); err != nil {
+				logger.With(zap.Error(err)).Error("failed to release outbox relay lock")
+			}
+		}
+	}()
+
+	lastPurgeTime := time.Time{}
+	for {
+		relayLock = o.keepRelayLock(ctx, relayLock)
+		if relayLock == nil {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-ticker.C:
+			}
+
+			continue
+		}
+
+		if time.Since(lastPurgeTime) >= outboxPurgeInterval {
+			o.purgeSentMessages(ctx)
+			lastPurgeTime = time.Now()
+		}
+
+		sentCount, err := o.relayBatch(ctx)
+		if err != nil {
+			logger.With(zap.Error(err)).Error("failed to relay outbox messages")
+		}
+
+		// Keep draining without waiting while the outbox is backed up.
+		if err == nil && sentCount == o.batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// keepRelayLock returns the relay lock if it is still held, or tries to acquire it otherwise. It returns nil if
+// the lock is held by the relay of another process.
+func (o *outboxRelay) keepRelayLock(ctx context.Context, relayLock *database.AdvisoryLock) *database.AdvisoryLock {
+	logger := utils.LoggerWithContext(ctx, o.logger)
+
+	if relayLock != nil {
+		if relayLock.IsHeld(ctx) {
+			return relayLock
+		}
+
+		logger.Warn("lost outbox relay lock")
+		_ = relayLock.Release(ctx)
+	}
+
+	relayLock, acquired, err := database.TryAcquireAdvisoryLock(
+		ctx, o.db, o.databaseConfig, outboxRelayLockName, outboxRelayLockPostgresKey,
+	)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to acquire outbox relay lock")
+		return nil
+	}
+
+	if !acquired {
+		return nil
+	}
+
+	logger.Info("acquired outbox relay lock, relaying outbox messages")
+	return relayLock
+}
+
+// relayBatch publishes the oldest unsent messages, then marks the ones published as sent. The messages are
+// published while the transaction holds the locks of their rows, so that no other relay publishes them at the
+// same time. If the transaction fails to commit after some of them were published, they are still unsent and
+// are published again by the next batch: consumers receive them twice and have to handle that.
+func (o *outboxRelay) relayBatch(ctx context.Context) (uint64, error) {
+	logger := utils.LoggerWithContext(ctx, o.logger)
+
+	var sentIdList []uint64
+	txErr := o.goquDatabase.WithTx(func(td *goqu.TxDatabase) error {
+		outboxMessageDataAccessor := o.outboxMessageDataAccessor.WithDatabase(td)
+
+		outboxMessageList, err := outboxMessageDataAccessor.GetUnsentOutboxMessageListWithXLock(ctx, o.batchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, outboxMessage := range outboxMessageList {
+			if err = o.client.SendWithKey(ctx, outboxMessage.Topic, outboxMessage.Key, outboxMessage.Payload, nil); err != nil {
+				logger.
+					With(zap.Uint64("outbox_message_id", outboxMessage.ID)).
+					With(zap.Error(err)).
+					Warn("failed to publish outbox message, will retry later")
+				break
+			}
+
+			sentIdList = append(sentIdList, outboxMessage.ID)
+		}
+
+		return outboxMessageDataAccessor.UpdateOutboxMessageListAsSent(ctx, sentIdList, time.Now())
+	})
+	if txErr != nil {
+		return 0, txErr
+	}
+
+	return uint64(len(sentIdList)), nil
+}
+
+// purgeSentMessages deletes the messages sent before the retention, batch by batch.
+func (o *outboxRelay) purgeSentMessages(ctx context.Context) {
+	logger := utils.LoggerWithContext(ctx, o.logger)
+
+	sentTime := time.Now().Add(-o.retention)
+	var purgedCount uint64
+	for ctx.Err() == nil {
+		deletedCount, err := o.outboxMessageDataAccessor.DeleteSentOutboxMessageList(ctx, sentTime, o.batchSize)
+		if err != nil {
+			logger.With(zap.Error(err)).Error("failed to purge sent outbox messages")
+			break
+		}
+
+		purgedCount += deletedCount
+		if deletedCount < o.batchSize {
+			break
+		}
+	}
+
+	if purgedCount > 0 {
+		logger.With(zap.Uint64("count", purgedCount)).Info("purged sent outbox messages")
+	}
+}
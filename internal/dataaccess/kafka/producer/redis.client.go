@@ -0,0 +1,90 @@
+package producer
+
+import (
+	"context"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/manhhung2111/go-idm/internal/config"
+	"github.com/manhhung2111/go-idm/internal/utils"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// RedisStreamFieldPayload is the stream entry field holding the message payload, and RedisStreamFieldKey
+	// the one holding its key. Message headers are stored in the other fields of the entry, each prefixed
+	// with RedisStreamFieldHeaderPrefix.
+	RedisStreamFieldPayload      = "payload"
+	RedisStreamFieldKey          = "key"
+	RedisStreamFieldHeaderPrefix = "header:"
+)
+
+type redisClient struct {
+	redisClient *redis.Client
+	maxLength   int64
+	logger      *zap.Logger
+}
+
+func NewRedisClient(
+	queueConfig config.Queue,
+	logger *zap.Logger,
+) (Client, func()) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     queueConfig.Redis.Address,
+		Username: queueConfig.Redis.Username,
+		Password: queueConfig.Redis.Password,
+	})
+
+	cleanupFunc := func() {
+		if err := client.Close(); err != nil {
+			logger.With(zap.Error(err)).Error("failed to close redis producer client")
+		}
+	}
+
+	return &redisClient{
+		redisClient: client,
+		maxLength:   int64(queueConfig.Redis.GetMaxLength()),
+		logger:      logger,
+	}, cleanupFunc
+}
+
+func (c redisClient) Send(ctx context.Context, topic string, payload []byte) error {
+	return c.SendWithHeaders(ctx, topic, payload, nil)
+}
+
+func (c redisClient) SendWithHeaders(ctx context.Context, topic string, payload []byte, headers map[string]string) error {
+	return c.SendWithKey(ctx, topic, "", payload, headers)
+}
+
+// SendWithKey implements Client. A stream is totally ordered, so the key is only stored along with the
+// message for the consumers to see. The stream is trimmed to about the max length as the message is added,
+// approximately for Redis to only drop whole nodes of the stream, which is much cheaper.
+func (c redisClient) SendWithKey(ctx context.Context, topic string, key string, payload []byte, headers map[string]string) error {
+	logger := utils.LoggerWithContext(ctx, c.logger).
+		With(zap.String("topic", topic)).
+		With(zap.String("key", key)).
+		With(zap.ByteString("payload", payload)).
+		With(zap.Any("headers", headers))
+
+	values := make(map[string]any, len(headers)+2)
+	values[RedisStreamFieldPayload] = payload
+	if key != "" {
+		values[RedisStreamFieldKey] = key
+	}
+	for key, value := range headers {
+		values[RedisStreamFieldHeaderPrefix+key] = value
+	}
+
+	if err := c.redisClient.XAdd(ctx, &redis.XAddArgs{
+		Stream: topic,
+		MaxLen: c.maxLength,
+		Approx: true,
+		Values: values,
+	}).Err(); err != nil {
+		logger.With(zap.Error(err)).Error("failed to produce message")
+		return status.Errorf(codes.Internal, "failed to produce message: %+v", err)
+	}
+
+	return nil
+}
@@ -5,4 +5,6 @@ import "github.com/google/wire"
 var WireSet = wire.NewSet(
 	NewClient,
 	NewDownloadTaskCreatedProducer,
+	NewDownloadTaskEventProducer,
+	NewOutboxRelay,
 )
\ No newline at end of file
@@ -3,10 +3,12 @@ package kafka
 import (
 	"github.com/google/wire"
 	"github.com/manhhung2111/go-idm/internal/dataaccess/kafka/consumer"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/kafka/inprocess"
 	"github.com/manhhung2111/go-idm/internal/dataaccess/kafka/producer"
 )
 
 var WireSet = wire.NewSet(
 	consumer.WireSet,
+	inprocess.WireSet,
 	producer.WireSet,
 )
\ No newline at end of file
@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.33.0
+// 	protoc-gen-go v1.36.10
 // 	protoc        v6.32.1
 // source: proto/api.proto
 
@@ -11,6 +11,7 @@ import (
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
 	sync "sync"
+	unsafe "unsafe"
 )
 
 const (
@@ -121,33 +122,1887 @@ func (DownloadStatus) EnumDescriptor() ([]byte, []int) {
 	return file_proto_api_proto_rawDescGZIP(), []int{1}
 }
 
+type TeamRole int32
+
+const (
+	TeamRole_UndefinedTeamRole TeamRole = 0
+	TeamRole_Owner             TeamRole = 1
+	TeamRole_Admin             TeamRole = 2
+	TeamRole_Member            TeamRole = 3
+)
+
+// Enum value maps for TeamRole.
+var (
+	TeamRole_name = map[int32]string{
+		0: "UndefinedTeamRole",
+		1: "Owner",
+		2: "Admin",
+		3: "Member",
+	}
+	TeamRole_value = map[string]int32{
+		"UndefinedTeamRole": 0,
+		"Owner":             1,
+		"Admin":             2,
+		"Member":            3,
+	}
+)
+
+func (x TeamRole) Enum() *TeamRole {
+	p := new(TeamRole)
+	*p = x
+	return p
+}
+
+func (x TeamRole) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TeamRole) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_api_proto_enumTypes[2].Descriptor()
+}
+
+func (TeamRole) Type() protoreflect.EnumType {
+	return &file_proto_api_proto_enumTypes[2]
+}
+
+func (x TeamRole) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TeamRole.Descriptor instead.
+func (TeamRole) EnumDescriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{2}
+}
+
+type DownloadTaskShareLevel int32
+
+const (
+	DownloadTaskShareLevel_UndefinedDownloadTaskShareLevel DownloadTaskShareLevel = 0
+	DownloadTaskShareLevel_Read                            DownloadTaskShareLevel = 1
+	DownloadTaskShareLevel_Manage                          DownloadTaskShareLevel = 2
+)
+
+// Enum value maps for DownloadTaskShareLevel.
+var (
+	DownloadTaskShareLevel_name = map[int32]string{
+		0: "UndefinedDownloadTaskShareLevel",
+		1: "Read",
+		2: "Manage",
+	}
+	DownloadTaskShareLevel_value = map[string]int32{
+		"UndefinedDownloadTaskShareLevel": 0,
+		"Read":                            1,
+		"Manage":                          2,
+	}
+)
+
+func (x DownloadTaskShareLevel) Enum() *DownloadTaskShareLevel {
+	p := new(DownloadTaskShareLevel)
+	*p = x
+	return p
+}
+
+func (x DownloadTaskShareLevel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DownloadTaskShareLevel) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_api_proto_enumTypes[3].Descriptor()
+}
+
+func (DownloadTaskShareLevel) Type() protoreflect.EnumType {
+	return &file_proto_api_proto_enumTypes[3]
+}
+
+func (x DownloadTaskShareLevel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DownloadTaskShareLevel.Descriptor instead.
+func (DownloadTaskShareLevel) EnumDescriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{3}
+}
+
+type DownloadFileIssueKind int32
+
+const (
+	DownloadFileIssueKind_UndefinedDownloadFileIssueKind DownloadFileIssueKind = 0
+	DownloadFileIssueKind_FileMissing                    DownloadFileIssueKind = 1
+	DownloadFileIssueKind_FileSizeMismatch               DownloadFileIssueKind = 2
+	DownloadFileIssueKind_FileChecksumMismatch           DownloadFileIssueKind = 3
+	DownloadFileIssueKind_FileMetadataInvalid            DownloadFileIssueKind = 4
+	DownloadFileIssueKind_FileOrphaned                   DownloadFileIssueKind = 5
+)
+
+// Enum value maps for DownloadFileIssueKind.
+var (
+	DownloadFileIssueKind_name = map[int32]string{
+		0: "UndefinedDownloadFileIssueKind",
+		1: "FileMissing",
+		2: "FileSizeMismatch",
+		3: "FileChecksumMismatch",
+		4: "FileMetadataInvalid",
+		5: "FileOrphaned",
+	}
+	DownloadFileIssueKind_value = map[string]int32{
+		"UndefinedDownloadFileIssueKind": 0,
+		"FileMissing":                    1,
+		"FileSizeMismatch":               2,
+		"FileChecksumMismatch":           3,
+		"FileMetadataInvalid":            4,
+		"FileOrphaned":                   5,
+	}
+)
+
+func (x DownloadFileIssueKind) Enum() *DownloadFileIssueKind {
+	p := new(DownloadFileIssueKind)
+	*p = x
+	return p
+}
+
+func (x DownloadFileIssueKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DownloadFileIssueKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_api_proto_enumTypes[4].Descriptor()
+}
+
+func (DownloadFileIssueKind) Type() protoreflect.EnumType {
+	return &file_proto_api_proto_enumTypes[4]
+}
+
+func (x DownloadFileIssueKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DownloadFileIssueKind.Descriptor instead.
+func (DownloadFileIssueKind) EnumDescriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{4}
+}
+
+type DownloadFileMismatchFix int32
+
+const (
+	DownloadFileMismatchFix_UndefinedDownloadFileMismatchFix DownloadFileMismatchFix = 0
+	DownloadFileMismatchFix_MarkFailed                       DownloadFileMismatchFix = 1
+	DownloadFileMismatchFix_Reenqueue                        DownloadFileMismatchFix = 2
+)
+
+// Enum value maps for DownloadFileMismatchFix.
+var (
+	DownloadFileMismatchFix_name = map[int32]string{
+		0: "UndefinedDownloadFileMismatchFix",
+		1: "MarkFailed",
+		2: "Reenqueue",
+	}
+	DownloadFileMismatchFix_value = map[string]int32{
+		"UndefinedDownloadFileMismatchFix": 0,
+		"MarkFailed":                       1,
+		"Reenqueue":                        2,
+	}
+)
+
+func (x DownloadFileMismatchFix) Enum() *DownloadFileMismatchFix {
+	p := new(DownloadFileMismatchFix)
+	*p = x
+	return p
+}
+
+func (x DownloadFileMismatchFix) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DownloadFileMismatchFix) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_api_proto_enumTypes[5].Descriptor()
+}
+
+func (DownloadFileMismatchFix) Type() protoreflect.EnumType {
+	return &file_proto_api_proto_enumTypes[5]
+}
+
+func (x DownloadFileMismatchFix) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DownloadFileMismatchFix.Descriptor instead.
+func (DownloadFileMismatchFix) EnumDescriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{5}
+}
+
 type Account struct {
-	state         protoimpl.MessageState
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	AccountName   string                 `protobuf:"bytes,2,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Account) Reset() {
+	*x = Account{}
+	mi := &file_proto_api_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Account) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Account) ProtoMessage() {}
+
+func (x *Account) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Account.ProtoReflect.Descriptor instead.
+func (*Account) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Account) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Account) GetAccountName() string {
+	if x != nil {
+		return x.AccountName
+	}
+	return ""
+}
+
+type DownloadTask struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	DownloadType   DownloadType           `protobuf:"varint,2,opt,name=download_type,json=downloadType,proto3,enum=go_idm.v1.DownloadType" json:"download_type,omitempty"`
+	Url            string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	DownloadStatus DownloadStatus         `protobuf:"varint,4,opt,name=download_status,json=downloadStatus,proto3,enum=go_idm.v1.DownloadStatus" json:"download_status,omitempty"`
+	OfAccountId    uint64                 `protobuf:"varint,5,opt,name=of_account_id,json=ofAccountId,proto3" json:"of_account_id,omitempty"`
+	OfTeamId       uint64                 `protobuf:"varint,6,opt,name=of_team_id,json=ofTeamId,proto3" json:"of_team_id,omitempty"`
+	DeletedTime    uint64                 `protobuf:"varint,7,opt,name=deleted_time,json=deletedTime,proto3" json:"deleted_time,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DownloadTask) Reset() {
+	*x = DownloadTask{}
+	mi := &file_proto_api_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadTask) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadTask) ProtoMessage() {}
+
+func (x *DownloadTask) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadTask.ProtoReflect.Descriptor instead.
+func (*DownloadTask) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DownloadTask) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DownloadTask) GetDownloadType() DownloadType {
+	if x != nil {
+		return x.DownloadType
+	}
+	return DownloadType_UndefinedDownloadType
+}
+
+func (x *DownloadTask) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *DownloadTask) GetDownloadStatus() DownloadStatus {
+	if x != nil {
+		return x.DownloadStatus
+	}
+	return DownloadStatus_UndefinedDownloadStatus
+}
+
+func (x *DownloadTask) GetOfAccountId() uint64 {
+	if x != nil {
+		return x.OfAccountId
+	}
+	return 0
+}
+
+func (x *DownloadTask) GetOfTeamId() uint64 {
+	if x != nil {
+		return x.OfTeamId
+	}
+	return 0
+}
+
+func (x *DownloadTask) GetDeletedTime() uint64 {
+	if x != nil {
+		return x.DeletedTime
+	}
+	return 0
+}
+
+type Team struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TeamName      string                 `protobuf:"bytes,2,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	TeamRole      TeamRole               `protobuf:"varint,3,opt,name=team_role,json=teamRole,proto3,enum=go_idm.v1.TeamRole" json:"team_role,omitempty"`
+	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Team) Reset() {
+	*x = Team{}
+	mi := &file_proto_api_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Team) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Team) ProtoMessage() {}
+
+func (x *Team) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Team.ProtoReflect.Descriptor instead.
+func (*Team) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Team) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Team) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *Team) GetTeamRole() TeamRole {
+	if x != nil {
+		return x.TeamRole
+	}
+	return TeamRole_UndefinedTeamRole
+}
+
+type ShareLink struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	DownloadTaskId    uint64                 `protobuf:"varint,2,opt,name=download_task_id,json=downloadTaskId,proto3" json:"download_task_id,omitempty"`
+	LinkToken         string                 `protobuf:"bytes,3,opt,name=link_token,json=linkToken,proto3" json:"link_token,omitempty"`
+	Url               string                 `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	PasswordProtected bool                   `protobuf:"varint,5,opt,name=password_protected,json=passwordProtected,proto3" json:"password_protected,omitempty"`
+	ExpireTime        uint64                 `protobuf:"varint,6,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
+	MaxDownloadCount  uint64                 `protobuf:"varint,7,opt,name=max_download_count,json=maxDownloadCount,proto3" json:"max_download_count,omitempty"`
+	DownloadCount     uint64                 `protobuf:"varint,8,opt,name=download_count,json=downloadCount,proto3" json:"download_count,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ShareLink) Reset() {
+	*x = ShareLink{}
+	mi := &file_proto_api_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareLink) ProtoMessage() {}
+
+func (x *ShareLink) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareLink.ProtoReflect.Descriptor instead.
+func (*ShareLink) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ShareLink) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ShareLink) GetDownloadTaskId() uint64 {
+	if x != nil {
+		return x.DownloadTaskId
+	}
+	return 0
+}
+
+func (x *ShareLink) GetLinkToken() string {
+	if x != nil {
+		return x.LinkToken
+	}
+	return ""
+}
+
+func (x *ShareLink) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ShareLink) GetPasswordProtected() bool {
+	if x != nil {
+		return x.PasswordProtected
+	}
+	return false
+}
+
+func (x *ShareLink) GetExpireTime() uint64 {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return 0
+}
+
+func (x *ShareLink) GetMaxDownloadCount() uint64 {
+	if x != nil {
+		return x.MaxDownloadCount
+	}
+	return 0
+}
+
+func (x *ShareLink) GetDownloadCount() uint64 {
+	if x != nil {
+		return x.DownloadCount
+	}
+	return 0
+}
+
+type AuditEvent struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ActorAccountId uint64                 `protobuf:"varint,2,opt,name=actor_account_id,json=actorAccountId,proto3" json:"actor_account_id,omitempty"`
+	Action         string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	ResourceType   string                 `protobuf:"bytes,4,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"`
+	ResourceId     uint64                 `protobuf:"varint,5,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	BeforeValue    string                 `protobuf:"bytes,6,opt,name=before_value,json=beforeValue,proto3" json:"before_value,omitempty"`
+	AfterValue     string                 `protobuf:"bytes,7,opt,name=after_value,json=afterValue,proto3" json:"after_value,omitempty"`
+	IpAddress      string                 `protobuf:"bytes,8,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	RequestId      string                 `protobuf:"bytes,9,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	CreatedTime    uint64                 `protobuf:"varint,10,opt,name=created_time,json=createdTime,proto3" json:"created_time,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	mi := &file_proto_api_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvent) ProtoMessage() {}
+
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AuditEvent) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetActorAccountId() uint64 {
+	if x != nil {
+		return x.ActorAccountId
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetResourceType() string {
+	if x != nil {
+		return x.ResourceType
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetResourceId() uint64 {
+	if x != nil {
+		return x.ResourceId
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetBeforeValue() string {
+	if x != nil {
+		return x.BeforeValue
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetAfterValue() string {
+	if x != nil {
+		return x.AfterValue
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetCreatedTime() uint64 {
+	if x != nil {
+		return x.CreatedTime
+	}
+	return 0
+}
+
+type DownloadFileIssue struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Kind             DownloadFileIssueKind  `protobuf:"varint,1,opt,name=kind,proto3,enum=go_idm.v1.DownloadFileIssueKind" json:"kind,omitempty"`
+	DownloadTaskId   uint64                 `protobuf:"varint,2,opt,name=download_task_id,json=downloadTaskId,proto3" json:"download_task_id,omitempty"`
+	FileName         string                 `protobuf:"bytes,3,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	ExpectedFileSize uint64                 `protobuf:"varint,4,opt,name=expected_file_size,json=expectedFileSize,proto3" json:"expected_file_size,omitempty"`
+	ActualFileSize   uint64                 `protobuf:"varint,5,opt,name=actual_file_size,json=actualFileSize,proto3" json:"actual_file_size,omitempty"`
+	ExpectedChecksum string                 `protobuf:"bytes,6,opt,name=expected_checksum,json=expectedChecksum,proto3" json:"expected_checksum,omitempty"`
+	ActualChecksum   string                 `protobuf:"bytes,7,opt,name=actual_checksum,json=actualChecksum,proto3" json:"actual_checksum,omitempty"`
+	Fixed            bool                   `protobuf:"varint,8,opt,name=fixed,proto3" json:"fixed,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DownloadFileIssue) Reset() {
+	*x = DownloadFileIssue{}
+	mi := &file_proto_api_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadFileIssue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadFileIssue) ProtoMessage() {}
+
+func (x *DownloadFileIssue) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadFileIssue.ProtoReflect.Descriptor instead.
+func (*DownloadFileIssue) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DownloadFileIssue) GetKind() DownloadFileIssueKind {
+	if x != nil {
+		return x.Kind
+	}
+	return DownloadFileIssueKind_UndefinedDownloadFileIssueKind
+}
+
+func (x *DownloadFileIssue) GetDownloadTaskId() uint64 {
+	if x != nil {
+		return x.DownloadTaskId
+	}
+	return 0
+}
+
+func (x *DownloadFileIssue) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *DownloadFileIssue) GetExpectedFileSize() uint64 {
+	if x != nil {
+		return x.ExpectedFileSize
+	}
+	return 0
+}
+
+func (x *DownloadFileIssue) GetActualFileSize() uint64 {
+	if x != nil {
+		return x.ActualFileSize
+	}
+	return 0
+}
+
+func (x *DownloadFileIssue) GetExpectedChecksum() string {
+	if x != nil {
+		return x.ExpectedChecksum
+	}
+	return ""
+}
+
+func (x *DownloadFileIssue) GetActualChecksum() string {
+	if x != nil {
+		return x.ActualChecksum
+	}
+	return ""
+}
+
+func (x *DownloadFileIssue) GetFixed() bool {
+	if x != nil {
+		return x.Fixed
+	}
+	return false
+}
+
+type CreateAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountName   string                 `protobuf:"bytes,1,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAccountRequest) Reset() {
+	*x = CreateAccountRequest{}
+	mi := &file_proto_api_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAccountRequest) ProtoMessage() {}
+
+func (x *CreateAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAccountRequest.ProtoReflect.Descriptor instead.
+func (*CreateAccountRequest) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateAccountRequest) GetAccountName() string {
+	if x != nil {
+		return x.AccountName
+	}
+	return ""
+}
+
+func (x *CreateAccountRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type CreateAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAccountResponse) Reset() {
+	*x = CreateAccountResponse{}
+	mi := &file_proto_api_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAccountResponse) ProtoMessage() {}
+
+func (x *CreateAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAccountResponse.ProtoReflect.Descriptor instead.
+func (*CreateAccountResponse) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateAccountResponse) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+type CreateSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountName   string                 `protobuf:"bytes,1,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSessionRequest) Reset() {
+	*x = CreateSessionRequest{}
+	mi := &file_proto_api_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSessionRequest) ProtoMessage() {}
+
+func (x *CreateSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSessionRequest.ProtoReflect.Descriptor instead.
+func (*CreateSessionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateSessionRequest) GetAccountName() string {
+	if x != nil {
+		return x.AccountName
+	}
+	return ""
+}
+
+func (x *CreateSessionRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type CreateSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSessionResponse) Reset() {
+	*x = CreateSessionResponse{}
+	mi := &file_proto_api_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSessionResponse) ProtoMessage() {}
+
+func (x *CreateSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSessionResponse.ProtoReflect.Descriptor instead.
+func (*CreateSessionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CreateSessionResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type CreateDownloadTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	DownloadType  DownloadType           `protobuf:"varint,2,opt,name=download_type,json=downloadType,proto3,enum=go_idm.v1.DownloadType" json:"download_type,omitempty"`
+	Url           string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	TeamId        uint64                 `protobuf:"varint,4,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateDownloadTaskRequest) Reset() {
+	*x = CreateDownloadTaskRequest{}
+	mi := &file_proto_api_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateDownloadTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateDownloadTaskRequest) ProtoMessage() {}
+
+func (x *CreateDownloadTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateDownloadTaskRequest.ProtoReflect.Descriptor instead.
+func (*CreateDownloadTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CreateDownloadTaskRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *CreateDownloadTaskRequest) GetDownloadType() DownloadType {
+	if x != nil {
+		return x.DownloadType
+	}
+	return DownloadType_UndefinedDownloadType
+}
+
+func (x *CreateDownloadTaskRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateDownloadTaskRequest) GetTeamId() uint64 {
+	if x != nil {
+		return x.TeamId
+	}
+	return 0
+}
+
+type CreateDownloadTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DownloadTask  *DownloadTask          `protobuf:"bytes,1,opt,name=download_task,json=downloadTask,proto3" json:"download_task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateDownloadTaskResponse) Reset() {
+	*x = CreateDownloadTaskResponse{}
+	mi := &file_proto_api_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateDownloadTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateDownloadTaskResponse) ProtoMessage() {}
+
+func (x *CreateDownloadTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateDownloadTaskResponse.ProtoReflect.Descriptor instead.
+func (*CreateDownloadTaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CreateDownloadTaskResponse) GetDownloadTask() *DownloadTask {
+	if x != nil {
+		return x.DownloadTask
+	}
+	return nil
+}
+
+type GetDownloadTaskListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Offset        uint64                 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit         uint64                 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDownloadTaskListRequest) Reset() {
+	*x = GetDownloadTaskListRequest{}
+	mi := &file_proto_api_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDownloadTaskListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDownloadTaskListRequest) ProtoMessage() {}
+
+func (x *GetDownloadTaskListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDownloadTaskListRequest.ProtoReflect.Descriptor instead.
+func (*GetDownloadTaskListRequest) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetDownloadTaskListRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *GetDownloadTaskListRequest) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetDownloadTaskListRequest) GetLimit() uint64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetDownloadTaskListResponse struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	DownloadTaskList       []*DownloadTask        `protobuf:"bytes,1,rep,name=download_task_list,json=downloadTaskList,proto3" json:"download_task_list,omitempty"`
+	TotalDownloadTaskCount uint64                 `protobuf:"varint,2,opt,name=total_download_task_count,json=totalDownloadTaskCount,proto3" json:"total_download_task_count,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *GetDownloadTaskListResponse) Reset() {
+	*x = GetDownloadTaskListResponse{}
+	mi := &file_proto_api_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDownloadTaskListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDownloadTaskListResponse) ProtoMessage() {}
+
+func (x *GetDownloadTaskListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDownloadTaskListResponse.ProtoReflect.Descriptor instead.
+func (*GetDownloadTaskListResponse) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetDownloadTaskListResponse) GetDownloadTaskList() []*DownloadTask {
+	if x != nil {
+		return x.DownloadTaskList
+	}
+	return nil
+}
+
+func (x *GetDownloadTaskListResponse) GetTotalDownloadTaskCount() uint64 {
+	if x != nil {
+		return x.TotalDownloadTaskCount
+	}
+	return 0
+}
+
+type UpdateDownloadTaskRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Token          string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	DownloadTaskId uint64                 `protobuf:"varint,2,opt,name=download_task_id,json=downloadTaskId,proto3" json:"download_task_id,omitempty"`
+	Url            string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateDownloadTaskRequest) Reset() {
+	*x = UpdateDownloadTaskRequest{}
+	mi := &file_proto_api_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateDownloadTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateDownloadTaskRequest) ProtoMessage() {}
+
+func (x *UpdateDownloadTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateDownloadTaskRequest.ProtoReflect.Descriptor instead.
+func (*UpdateDownloadTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UpdateDownloadTaskRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *UpdateDownloadTaskRequest) GetDownloadTaskId() uint64 {
+	if x != nil {
+		return x.DownloadTaskId
+	}
+	return 0
+}
+
+func (x *UpdateDownloadTaskRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type UpdateDownloadTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DownloadTask  *DownloadTask          `protobuf:"bytes,1,opt,name=download_task,json=downloadTask,proto3" json:"download_task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateDownloadTaskResponse) Reset() {
+	*x = UpdateDownloadTaskResponse{}
+	mi := &file_proto_api_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateDownloadTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateDownloadTaskResponse) ProtoMessage() {}
+
+func (x *UpdateDownloadTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateDownloadTaskResponse.ProtoReflect.Descriptor instead.
+func (*UpdateDownloadTaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UpdateDownloadTaskResponse) GetDownloadTask() *DownloadTask {
+	if x != nil {
+		return x.DownloadTask
+	}
+	return nil
+}
+
+type DeleteDownloadTaskRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Token          string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	DownloadTaskId uint64                 `protobuf:"varint,2,opt,name=download_task_id,json=downloadTaskId,proto3" json:"download_task_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DeleteDownloadTaskRequest) Reset() {
+	*x = DeleteDownloadTaskRequest{}
+	mi := &file_proto_api_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteDownloadTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteDownloadTaskRequest) ProtoMessage() {}
+
+func (x *DeleteDownloadTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteDownloadTaskRequest.ProtoReflect.Descriptor instead.
+func (*DeleteDownloadTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DeleteDownloadTaskRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *DeleteDownloadTaskRequest) GetDownloadTaskId() uint64 {
+	if x != nil {
+		return x.DownloadTaskId
+	}
+	return 0
+}
+
+type DeleteDownloadTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteDownloadTaskResponse) Reset() {
+	*x = DeleteDownloadTaskResponse{}
+	mi := &file_proto_api_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteDownloadTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteDownloadTaskResponse) ProtoMessage() {}
+
+func (x *DeleteDownloadTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteDownloadTaskResponse.ProtoReflect.Descriptor instead.
+func (*DeleteDownloadTaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{17}
+}
+
+type RestoreDownloadTaskRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Token          string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	DownloadTaskId uint64                 `protobuf:"varint,2,opt,name=download_task_id,json=downloadTaskId,proto3" json:"download_task_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RestoreDownloadTaskRequest) Reset() {
+	*x = RestoreDownloadTaskRequest{}
+	mi := &file_proto_api_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreDownloadTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreDownloadTaskRequest) ProtoMessage() {}
+
+func (x *RestoreDownloadTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreDownloadTaskRequest.ProtoReflect.Descriptor instead.
+func (*RestoreDownloadTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RestoreDownloadTaskRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *RestoreDownloadTaskRequest) GetDownloadTaskId() uint64 {
+	if x != nil {
+		return x.DownloadTaskId
+	}
+	return 0
+}
+
+type RestoreDownloadTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DownloadTask  *DownloadTask          `protobuf:"bytes,1,opt,name=download_task,json=downloadTask,proto3" json:"download_task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreDownloadTaskResponse) Reset() {
+	*x = RestoreDownloadTaskResponse{}
+	mi := &file_proto_api_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreDownloadTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreDownloadTaskResponse) ProtoMessage() {}
+
+func (x *RestoreDownloadTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreDownloadTaskResponse.ProtoReflect.Descriptor instead.
+func (*RestoreDownloadTaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RestoreDownloadTaskResponse) GetDownloadTask() *DownloadTask {
+	if x != nil {
+		return x.DownloadTask
+	}
+	return nil
+}
+
+type ListTrashRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Offset        uint64                 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit         uint64                 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrashRequest) Reset() {
+	*x = ListTrashRequest{}
+	mi := &file_proto_api_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrashRequest) ProtoMessage() {}
+
+func (x *ListTrashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrashRequest.ProtoReflect.Descriptor instead.
+func (*ListTrashRequest) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListTrashRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ListTrashRequest) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListTrashRequest) GetLimit() uint64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListTrashResponse struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	DownloadTaskList       []*DownloadTask        `protobuf:"bytes,1,rep,name=download_task_list,json=downloadTaskList,proto3" json:"download_task_list,omitempty"`
+	TotalDownloadTaskCount uint64                 `protobuf:"varint,2,opt,name=total_download_task_count,json=totalDownloadTaskCount,proto3" json:"total_download_task_count,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ListTrashResponse) Reset() {
+	*x = ListTrashResponse{}
+	mi := &file_proto_api_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrashResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrashResponse) ProtoMessage() {}
+
+func (x *ListTrashResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrashResponse.ProtoReflect.Descriptor instead.
+func (*ListTrashResponse) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListTrashResponse) GetDownloadTaskList() []*DownloadTask {
+	if x != nil {
+		return x.DownloadTaskList
+	}
+	return nil
+}
+
+func (x *ListTrashResponse) GetTotalDownloadTaskCount() uint64 {
+	if x != nil {
+		return x.TotalDownloadTaskCount
+	}
+	return 0
+}
+
+type GetDownloadTaskFiletRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Token          string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	DownloadTaskId uint64                 `protobuf:"varint,2,opt,name=download_task_id,json=downloadTaskId,proto3" json:"download_task_id,omitempty"`
+	Offset         uint64                 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Length         uint64                 `protobuf:"varint,4,opt,name=length,proto3" json:"length,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetDownloadTaskFiletRequest) Reset() {
+	*x = GetDownloadTaskFiletRequest{}
+	mi := &file_proto_api_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDownloadTaskFiletRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDownloadTaskFiletRequest) ProtoMessage() {}
+
+func (x *GetDownloadTaskFiletRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDownloadTaskFiletRequest.ProtoReflect.Descriptor instead.
+func (*GetDownloadTaskFiletRequest) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetDownloadTaskFiletRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *GetDownloadTaskFiletRequest) GetDownloadTaskId() uint64 {
+	if x != nil {
+		return x.DownloadTaskId
+	}
+	return 0
+}
+
+func (x *GetDownloadTaskFiletRequest) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetDownloadTaskFiletRequest) GetLength() uint64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+type GetDownloadTaskFiletResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDownloadTaskFiletResponse) Reset() {
+	*x = GetDownloadTaskFiletResponse{}
+	mi := &file_proto_api_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDownloadTaskFiletResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDownloadTaskFiletResponse) ProtoMessage() {}
+
+func (x *GetDownloadTaskFiletResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDownloadTaskFiletResponse.ProtoReflect.Descriptor instead.
+func (*GetDownloadTaskFiletResponse) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetDownloadTaskFiletResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type GetDownloadTaskRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Token          string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	DownloadTaskId uint64                 `protobuf:"varint,2,opt,name=download_task_id,json=downloadTaskId,proto3" json:"download_task_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetDownloadTaskRequest) Reset() {
+	*x = GetDownloadTaskRequest{}
+	mi := &file_proto_api_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDownloadTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDownloadTaskRequest) ProtoMessage() {}
+
+func (x *GetDownloadTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDownloadTaskRequest.ProtoReflect.Descriptor instead.
+func (*GetDownloadTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetDownloadTaskRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *GetDownloadTaskRequest) GetDownloadTaskId() uint64 {
+	if x != nil {
+		return x.DownloadTaskId
+	}
+	return 0
+}
+
+type GetDownloadTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DownloadTask  *DownloadTask          `protobuf:"bytes,1,opt,name=download_task,json=downloadTask,proto3" json:"download_task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDownloadTaskResponse) Reset() {
+	*x = GetDownloadTaskResponse{}
+	mi := &file_proto_api_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDownloadTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDownloadTaskResponse) ProtoMessage() {}
+
+func (x *GetDownloadTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDownloadTaskResponse.ProtoReflect.Descriptor instead.
+func (*GetDownloadTaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetDownloadTaskResponse) GetDownloadTask() *DownloadTask {
+	if x != nil {
+		return x.DownloadTask
+	}
+	return nil
+}
+
+type ShareDownloadTaskRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Token          string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	DownloadTaskId uint64                 `protobuf:"varint,2,opt,name=download_task_id,json=downloadTaskId,proto3" json:"download_task_id,omitempty"`
+	AccountId      uint64                 `protobuf:"varint,3,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	ShareLevel     DownloadTaskShareLevel `protobuf:"varint,4,opt,name=share_level,json=shareLevel,proto3,enum=go_idm.v1.DownloadTaskShareLevel" json:"share_level,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ShareDownloadTaskRequest) Reset() {
+	*x = ShareDownloadTaskRequest{}
+	mi := &file_proto_api_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareDownloadTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareDownloadTaskRequest) ProtoMessage() {}
+
+func (x *ShareDownloadTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareDownloadTaskRequest.ProtoReflect.Descriptor instead.
+func (*ShareDownloadTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ShareDownloadTaskRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ShareDownloadTaskRequest) GetDownloadTaskId() uint64 {
+	if x != nil {
+		return x.DownloadTaskId
+	}
+	return 0
+}
+
+func (x *ShareDownloadTaskRequest) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *ShareDownloadTaskRequest) GetShareLevel() DownloadTaskShareLevel {
+	if x != nil {
+		return x.ShareLevel
+	}
+	return DownloadTaskShareLevel_UndefinedDownloadTaskShareLevel
+}
+
+type ShareDownloadTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-	Id          uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	AccountName string `protobuf:"bytes,2,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+func (x *ShareDownloadTaskResponse) Reset() {
+	*x = ShareDownloadTaskResponse{}
+	mi := &file_proto_api_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Account) Reset() {
-	*x = Account{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_api_proto_msgTypes[0]
+func (x *ShareDownloadTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareDownloadTaskResponse) ProtoMessage() {}
+
+func (x *ShareDownloadTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[27]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
+	return mi.MessageOf(x)
 }
 
-func (x *Account) String() string {
+// Deprecated: Use ShareDownloadTaskResponse.ProtoReflect.Descriptor instead.
+func (*ShareDownloadTaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{27}
+}
+
+type UnshareDownloadTaskRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Token          string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	DownloadTaskId uint64                 `protobuf:"varint,2,opt,name=download_task_id,json=downloadTaskId,proto3" json:"download_task_id,omitempty"`
+	AccountId      uint64                 `protobuf:"varint,3,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UnshareDownloadTaskRequest) Reset() {
+	*x = UnshareDownloadTaskRequest{}
+	mi := &file_proto_api_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnshareDownloadTaskRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Account) ProtoMessage() {}
+func (*UnshareDownloadTaskRequest) ProtoMessage() {}
 
-func (x *Account) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_api_proto_msgTypes[0]
-	if protoimpl.UnsafeEnabled && x != nil {
+func (x *UnshareDownloadTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[28]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -157,54 +2012,54 @@ func (x *Account) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Account.ProtoReflect.Descriptor instead.
-func (*Account) Descriptor() ([]byte, []int) {
-	return file_proto_api_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use UnshareDownloadTaskRequest.ProtoReflect.Descriptor instead.
+func (*UnshareDownloadTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *Account) GetId() uint64 {
+func (x *UnshareDownloadTaskRequest) GetToken() string {
 	if x != nil {
-		return x.Id
+		return x.Token
+	}
+	return ""
+}
+
+func (x *UnshareDownloadTaskRequest) GetDownloadTaskId() uint64 {
+	if x != nil {
+		return x.DownloadTaskId
 	}
 	return 0
 }
 
-func (x *Account) GetAccountName() string {
+func (x *UnshareDownloadTaskRequest) GetAccountId() uint64 {
 	if x != nil {
-		return x.AccountName
+		return x.AccountId
 	}
-	return ""
+	return 0
 }
 
-type DownloadTask struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+type UnshareDownloadTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
-
-	Id             uint64         `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	DownloadType   DownloadType   `protobuf:"varint,2,opt,name=download_type,json=downloadType,proto3,enum=go_idm.v1.DownloadType" json:"download_type,omitempty"`
-	Url            string         `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
-	DownloadStatus DownloadStatus `protobuf:"varint,4,opt,name=download_status,json=downloadStatus,proto3,enum=go_idm.v1.DownloadStatus" json:"download_status,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DownloadTask) Reset() {
-	*x = DownloadTask{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_api_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x *UnshareDownloadTaskResponse) Reset() {
+	*x = UnshareDownloadTaskResponse{}
+	mi := &file_proto_api_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *DownloadTask) String() string {
+func (x *UnshareDownloadTaskResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DownloadTask) ProtoMessage() {}
+func (*UnshareDownloadTaskResponse) ProtoMessage() {}
 
-func (x *DownloadTask) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_api_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
+func (x *UnshareDownloadTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[29]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -214,66 +2069,86 @@ func (x *DownloadTask) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DownloadTask.ProtoReflect.Descriptor instead.
-func (*DownloadTask) Descriptor() ([]byte, []int) {
-	return file_proto_api_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use UnshareDownloadTaskResponse.ProtoReflect.Descriptor instead.
+func (*UnshareDownloadTaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *DownloadTask) GetId() uint64 {
-	if x != nil {
-		return x.Id
-	}
-	return 0
+type CreateTeamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	TeamName      string                 `protobuf:"bytes,2,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DownloadTask) GetDownloadType() DownloadType {
+func (x *CreateTeamRequest) Reset() {
+	*x = CreateTeamRequest{}
+	mi := &file_proto_api_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTeamRequest) ProtoMessage() {}
+
+func (x *CreateTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[30]
 	if x != nil {
-		return x.DownloadType
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return DownloadType_UndefinedDownloadType
+	return mi.MessageOf(x)
 }
 
-func (x *DownloadTask) GetUrl() string {
+// Deprecated: Use CreateTeamRequest.ProtoReflect.Descriptor instead.
+func (*CreateTeamRequest) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CreateTeamRequest) GetToken() string {
 	if x != nil {
-		return x.Url
+		return x.Token
 	}
 	return ""
 }
 
-func (x *DownloadTask) GetDownloadStatus() DownloadStatus {
+func (x *CreateTeamRequest) GetTeamName() string {
 	if x != nil {
-		return x.DownloadStatus
+		return x.TeamName
 	}
-	return DownloadStatus_UndefinedDownloadStatus
+	return ""
 }
 
-type CreateAccountRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+type CreateTeamResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Team          *Team                  `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	AccountName string `protobuf:"bytes,1,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
-	Password    string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateAccountRequest) Reset() {
-	*x = CreateAccountRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_api_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x *CreateTeamResponse) Reset() {
+	*x = CreateTeamResponse{}
+	mi := &file_proto_api_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateAccountRequest) String() string {
+func (x *CreateTeamResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateAccountRequest) ProtoMessage() {}
+func (*CreateTeamResponse) ProtoMessage() {}
 
-func (x *CreateAccountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_api_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
+func (x *CreateTeamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[31]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -283,51 +2158,85 @@ func (x *CreateAccountRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateAccountRequest.ProtoReflect.Descriptor instead.
-func (*CreateAccountRequest) Descriptor() ([]byte, []int) {
-	return file_proto_api_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use CreateTeamResponse.ProtoReflect.Descriptor instead.
+func (*CreateTeamResponse) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *CreateAccountRequest) GetAccountName() string {
+func (x *CreateTeamResponse) GetTeam() *Team {
 	if x != nil {
-		return x.AccountName
+		return x.Team
 	}
-	return ""
+	return nil
 }
 
-func (x *CreateAccountRequest) GetPassword() string {
+type GetTeamListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTeamListRequest) Reset() {
+	*x = GetTeamListRequest{}
+	mi := &file_proto_api_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTeamListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTeamListRequest) ProtoMessage() {}
+
+func (x *GetTeamListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[32]
 	if x != nil {
-		return x.Password
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTeamListRequest.ProtoReflect.Descriptor instead.
+func (*GetTeamListRequest) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetTeamListRequest) GetToken() string {
+	if x != nil {
+		return x.Token
 	}
 	return ""
 }
 
-type CreateAccountResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+type GetTeamListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamList      []*Team                `protobuf:"bytes,1,rep,name=team_list,json=teamList,proto3" json:"team_list,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	AccountId uint64 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateAccountResponse) Reset() {
-	*x = CreateAccountResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_api_proto_msgTypes[3]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x *GetTeamListResponse) Reset() {
+	*x = GetTeamListResponse{}
+	mi := &file_proto_api_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateAccountResponse) String() string {
+func (x *GetTeamListResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateAccountResponse) ProtoMessage() {}
+func (*GetTeamListResponse) ProtoMessage() {}
 
-func (x *CreateAccountResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_api_proto_msgTypes[3]
-	if protoimpl.UnsafeEnabled && x != nil {
+func (x *GetTeamListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[33]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -337,45 +2246,44 @@ func (x *CreateAccountResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateAccountResponse.ProtoReflect.Descriptor instead.
-func (*CreateAccountResponse) Descriptor() ([]byte, []int) {
-	return file_proto_api_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use GetTeamListResponse.ProtoReflect.Descriptor instead.
+func (*GetTeamListResponse) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *CreateAccountResponse) GetAccountId() uint64 {
+func (x *GetTeamListResponse) GetTeamList() []*Team {
 	if x != nil {
-		return x.AccountId
+		return x.TeamList
 	}
-	return 0
+	return nil
 }
 
-type CreateSessionRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+type AddTeamMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	TeamId        uint64                 `protobuf:"varint,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	AccountId     uint64                 `protobuf:"varint,3,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	TeamRole      TeamRole               `protobuf:"varint,4,opt,name=team_role,json=teamRole,proto3,enum=go_idm.v1.TeamRole" json:"team_role,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	AccountName string `protobuf:"bytes,1,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
-	Password    string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateSessionRequest) Reset() {
-	*x = CreateSessionRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_api_proto_msgTypes[4]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x *AddTeamMemberRequest) Reset() {
+	*x = AddTeamMemberRequest{}
+	mi := &file_proto_api_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateSessionRequest) String() string {
+func (x *AddTeamMemberRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateSessionRequest) ProtoMessage() {}
+func (*AddTeamMemberRequest) ProtoMessage() {}
 
-func (x *CreateSessionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_api_proto_msgTypes[4]
-	if protoimpl.UnsafeEnabled && x != nil {
+func (x *AddTeamMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[34]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -385,51 +2293,61 @@ func (x *CreateSessionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateSessionRequest.ProtoReflect.Descriptor instead.
-func (*CreateSessionRequest) Descriptor() ([]byte, []int) {
-	return file_proto_api_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use AddTeamMemberRequest.ProtoReflect.Descriptor instead.
+func (*AddTeamMemberRequest) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *CreateSessionRequest) GetAccountName() string {
+func (x *AddTeamMemberRequest) GetToken() string {
 	if x != nil {
-		return x.AccountName
+		return x.Token
 	}
 	return ""
 }
 
-func (x *CreateSessionRequest) GetPassword() string {
+func (x *AddTeamMemberRequest) GetTeamId() uint64 {
 	if x != nil {
-		return x.Password
+		return x.TeamId
 	}
-	return ""
+	return 0
 }
 
-type CreateSessionResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+func (x *AddTeamMemberRequest) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
 }
 
-func (x *CreateSessionResponse) Reset() {
-	*x = CreateSessionResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_api_proto_msgTypes[5]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *AddTeamMemberRequest) GetTeamRole() TeamRole {
+	if x != nil {
+		return x.TeamRole
 	}
+	return TeamRole_UndefinedTeamRole
 }
 
-func (x *CreateSessionResponse) String() string {
+type AddTeamMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTeamMemberResponse) Reset() {
+	*x = AddTeamMemberResponse{}
+	mi := &file_proto_api_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTeamMemberResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateSessionResponse) ProtoMessage() {}
+func (*AddTeamMemberResponse) ProtoMessage() {}
 
-func (x *CreateSessionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_api_proto_msgTypes[5]
-	if protoimpl.UnsafeEnabled && x != nil {
+func (x *AddTeamMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[35]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -439,46 +2357,36 @@ func (x *CreateSessionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateSessionResponse.ProtoReflect.Descriptor instead.
-func (*CreateSessionResponse) Descriptor() ([]byte, []int) {
-	return file_proto_api_proto_rawDescGZIP(), []int{5}
-}
-
-func (x *CreateSessionResponse) GetToken() string {
-	if x != nil {
-		return x.Token
-	}
-	return ""
+// Deprecated: Use AddTeamMemberResponse.ProtoReflect.Descriptor instead.
+func (*AddTeamMemberResponse) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{35}
 }
 
-type CreateDownloadTaskRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+type RemoveTeamMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	TeamId        uint64                 `protobuf:"varint,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	AccountId     uint64                 `protobuf:"varint,3,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Token        string       `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	DownloadType DownloadType `protobuf:"varint,2,opt,name=download_type,json=downloadType,proto3,enum=go_idm.v1.DownloadType" json:"download_type,omitempty"`
-	Url          string       `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateDownloadTaskRequest) Reset() {
-	*x = CreateDownloadTaskRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_api_proto_msgTypes[6]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x *RemoveTeamMemberRequest) Reset() {
+	*x = RemoveTeamMemberRequest{}
+	mi := &file_proto_api_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateDownloadTaskRequest) String() string {
+func (x *RemoveTeamMemberRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateDownloadTaskRequest) ProtoMessage() {}
+func (*RemoveTeamMemberRequest) ProtoMessage() {}
 
-func (x *CreateDownloadTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_api_proto_msgTypes[6]
-	if protoimpl.UnsafeEnabled && x != nil {
+func (x *RemoveTeamMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[36]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -488,58 +2396,54 @@ func (x *CreateDownloadTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateDownloadTaskRequest.ProtoReflect.Descriptor instead.
-func (*CreateDownloadTaskRequest) Descriptor() ([]byte, []int) {
-	return file_proto_api_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use RemoveTeamMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveTeamMemberRequest) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *CreateDownloadTaskRequest) GetToken() string {
+func (x *RemoveTeamMemberRequest) GetToken() string {
 	if x != nil {
 		return x.Token
 	}
 	return ""
 }
 
-func (x *CreateDownloadTaskRequest) GetDownloadType() DownloadType {
+func (x *RemoveTeamMemberRequest) GetTeamId() uint64 {
 	if x != nil {
-		return x.DownloadType
+		return x.TeamId
 	}
-	return DownloadType_UndefinedDownloadType
+	return 0
 }
 
-func (x *CreateDownloadTaskRequest) GetUrl() string {
+func (x *RemoveTeamMemberRequest) GetAccountId() uint64 {
 	if x != nil {
-		return x.Url
+		return x.AccountId
 	}
-	return ""
+	return 0
 }
 
-type CreateDownloadTaskResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+type RemoveTeamMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
-
-	DownloadTask *DownloadTask `protobuf:"bytes,1,opt,name=download_task,json=downloadTask,proto3" json:"download_task,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateDownloadTaskResponse) Reset() {
-	*x = CreateDownloadTaskResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_api_proto_msgTypes[7]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x *RemoveTeamMemberResponse) Reset() {
+	*x = RemoveTeamMemberResponse{}
+	mi := &file_proto_api_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateDownloadTaskResponse) String() string {
+func (x *RemoveTeamMemberResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateDownloadTaskResponse) ProtoMessage() {}
+func (*RemoveTeamMemberResponse) ProtoMessage() {}
 
-func (x *CreateDownloadTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_api_proto_msgTypes[7]
-	if protoimpl.UnsafeEnabled && x != nil {
+func (x *RemoveTeamMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[37]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -549,46 +2453,38 @@ func (x *CreateDownloadTaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateDownloadTaskResponse.ProtoReflect.Descriptor instead.
-func (*CreateDownloadTaskResponse) Descriptor() ([]byte, []int) {
-	return file_proto_api_proto_rawDescGZIP(), []int{7}
-}
-
-func (x *CreateDownloadTaskResponse) GetDownloadTask() *DownloadTask {
-	if x != nil {
-		return x.DownloadTask
-	}
-	return nil
+// Deprecated: Use RemoveTeamMemberResponse.ProtoReflect.Descriptor instead.
+func (*RemoveTeamMemberResponse) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{37}
 }
 
-type GetDownloadTaskListRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Token  string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	Offset uint64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
-	Limit  uint64 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+type CreateShareLinkRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Token            string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	DownloadTaskId   uint64                 `protobuf:"varint,2,opt,name=download_task_id,json=downloadTaskId,proto3" json:"download_task_id,omitempty"`
+	Password         string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	ExpiresInSeconds uint64                 `protobuf:"varint,4,opt,name=expires_in_seconds,json=expiresInSeconds,proto3" json:"expires_in_seconds,omitempty"`
+	MaxDownloadCount uint64                 `protobuf:"varint,5,opt,name=max_download_count,json=maxDownloadCount,proto3" json:"max_download_count,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *GetDownloadTaskListRequest) Reset() {
-	*x = GetDownloadTaskListRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_api_proto_msgTypes[8]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x *CreateShareLinkRequest) Reset() {
+	*x = CreateShareLinkRequest{}
+	mi := &file_proto_api_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDownloadTaskListRequest) String() string {
+func (x *CreateShareLinkRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDownloadTaskListRequest) ProtoMessage() {}
+func (*CreateShareLinkRequest) ProtoMessage() {}
 
-func (x *GetDownloadTaskListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_api_proto_msgTypes[8]
-	if protoimpl.UnsafeEnabled && x != nil {
+func (x *CreateShareLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[38]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -598,59 +2494,69 @@ func (x *GetDownloadTaskListRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDownloadTaskListRequest.ProtoReflect.Descriptor instead.
-func (*GetDownloadTaskListRequest) Descriptor() ([]byte, []int) {
-	return file_proto_api_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use CreateShareLinkRequest.ProtoReflect.Descriptor instead.
+func (*CreateShareLinkRequest) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *GetDownloadTaskListRequest) GetToken() string {
+func (x *CreateShareLinkRequest) GetToken() string {
 	if x != nil {
 		return x.Token
 	}
 	return ""
 }
 
-func (x *GetDownloadTaskListRequest) GetOffset() uint64 {
+func (x *CreateShareLinkRequest) GetDownloadTaskId() uint64 {
 	if x != nil {
-		return x.Offset
+		return x.DownloadTaskId
 	}
 	return 0
 }
 
-func (x *GetDownloadTaskListRequest) GetLimit() uint64 {
+func (x *CreateShareLinkRequest) GetPassword() string {
 	if x != nil {
-		return x.Limit
+		return x.Password
+	}
+	return ""
+}
+
+func (x *CreateShareLinkRequest) GetExpiresInSeconds() uint64 {
+	if x != nil {
+		return x.ExpiresInSeconds
 	}
 	return 0
 }
 
-type GetDownloadTaskListResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *CreateShareLinkRequest) GetMaxDownloadCount() uint64 {
+	if x != nil {
+		return x.MaxDownloadCount
+	}
+	return 0
+}
 
-	DownloadTaskList       []*DownloadTask `protobuf:"bytes,1,rep,name=download_task_list,json=downloadTaskList,proto3" json:"download_task_list,omitempty"`
-	TotalDownloadTaskCount uint64          `protobuf:"varint,2,opt,name=total_download_task_count,json=totalDownloadTaskCount,proto3" json:"total_download_task_count,omitempty"`
+type CreateShareLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShareLink     *ShareLink             `protobuf:"bytes,1,opt,name=share_link,json=shareLink,proto3" json:"share_link,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetDownloadTaskListResponse) Reset() {
-	*x = GetDownloadTaskListResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_api_proto_msgTypes[9]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x *CreateShareLinkResponse) Reset() {
+	*x = CreateShareLinkResponse{}
+	mi := &file_proto_api_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDownloadTaskListResponse) String() string {
+func (x *CreateShareLinkResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDownloadTaskListResponse) ProtoMessage() {}
+func (*CreateShareLinkResponse) ProtoMessage() {}
 
-func (x *GetDownloadTaskListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_api_proto_msgTypes[9]
-	if protoimpl.UnsafeEnabled && x != nil {
+func (x *CreateShareLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[39]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -660,53 +2566,42 @@ func (x *GetDownloadTaskListResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDownloadTaskListResponse.ProtoReflect.Descriptor instead.
-func (*GetDownloadTaskListResponse) Descriptor() ([]byte, []int) {
-	return file_proto_api_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use CreateShareLinkResponse.ProtoReflect.Descriptor instead.
+func (*CreateShareLinkResponse) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *GetDownloadTaskListResponse) GetDownloadTaskList() []*DownloadTask {
+func (x *CreateShareLinkResponse) GetShareLink() *ShareLink {
 	if x != nil {
-		return x.DownloadTaskList
+		return x.ShareLink
 	}
 	return nil
 }
 
-func (x *GetDownloadTaskListResponse) GetTotalDownloadTaskCount() uint64 {
-	if x != nil {
-		return x.TotalDownloadTaskCount
-	}
-	return 0
-}
-
-type UpdateDownloadTaskRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+type RevokeShareLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	ShareLinkId   uint64                 `protobuf:"varint,2,opt,name=share_link_id,json=shareLinkId,proto3" json:"share_link_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Token          string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	DownloadTaskId uint64 `protobuf:"varint,2,opt,name=download_task_id,json=downloadTaskId,proto3" json:"download_task_id,omitempty"`
-	Url            string `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateDownloadTaskRequest) Reset() {
-	*x = UpdateDownloadTaskRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_api_proto_msgTypes[10]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x *RevokeShareLinkRequest) Reset() {
+	*x = RevokeShareLinkRequest{}
+	mi := &file_proto_api_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateDownloadTaskRequest) String() string {
+func (x *RevokeShareLinkRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateDownloadTaskRequest) ProtoMessage() {}
+func (*RevokeShareLinkRequest) ProtoMessage() {}
 
-func (x *UpdateDownloadTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_api_proto_msgTypes[10]
-	if protoimpl.UnsafeEnabled && x != nil {
+func (x *RevokeShareLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[40]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -716,58 +2611,47 @@ func (x *UpdateDownloadTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateDownloadTaskRequest.ProtoReflect.Descriptor instead.
-func (*UpdateDownloadTaskRequest) Descriptor() ([]byte, []int) {
-	return file_proto_api_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use RevokeShareLinkRequest.ProtoReflect.Descriptor instead.
+func (*RevokeShareLinkRequest) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *UpdateDownloadTaskRequest) GetToken() string {
+func (x *RevokeShareLinkRequest) GetToken() string {
 	if x != nil {
 		return x.Token
 	}
 	return ""
 }
 
-func (x *UpdateDownloadTaskRequest) GetDownloadTaskId() uint64 {
+func (x *RevokeShareLinkRequest) GetShareLinkId() uint64 {
 	if x != nil {
-		return x.DownloadTaskId
+		return x.ShareLinkId
 	}
 	return 0
 }
 
-func (x *UpdateDownloadTaskRequest) GetUrl() string {
-	if x != nil {
-		return x.Url
-	}
-	return ""
-}
-
-type UpdateDownloadTaskResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+type RevokeShareLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
-
-	DownloadTask *DownloadTask `protobuf:"bytes,1,opt,name=download_task,json=downloadTask,proto3" json:"download_task,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateDownloadTaskResponse) Reset() {
-	*x = UpdateDownloadTaskResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_api_proto_msgTypes[11]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x *RevokeShareLinkResponse) Reset() {
+	*x = RevokeShareLinkResponse{}
+	mi := &file_proto_api_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateDownloadTaskResponse) String() string {
+func (x *RevokeShareLinkResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateDownloadTaskResponse) ProtoMessage() {}
+func (*RevokeShareLinkResponse) ProtoMessage() {}
 
-func (x *UpdateDownloadTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_api_proto_msgTypes[11]
-	if protoimpl.UnsafeEnabled && x != nil {
+func (x *RevokeShareLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[41]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -777,45 +2661,39 @@ func (x *UpdateDownloadTaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateDownloadTaskResponse.ProtoReflect.Descriptor instead.
-func (*UpdateDownloadTaskResponse) Descriptor() ([]byte, []int) {
-	return file_proto_api_proto_rawDescGZIP(), []int{11}
-}
-
-func (x *UpdateDownloadTaskResponse) GetDownloadTask() *DownloadTask {
-	if x != nil {
-		return x.DownloadTask
-	}
-	return nil
+// Deprecated: Use RevokeShareLinkResponse.ProtoReflect.Descriptor instead.
+func (*RevokeShareLinkResponse) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{41}
 }
 
-type DeleteDownloadTaskRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Token          string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	DownloadTaskId uint64 `protobuf:"varint,2,opt,name=download_task_id,json=downloadTaskId,proto3" json:"download_task_id,omitempty"`
+type ListAuditEventsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Token          string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	ActorAccountId uint64                 `protobuf:"varint,2,opt,name=actor_account_id,json=actorAccountId,proto3" json:"actor_account_id,omitempty"`
+	ResourceType   string                 `protobuf:"bytes,3,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"`
+	ResourceId     uint64                 `protobuf:"varint,4,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	Offset         uint64                 `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit          uint64                 `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *DeleteDownloadTaskRequest) Reset() {
-	*x = DeleteDownloadTaskRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_api_proto_msgTypes[12]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x *ListAuditEventsRequest) Reset() {
+	*x = ListAuditEventsRequest{}
+	mi := &file_proto_api_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteDownloadTaskRequest) String() string {
+func (x *ListAuditEventsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteDownloadTaskRequest) ProtoMessage() {}
+func (*ListAuditEventsRequest) ProtoMessage() {}
 
-func (x *DeleteDownloadTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_api_proto_msgTypes[12]
-	if protoimpl.UnsafeEnabled && x != nil {
+func (x *ListAuditEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[42]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -825,49 +2703,77 @@ func (x *DeleteDownloadTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteDownloadTaskRequest.ProtoReflect.Descriptor instead.
-func (*DeleteDownloadTaskRequest) Descriptor() ([]byte, []int) {
-	return file_proto_api_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use ListAuditEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *DeleteDownloadTaskRequest) GetToken() string {
+func (x *ListAuditEventsRequest) GetToken() string {
 	if x != nil {
 		return x.Token
 	}
 	return ""
 }
 
-func (x *DeleteDownloadTaskRequest) GetDownloadTaskId() uint64 {
+func (x *ListAuditEventsRequest) GetActorAccountId() uint64 {
 	if x != nil {
-		return x.DownloadTaskId
+		return x.ActorAccountId
 	}
 	return 0
 }
 
-type DeleteDownloadTaskResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *ListAuditEventsRequest) GetResourceType() string {
+	if x != nil {
+		return x.ResourceType
+	}
+	return ""
 }
 
-func (x *DeleteDownloadTaskResponse) Reset() {
-	*x = DeleteDownloadTaskResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_api_proto_msgTypes[13]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *ListAuditEventsRequest) GetResourceId() uint64 {
+	if x != nil {
+		return x.ResourceId
 	}
+	return 0
 }
 
-func (x *DeleteDownloadTaskResponse) String() string {
+func (x *ListAuditEventsRequest) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetLimit() uint64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListAuditEventsResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	AuditEventList       []*AuditEvent          `protobuf:"bytes,1,rep,name=audit_event_list,json=auditEventList,proto3" json:"audit_event_list,omitempty"`
+	TotalAuditEventCount uint64                 `protobuf:"varint,2,opt,name=total_audit_event_count,json=totalAuditEventCount,proto3" json:"total_audit_event_count,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsResponse) Reset() {
+	*x = ListAuditEventsResponse{}
+	mi := &file_proto_api_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteDownloadTaskResponse) ProtoMessage() {}
+func (*ListAuditEventsResponse) ProtoMessage() {}
 
-func (x *DeleteDownloadTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_api_proto_msgTypes[13]
-	if protoimpl.UnsafeEnabled && x != nil {
+func (x *ListAuditEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[43]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -877,38 +2783,51 @@ func (x *DeleteDownloadTaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteDownloadTaskResponse.ProtoReflect.Descriptor instead.
-func (*DeleteDownloadTaskResponse) Descriptor() ([]byte, []int) {
-	return file_proto_api_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use ListAuditEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{43}
 }
 
-type GetDownloadTaskFiletRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Token          string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	DownloadTaskId uint64 `protobuf:"varint,2,opt,name=download_task_id,json=downloadTaskId,proto3" json:"download_task_id,omitempty"`
+func (x *ListAuditEventsResponse) GetAuditEventList() []*AuditEvent {
+	if x != nil {
+		return x.AuditEventList
+	}
+	return nil
 }
 
-func (x *GetDownloadTaskFiletRequest) Reset() {
-	*x = GetDownloadTaskFiletRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_api_proto_msgTypes[14]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *ListAuditEventsResponse) GetTotalAuditEventCount() uint64 {
+	if x != nil {
+		return x.TotalAuditEventCount
 	}
+	return 0
 }
 
-func (x *GetDownloadTaskFiletRequest) String() string {
+type CheckDownloadFilesRequest struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Token         string                  `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	MismatchFix   DownloadFileMismatchFix `protobuf:"varint,2,opt,name=mismatch_fix,json=mismatchFix,proto3,enum=go_idm.v1.DownloadFileMismatchFix" json:"mismatch_fix,omitempty"`
+	DeleteOrphans bool                    `protobuf:"varint,3,opt,name=delete_orphans,json=deleteOrphans,proto3" json:"delete_orphans,omitempty"`
+	SkipChecksum  bool                    `protobuf:"varint,4,opt,name=skip_checksum,json=skipChecksum,proto3" json:"skip_checksum,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckDownloadFilesRequest) Reset() {
+	*x = CheckDownloadFilesRequest{}
+	mi := &file_proto_api_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckDownloadFilesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDownloadTaskFiletRequest) ProtoMessage() {}
+func (*CheckDownloadFilesRequest) ProtoMessage() {}
 
-func (x *GetDownloadTaskFiletRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_api_proto_msgTypes[14]
-	if protoimpl.UnsafeEnabled && x != nil {
+func (x *CheckDownloadFilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[44]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -918,51 +2837,64 @@ func (x *GetDownloadTaskFiletRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDownloadTaskFiletRequest.ProtoReflect.Descriptor instead.
-func (*GetDownloadTaskFiletRequest) Descriptor() ([]byte, []int) {
-	return file_proto_api_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use CheckDownloadFilesRequest.ProtoReflect.Descriptor instead.
+func (*CheckDownloadFilesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *GetDownloadTaskFiletRequest) GetToken() string {
+func (x *CheckDownloadFilesRequest) GetToken() string {
 	if x != nil {
 		return x.Token
 	}
 	return ""
 }
 
-func (x *GetDownloadTaskFiletRequest) GetDownloadTaskId() uint64 {
+func (x *CheckDownloadFilesRequest) GetMismatchFix() DownloadFileMismatchFix {
 	if x != nil {
-		return x.DownloadTaskId
+		return x.MismatchFix
 	}
-	return 0
+	return DownloadFileMismatchFix_UndefinedDownloadFileMismatchFix
 }
 
-type GetDownloadTaskFiletResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+func (x *CheckDownloadFilesRequest) GetDeleteOrphans() bool {
+	if x != nil {
+		return x.DeleteOrphans
+	}
+	return false
 }
 
-func (x *GetDownloadTaskFiletResponse) Reset() {
-	*x = GetDownloadTaskFiletResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_api_proto_msgTypes[15]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *CheckDownloadFilesRequest) GetSkipChecksum() bool {
+	if x != nil {
+		return x.SkipChecksum
 	}
+	return false
 }
 
-func (x *GetDownloadTaskFiletResponse) String() string {
+type CheckDownloadFilesResponse struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	CheckedDownloadTaskCount uint64                 `protobuf:"varint,1,opt,name=checked_download_task_count,json=checkedDownloadTaskCount,proto3" json:"checked_download_task_count,omitempty"`
+	CheckedFileCount         uint64                 `protobuf:"varint,2,opt,name=checked_file_count,json=checkedFileCount,proto3" json:"checked_file_count,omitempty"`
+	DownloadFileIssueList    []*DownloadFileIssue   `protobuf:"bytes,3,rep,name=download_file_issue_list,json=downloadFileIssueList,proto3" json:"download_file_issue_list,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *CheckDownloadFilesResponse) Reset() {
+	*x = CheckDownloadFilesResponse{}
+	mi := &file_proto_api_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckDownloadFilesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDownloadTaskFiletResponse) ProtoMessage() {}
+func (*CheckDownloadFilesResponse) ProtoMessage() {}
 
-func (x *GetDownloadTaskFiletResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_api_proto_msgTypes[15]
-	if protoimpl.UnsafeEnabled && x != nil {
+func (x *CheckDownloadFilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_api_proto_msgTypes[45]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -972,233 +2904,407 @@ func (x *GetDownloadTaskFiletResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDownloadTaskFiletResponse.ProtoReflect.Descriptor instead.
-func (*GetDownloadTaskFiletResponse) Descriptor() ([]byte, []int) {
-	return file_proto_api_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use CheckDownloadFilesResponse.ProtoReflect.Descriptor instead.
+func (*CheckDownloadFilesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_api_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *GetDownloadTaskFiletResponse) GetData() []byte {
+func (x *CheckDownloadFilesResponse) GetCheckedDownloadTaskCount() uint64 {
 	if x != nil {
-		return x.Data
+		return x.CheckedDownloadTaskCount
+	}
+	return 0
+}
+
+func (x *CheckDownloadFilesResponse) GetCheckedFileCount() uint64 {
+	if x != nil {
+		return x.CheckedFileCount
+	}
+	return 0
+}
+
+func (x *CheckDownloadFilesResponse) GetDownloadFileIssueList() []*DownloadFileIssue {
+	if x != nil {
+		return x.DownloadFileIssueList
 	}
 	return nil
 }
 
 var File_proto_api_proto protoreflect.FileDescriptor
 
-var file_proto_api_proto_rawDesc = []byte{
-	0x0a, 0x0f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x12, 0x09, 0x67, 0x6f, 0x5f, 0x69, 0x64, 0x6d, 0x2e, 0x76, 0x31, 0x22, 0x3c, 0x0a, 0x07,
-	0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x63, 0x63, 0x6f, 0x75,
-	0x6e, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61,
-	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0xb2, 0x01, 0x0a, 0x0c, 0x44,
-	0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x3c, 0x0a, 0x0d, 0x64,
-	0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x5f, 0x69, 0x64, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x44,
-	0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0c, 0x64, 0x6f, 0x77,
-	0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x42, 0x0a, 0x0f, 0x64,
-	0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x5f, 0x69, 0x64, 0x6d, 0x2e, 0x76, 0x31,
-	0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
-	0x0e, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22,
-	0x55, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x63, 0x63, 0x6f, 0x75,
-	0x6e, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61,
-	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61,
-	0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61,
-	0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x22, 0x36, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x22, 0x55,
-	0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e,
-	0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x63,
-	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73,
-	0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73,
-	0x73, 0x77, 0x6f, 0x72, 0x64, 0x22, 0x2d, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14,
-	0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74,
-	0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x81, 0x01, 0x0a, 0x19, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44,
-	0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x3c, 0x0a, 0x0d, 0x64, 0x6f, 0x77, 0x6e,
-	0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x17, 0x2e, 0x67, 0x6f, 0x5f, 0x69, 0x64, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f, 0x77, 0x6e,
-	0x6c, 0x6f, 0x61, 0x64, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0c, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
-	0x61, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x22, 0x5a, 0x0a, 0x1a, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0d, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
-	0x61, 0x64, 0x5f, 0x74, 0x61, 0x73, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
-	0x67, 0x6f, 0x5f, 0x69, 0x64, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
-	0x61, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x0c, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64,
-	0x54, 0x61, 0x73, 0x6b, 0x22, 0x60, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x44, 0x6f, 0x77, 0x6e, 0x6c,
-	0x6f, 0x61, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73,
-	0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74,
-	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x9f, 0x01, 0x0a, 0x1b, 0x47, 0x65, 0x74, 0x44, 0x6f,
-	0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x45, 0x0a, 0x12, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
-	0x61, 0x64, 0x5f, 0x74, 0x61, 0x73, 0x6b, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x5f, 0x69, 0x64, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x44,
-	0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x10, 0x64, 0x6f, 0x77,
-	0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x39, 0x0a,
-	0x19, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x5f,
-	0x74, 0x61, 0x73, 0x6b, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x16, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x54,
-	0x61, 0x73, 0x6b, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x6d, 0x0a, 0x19, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x28, 0x0a, 0x10, 0x64,
-	0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x74, 0x61, 0x73, 0x6b, 0x5f, 0x69, 0x64, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x54,
-	0x61, 0x73, 0x6b, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x22, 0x5a, 0x0a, 0x1a, 0x55, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0d, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61,
-	0x64, 0x5f, 0x74, 0x61, 0x73, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67,
-	0x6f, 0x5f, 0x69, 0x64, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61,
-	0x64, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x0c, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x54,
-	0x61, 0x73, 0x6b, 0x22, 0x5b, 0x0a, 0x19, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x6f, 0x77,
-	0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x28, 0x0a, 0x10, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
-	0x61, 0x64, 0x5f, 0x74, 0x61, 0x73, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x0e, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x49, 0x64,
-	0x22, 0x1c, 0x0a, 0x1a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
-	0x61, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x5d,
-	0x0a, 0x1b, 0x47, 0x65, 0x74, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x61, 0x73,
-	0x6b, 0x46, 0x69, 0x6c, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
-	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f,
-	0x6b, 0x65, 0x6e, 0x12, 0x28, 0x0a, 0x10, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x5f,
-	0x74, 0x61, 0x73, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x64,
-	0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x49, 0x64, 0x22, 0x32, 0x0a,
-	0x1c, 0x47, 0x65, 0x74, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x61, 0x73, 0x6b,
-	0x46, 0x69, 0x6c, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a,
-	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74,
-	0x61, 0x2a, 0x33, 0x0a, 0x0c, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x19, 0x0a, 0x15, 0x55, 0x6e, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x65, 0x64, 0x44, 0x6f,
-	0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x79, 0x70, 0x65, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04,
-	0x48, 0x54, 0x54, 0x50, 0x10, 0x01, 0x2a, 0x66, 0x0a, 0x0e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
-	0x61, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1b, 0x0a, 0x17, 0x55, 0x6e, 0x64, 0x65,
-	0x66, 0x69, 0x6e, 0x65, 0x64, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67,
-	0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x69, 0x6e,
-	0x67, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0x03, 0x12,
-	0x0d, 0x0a, 0x09, 0x53, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x10, 0x04, 0x32, 0xbd,
-	0x05, 0x0a, 0x0c, 0x47, 0x6f, 0x49, 0x44, 0x4d, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
-	0x54, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
-	0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x5f, 0x69, 0x64, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x20, 0x2e, 0x67, 0x6f, 0x5f, 0x69, 0x64, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x5f, 0x69, 0x64, 0x6d, 0x2e,
-	0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x67, 0x6f, 0x5f, 0x69, 0x64, 0x6d,
-	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
-	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x63, 0x0a, 0x12, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x61, 0x73,
-	0x6b, 0x12, 0x24, 0x2e, 0x67, 0x6f, 0x5f, 0x69, 0x64, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x61, 0x73, 0x6b,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x67, 0x6f, 0x5f, 0x69, 0x64, 0x6d,
-	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
-	0x61, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x12, 0x66, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x54,
-	0x61, 0x73, 0x6b, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x25, 0x2e, 0x67, 0x6f, 0x5f, 0x69, 0x64, 0x6d,
-	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x54,
-	0x61, 0x73, 0x6b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26,
-	0x2e, 0x67, 0x6f, 0x5f, 0x69, 0x64, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x6f,
-	0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x63, 0x0a, 0x12, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x12, 0x24,
-	0x2e, 0x67, 0x6f, 0x5f, 0x69, 0x64, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x67, 0x6f, 0x5f, 0x69, 0x64, 0x6d, 0x2e, 0x76, 0x31,
-	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x54,
-	0x61, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x63, 0x0a,
-	0x12, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x54,
-	0x61, 0x73, 0x6b, 0x12, 0x24, 0x2e, 0x67, 0x6f, 0x5f, 0x69, 0x64, 0x6d, 0x2e, 0x76, 0x31, 0x2e,
-	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x61,
-	0x73, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x67, 0x6f, 0x5f, 0x69,
-	0x64, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x6f, 0x77, 0x6e,
-	0x6c, 0x6f, 0x61, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x12, 0x6a, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61,
-	0x64, 0x54, 0x61, 0x73, 0x6b, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x26, 0x2e, 0x67, 0x6f, 0x5f, 0x69,
-	0x64, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61,
-	0x64, 0x54, 0x61, 0x73, 0x6b, 0x46, 0x69, 0x6c, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x27, 0x2e, 0x67, 0x6f, 0x5f, 0x69, 0x64, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
-	0x74, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x46, 0x69, 0x6c,
-	0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x42, 0x13,
-	0x5a, 0x11, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x67, 0x6f, 0x5f, 0x69, 0x64, 0x6d, 0x5f, 0x76, 0x31,
-	0x70, 0x72, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-}
+const file_proto_api_proto_rawDesc = "" +
+	"\n" +
+	"\x0fproto/api.proto\x12\tgo_idm.v1\"<\n" +
+	"\aAccount\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12!\n" +
+	"\faccount_name\x18\x02 \x01(\tR\vaccountName\"\x97\x02\n" +
+	"\fDownloadTask\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12<\n" +
+	"\rdownload_type\x18\x02 \x01(\x0e2\x17.go_idm.v1.DownloadTypeR\fdownloadType\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12B\n" +
+	"\x0fdownload_status\x18\x04 \x01(\x0e2\x19.go_idm.v1.DownloadStatusR\x0edownloadStatus\x12\"\n" +
+	"\rof_account_id\x18\x05 \x01(\x04R\vofAccountId\x12\x1c\n" +
+	"\n" +
+	"of_team_id\x18\x06 \x01(\x04R\bofTeamId\x12!\n" +
+	"\fdeleted_time\x18\a \x01(\x04R\vdeletedTime\"e\n" +
+	"\x04Team\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1b\n" +
+	"\tteam_name\x18\x02 \x01(\tR\bteamName\x120\n" +
+	"\tteam_role\x18\x03 \x01(\x0e2\x13.go_idm.v1.TeamRoleR\bteamRole\"\x9b\x02\n" +
+	"\tShareLink\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12(\n" +
+	"\x10download_task_id\x18\x02 \x01(\x04R\x0edownloadTaskId\x12\x1d\n" +
+	"\n" +
+	"link_token\x18\x03 \x01(\tR\tlinkToken\x12\x10\n" +
+	"\x03url\x18\x04 \x01(\tR\x03url\x12-\n" +
+	"\x12password_protected\x18\x05 \x01(\bR\x11passwordProtected\x12\x1f\n" +
+	"\vexpire_time\x18\x06 \x01(\x04R\n" +
+	"expireTime\x12,\n" +
+	"\x12max_download_count\x18\a \x01(\x04R\x10maxDownloadCount\x12%\n" +
+	"\x0edownload_count\x18\b \x01(\x04R\rdownloadCount\"\xc9\x02\n" +
+	"\n" +
+	"AuditEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12(\n" +
+	"\x10actor_account_id\x18\x02 \x01(\x04R\x0eactorAccountId\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12#\n" +
+	"\rresource_type\x18\x04 \x01(\tR\fresourceType\x12\x1f\n" +
+	"\vresource_id\x18\x05 \x01(\x04R\n" +
+	"resourceId\x12!\n" +
+	"\fbefore_value\x18\x06 \x01(\tR\vbeforeValue\x12\x1f\n" +
+	"\vafter_value\x18\a \x01(\tR\n" +
+	"afterValue\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\b \x01(\tR\tipAddress\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\t \x01(\tR\trequestId\x12!\n" +
+	"\fcreated_time\x18\n" +
+	" \x01(\x04R\vcreatedTime\"\xd4\x02\n" +
+	"\x11DownloadFileIssue\x124\n" +
+	"\x04kind\x18\x01 \x01(\x0e2 .go_idm.v1.DownloadFileIssueKindR\x04kind\x12(\n" +
+	"\x10download_task_id\x18\x02 \x01(\x04R\x0edownloadTaskId\x12\x1b\n" +
+	"\tfile_name\x18\x03 \x01(\tR\bfileName\x12,\n" +
+	"\x12expected_file_size\x18\x04 \x01(\x04R\x10expectedFileSize\x12(\n" +
+	"\x10actual_file_size\x18\x05 \x01(\x04R\x0eactualFileSize\x12+\n" +
+	"\x11expected_checksum\x18\x06 \x01(\tR\x10expectedChecksum\x12'\n" +
+	"\x0factual_checksum\x18\a \x01(\tR\x0eactualChecksum\x12\x14\n" +
+	"\x05fixed\x18\b \x01(\bR\x05fixed\"U\n" +
+	"\x14CreateAccountRequest\x12!\n" +
+	"\faccount_name\x18\x01 \x01(\tR\vaccountName\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"6\n" +
+	"\x15CreateAccountResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\"U\n" +
+	"\x14CreateSessionRequest\x12!\n" +
+	"\faccount_name\x18\x01 \x01(\tR\vaccountName\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"-\n" +
+	"\x15CreateSessionResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\x9a\x01\n" +
+	"\x19CreateDownloadTaskRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12<\n" +
+	"\rdownload_type\x18\x02 \x01(\x0e2\x17.go_idm.v1.DownloadTypeR\fdownloadType\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12\x17\n" +
+	"\ateam_id\x18\x04 \x01(\x04R\x06teamId\"Z\n" +
+	"\x1aCreateDownloadTaskResponse\x12<\n" +
+	"\rdownload_task\x18\x01 \x01(\v2\x17.go_idm.v1.DownloadTaskR\fdownloadTask\"`\n" +
+	"\x1aGetDownloadTaskListRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x04R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x04R\x05limit\"\x9f\x01\n" +
+	"\x1bGetDownloadTaskListResponse\x12E\n" +
+	"\x12download_task_list\x18\x01 \x03(\v2\x17.go_idm.v1.DownloadTaskR\x10downloadTaskList\x129\n" +
+	"\x19total_download_task_count\x18\x02 \x01(\x04R\x16totalDownloadTaskCount\"m\n" +
+	"\x19UpdateDownloadTaskRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12(\n" +
+	"\x10download_task_id\x18\x02 \x01(\x04R\x0edownloadTaskId\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\"Z\n" +
+	"\x1aUpdateDownloadTaskResponse\x12<\n" +
+	"\rdownload_task\x18\x01 \x01(\v2\x17.go_idm.v1.DownloadTaskR\fdownloadTask\"[\n" +
+	"\x19DeleteDownloadTaskRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12(\n" +
+	"\x10download_task_id\x18\x02 \x01(\x04R\x0edownloadTaskId\"\x1c\n" +
+	"\x1aDeleteDownloadTaskResponse\"\\\n" +
+	"\x1aRestoreDownloadTaskRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12(\n" +
+	"\x10download_task_id\x18\x02 \x01(\x04R\x0edownloadTaskId\"[\n" +
+	"\x1bRestoreDownloadTaskResponse\x12<\n" +
+	"\rdownload_task\x18\x01 \x01(\v2\x17.go_idm.v1.DownloadTaskR\fdownloadTask\"V\n" +
+	"\x10ListTrashRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x04R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x04R\x05limit\"\x95\x01\n" +
+	"\x11ListTrashResponse\x12E\n" +
+	"\x12download_task_list\x18\x01 \x03(\v2\x17.go_idm.v1.DownloadTaskR\x10downloadTaskList\x129\n" +
+	"\x19total_download_task_count\x18\x02 \x01(\x04R\x16totalDownloadTaskCount\"\x8d\x01\n" +
+	"\x1bGetDownloadTaskFiletRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12(\n" +
+	"\x10download_task_id\x18\x02 \x01(\x04R\x0edownloadTaskId\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x04R\x06offset\x12\x16\n" +
+	"\x06length\x18\x04 \x01(\x04R\x06length\"2\n" +
+	"\x1cGetDownloadTaskFiletResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"X\n" +
+	"\x16GetDownloadTaskRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12(\n" +
+	"\x10download_task_id\x18\x02 \x01(\x04R\x0edownloadTaskId\"W\n" +
+	"\x17GetDownloadTaskResponse\x12<\n" +
+	"\rdownload_task\x18\x01 \x01(\v2\x17.go_idm.v1.DownloadTaskR\fdownloadTask\"\xbd\x01\n" +
+	"\x18ShareDownloadTaskRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12(\n" +
+	"\x10download_task_id\x18\x02 \x01(\x04R\x0edownloadTaskId\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x03 \x01(\x04R\taccountId\x12B\n" +
+	"\vshare_level\x18\x04 \x01(\x0e2!.go_idm.v1.DownloadTaskShareLevelR\n" +
+	"shareLevel\"\x1b\n" +
+	"\x19ShareDownloadTaskResponse\"{\n" +
+	"\x1aUnshareDownloadTaskRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12(\n" +
+	"\x10download_task_id\x18\x02 \x01(\x04R\x0edownloadTaskId\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x03 \x01(\x04R\taccountId\"\x1d\n" +
+	"\x1bUnshareDownloadTaskResponse\"F\n" +
+	"\x11CreateTeamRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1b\n" +
+	"\tteam_name\x18\x02 \x01(\tR\bteamName\"9\n" +
+	"\x12CreateTeamResponse\x12#\n" +
+	"\x04team\x18\x01 \x01(\v2\x0f.go_idm.v1.TeamR\x04team\"*\n" +
+	"\x12GetTeamListRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"C\n" +
+	"\x13GetTeamListResponse\x12,\n" +
+	"\tteam_list\x18\x01 \x03(\v2\x0f.go_idm.v1.TeamR\bteamList\"\x96\x01\n" +
+	"\x14AddTeamMemberRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x17\n" +
+	"\ateam_id\x18\x02 \x01(\x04R\x06teamId\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x03 \x01(\x04R\taccountId\x120\n" +
+	"\tteam_role\x18\x04 \x01(\x0e2\x13.go_idm.v1.TeamRoleR\bteamRole\"\x17\n" +
+	"\x15AddTeamMemberResponse\"g\n" +
+	"\x17RemoveTeamMemberRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x17\n" +
+	"\ateam_id\x18\x02 \x01(\x04R\x06teamId\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x03 \x01(\x04R\taccountId\"\x1a\n" +
+	"\x18RemoveTeamMemberResponse\"\xd0\x01\n" +
+	"\x16CreateShareLinkRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12(\n" +
+	"\x10download_task_id\x18\x02 \x01(\x04R\x0edownloadTaskId\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\x12,\n" +
+	"\x12expires_in_seconds\x18\x04 \x01(\x04R\x10expiresInSeconds\x12,\n" +
+	"\x12max_download_count\x18\x05 \x01(\x04R\x10maxDownloadCount\"N\n" +
+	"\x17CreateShareLinkResponse\x123\n" +
+	"\n" +
+	"share_link\x18\x01 \x01(\v2\x14.go_idm.v1.ShareLinkR\tshareLink\"R\n" +
+	"\x16RevokeShareLinkRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\"\n" +
+	"\rshare_link_id\x18\x02 \x01(\x04R\vshareLinkId\"\x19\n" +
+	"\x17RevokeShareLinkResponse\"\xcc\x01\n" +
+	"\x16ListAuditEventsRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12(\n" +
+	"\x10actor_account_id\x18\x02 \x01(\x04R\x0eactorAccountId\x12#\n" +
+	"\rresource_type\x18\x03 \x01(\tR\fresourceType\x12\x1f\n" +
+	"\vresource_id\x18\x04 \x01(\x04R\n" +
+	"resourceId\x12\x16\n" +
+	"\x06offset\x18\x05 \x01(\x04R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x06 \x01(\x04R\x05limit\"\x91\x01\n" +
+	"\x17ListAuditEventsResponse\x12?\n" +
+	"\x10audit_event_list\x18\x01 \x03(\v2\x15.go_idm.v1.AuditEventR\x0eauditEventList\x125\n" +
+	"\x17total_audit_event_count\x18\x02 \x01(\x04R\x14totalAuditEventCount\"\xc4\x01\n" +
+	"\x19CheckDownloadFilesRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12E\n" +
+	"\fmismatch_fix\x18\x02 \x01(\x0e2\".go_idm.v1.DownloadFileMismatchFixR\vmismatchFix\x12%\n" +
+	"\x0edelete_orphans\x18\x03 \x01(\bR\rdeleteOrphans\x12#\n" +
+	"\rskip_checksum\x18\x04 \x01(\bR\fskipChecksum\"\xe0\x01\n" +
+	"\x1aCheckDownloadFilesResponse\x12=\n" +
+	"\x1bchecked_download_task_count\x18\x01 \x01(\x04R\x18checkedDownloadTaskCount\x12,\n" +
+	"\x12checked_file_count\x18\x02 \x01(\x04R\x10checkedFileCount\x12U\n" +
+	"\x18download_file_issue_list\x18\x03 \x03(\v2\x1c.go_idm.v1.DownloadFileIssueR\x15downloadFileIssueList*3\n" +
+	"\fDownloadType\x12\x19\n" +
+	"\x15UndefinedDownloadType\x10\x00\x12\b\n" +
+	"\x04HTTP\x10\x01*f\n" +
+	"\x0eDownloadStatus\x12\x1b\n" +
+	"\x17UndefinedDownloadStatus\x10\x00\x12\v\n" +
+	"\aPending\x10\x01\x12\x0f\n" +
+	"\vDownloading\x10\x02\x12\n" +
+	"\n" +
+	"\x06Failed\x10\x03\x12\r\n" +
+	"\tSucceeded\x10\x04*C\n" +
+	"\bTeamRole\x12\x15\n" +
+	"\x11UndefinedTeamRole\x10\x00\x12\t\n" +
+	"\x05Owner\x10\x01\x12\t\n" +
+	"\x05Admin\x10\x02\x12\n" +
+	"\n" +
+	"\x06Member\x10\x03*S\n" +
+	"\x16DownloadTaskShareLevel\x12#\n" +
+	"\x1fUndefinedDownloadTaskShareLevel\x10\x00\x12\b\n" +
+	"\x04Read\x10\x01\x12\n" +
+	"\n" +
+	"\x06Manage\x10\x02*\xa7\x01\n" +
+	"\x15DownloadFileIssueKind\x12\"\n" +
+	"\x1eUndefinedDownloadFileIssueKind\x10\x00\x12\x0f\n" +
+	"\vFileMissing\x10\x01\x12\x14\n" +
+	"\x10FileSizeMismatch\x10\x02\x12\x18\n" +
+	"\x14FileChecksumMismatch\x10\x03\x12\x17\n" +
+	"\x13FileMetadataInvalid\x10\x04\x12\x10\n" +
+	"\fFileOrphaned\x10\x05*^\n" +
+	"\x17DownloadFileMismatchFix\x12$\n" +
+	" UndefinedDownloadFileMismatchFix\x10\x00\x12\x0e\n" +
+	"\n" +
+	"MarkFailed\x10\x01\x12\r\n" +
+	"\tReenqueue\x10\x022\xe0\x0e\n" +
+	"\fGoIDMService\x12T\n" +
+	"\rCreateAccount\x12\x1f.go_idm.v1.CreateAccountRequest\x1a .go_idm.v1.CreateAccountResponse\"\x00\x12T\n" +
+	"\rCreateSession\x12\x1f.go_idm.v1.CreateSessionRequest\x1a .go_idm.v1.CreateSessionResponse\"\x00\x12c\n" +
+	"\x12CreateDownloadTask\x12$.go_idm.v1.CreateDownloadTaskRequest\x1a%.go_idm.v1.CreateDownloadTaskResponse\"\x00\x12f\n" +
+	"\x13GetDownloadTaskList\x12%.go_idm.v1.GetDownloadTaskListRequest\x1a&.go_idm.v1.GetDownloadTaskListResponse\"\x00\x12c\n" +
+	"\x12UpdateDownloadTask\x12$.go_idm.v1.UpdateDownloadTaskRequest\x1a%.go_idm.v1.UpdateDownloadTaskResponse\"\x00\x12c\n" +
+	"\x12DeleteDownloadTask\x12$.go_idm.v1.DeleteDownloadTaskRequest\x1a%.go_idm.v1.DeleteDownloadTaskResponse\"\x00\x12f\n" +
+	"\x13RestoreDownloadTask\x12%.go_idm.v1.RestoreDownloadTaskRequest\x1a&.go_idm.v1.RestoreDownloadTaskResponse\"\x00\x12H\n" +
+	"\tListTrash\x12\x1b.go_idm.v1.ListTrashRequest\x1a\x1c.go_idm.v1.ListTrashResponse\"\x00\x12j\n" +
+	"\x13GetDownloadTaskFile\x12&.go_idm.v1.GetDownloadTaskFiletRequest\x1a'.go_idm.v1.GetDownloadTaskFiletResponse\"\x000\x01\x12Z\n" +
+	"\x0fGetDownloadTask\x12!.go_idm.v1.GetDownloadTaskRequest\x1a\".go_idm.v1.GetDownloadTaskResponse\"\x00\x12`\n" +
+	"\x11ShareDownloadTask\x12#.go_idm.v1.ShareDownloadTaskRequest\x1a$.go_idm.v1.ShareDownloadTaskResponse\"\x00\x12f\n" +
+	"\x13UnshareDownloadTask\x12%.go_idm.v1.UnshareDownloadTaskRequest\x1a&.go_idm.v1.UnshareDownloadTaskResponse\"\x00\x12K\n" +
+	"\n" +
+	"CreateTeam\x12\x1c.go_idm.v1.CreateTeamRequest\x1a\x1d.go_idm.v1.CreateTeamResponse\"\x00\x12N\n" +
+	"\vGetTeamList\x12\x1d.go_idm.v1.GetTeamListRequest\x1a\x1e.go_idm.v1.GetTeamListResponse\"\x00\x12T\n" +
+	"\rAddTeamMember\x12\x1f.go_idm.v1.AddTeamMemberRequest\x1a .go_idm.v1.AddTeamMemberResponse\"\x00\x12]\n" +
+	"\x10RemoveTeamMember\x12\".go_idm.v1.RemoveTeamMemberRequest\x1a#.go_idm.v1.RemoveTeamMemberResponse\"\x00\x12Z\n" +
+	"\x0fCreateShareLink\x12!.go_idm.v1.CreateShareLinkRequest\x1a\".go_idm.v1.CreateShareLinkResponse\"\x00\x12Z\n" +
+	"\x0fRevokeShareLink\x12!.go_idm.v1.RevokeShareLinkRequest\x1a\".go_idm.v1.RevokeShareLinkResponse\"\x00\x12Z\n" +
+	"\x0fListAuditEvents\x12!.go_idm.v1.ListAuditEventsRequest\x1a\".go_idm.v1.ListAuditEventsResponse\"\x00\x12c\n" +
+	"\x12CheckDownloadFiles\x12$.go_idm.v1.CheckDownloadFilesRequest\x1a%.go_idm.v1.CheckDownloadFilesResponse\"\x00B\x13Z\x11grpc/go_idm_v1prob\x06proto3"
 
 var (
 	file_proto_api_proto_rawDescOnce sync.Once
-	file_proto_api_proto_rawDescData = file_proto_api_proto_rawDesc
+	file_proto_api_proto_rawDescData []byte
 )
 
 func file_proto_api_proto_rawDescGZIP() []byte {
 	file_proto_api_proto_rawDescOnce.Do(func() {
-		file_proto_api_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_api_proto_rawDescData)
+		file_proto_api_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_api_proto_rawDesc), len(file_proto_api_proto_rawDesc)))
 	})
 	return file_proto_api_proto_rawDescData
 }
 
-var file_proto_api_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_proto_api_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
-var file_proto_api_proto_goTypes = []interface{}{
+var file_proto_api_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_proto_api_proto_msgTypes = make([]protoimpl.MessageInfo, 46)
+var file_proto_api_proto_goTypes = []any{
 	(DownloadType)(0),                    // 0: go_idm.v1.DownloadType
 	(DownloadStatus)(0),                  // 1: go_idm.v1.DownloadStatus
-	(*Account)(nil),                      // 2: go_idm.v1.Account
-	(*DownloadTask)(nil),                 // 3: go_idm.v1.DownloadTask
-	(*CreateAccountRequest)(nil),         // 4: go_idm.v1.CreateAccountRequest
-	(*CreateAccountResponse)(nil),        // 5: go_idm.v1.CreateAccountResponse
-	(*CreateSessionRequest)(nil),         // 6: go_idm.v1.CreateSessionRequest
-	(*CreateSessionResponse)(nil),        // 7: go_idm.v1.CreateSessionResponse
-	(*CreateDownloadTaskRequest)(nil),    // 8: go_idm.v1.CreateDownloadTaskRequest
-	(*CreateDownloadTaskResponse)(nil),   // 9: go_idm.v1.CreateDownloadTaskResponse
-	(*GetDownloadTaskListRequest)(nil),   // 10: go_idm.v1.GetDownloadTaskListRequest
-	(*GetDownloadTaskListResponse)(nil),  // 11: go_idm.v1.GetDownloadTaskListResponse
-	(*UpdateDownloadTaskRequest)(nil),    // 12: go_idm.v1.UpdateDownloadTaskRequest
-	(*UpdateDownloadTaskResponse)(nil),   // 13: go_idm.v1.UpdateDownloadTaskResponse
-	(*DeleteDownloadTaskRequest)(nil),    // 14: go_idm.v1.DeleteDownloadTaskRequest
-	(*DeleteDownloadTaskResponse)(nil),   // 15: go_idm.v1.DeleteDownloadTaskResponse
-	(*GetDownloadTaskFiletRequest)(nil),  // 16: go_idm.v1.GetDownloadTaskFiletRequest
-	(*GetDownloadTaskFiletResponse)(nil), // 17: go_idm.v1.GetDownloadTaskFiletResponse
+	(TeamRole)(0),                        // 2: go_idm.v1.TeamRole
+	(DownloadTaskShareLevel)(0),          // 3: go_idm.v1.DownloadTaskShareLevel
+	(DownloadFileIssueKind)(0),           // 4: go_idm.v1.DownloadFileIssueKind
+	(DownloadFileMismatchFix)(0),         // 5: go_idm.v1.DownloadFileMismatchFix
+	(*Account)(nil),                      // 6: go_idm.v1.Account
+	(*DownloadTask)(nil),                 // 7: go_idm.v1.DownloadTask
+	(*Team)(nil),                         // 8: go_idm.v1.Team
+	(*ShareLink)(nil),                    // 9: go_idm.v1.ShareLink
+	(*AuditEvent)(nil),                   // 10: go_idm.v1.AuditEvent
+	(*DownloadFileIssue)(nil),            // 11: go_idm.v1.DownloadFileIssue
+	(*CreateAccountRequest)(nil),         // 12: go_idm.v1.CreateAccountRequest
+	(*CreateAccountResponse)(nil),        // 13: go_idm.v1.CreateAccountResponse
+	(*CreateSessionRequest)(nil),         // 14: go_idm.v1.CreateSessionRequest
+	(*CreateSessionResponse)(nil),        // 15: go_idm.v1.CreateSessionResponse
+	(*CreateDownloadTaskRequest)(nil),    // 16: go_idm.v1.CreateDownloadTaskRequest
+	(*CreateDownloadTaskResponse)(nil),   // 17: go_idm.v1.CreateDownloadTaskResponse
+	(*GetDownloadTaskListRequest)(nil),   // 18: go_idm.v1.GetDownloadTaskListRequest
+	(*GetDownloadTaskListResponse)(nil),  // 19: go_idm.v1.GetDownloadTaskListResponse
+	(*UpdateDownloadTaskRequest)(nil),    // 20: go_idm.v1.UpdateDownloadTaskRequest
+	(*UpdateDownloadTaskResponse)(nil),   // 21: go_idm.v1.UpdateDownloadTaskResponse
+	(*DeleteDownloadTaskRequest)(nil),    // 22: go_idm.v1.DeleteDownloadTaskRequest
+	(*DeleteDownloadTaskResponse)(nil),   // 23: go_idm.v1.DeleteDownloadTaskResponse
+	(*RestoreDownloadTaskRequest)(nil),   // 24: go_idm.v1.RestoreDownloadTaskRequest
+	(*RestoreDownloadTaskResponse)(nil),  // 25: go_idm.v1.RestoreDownloadTaskResponse
+	(*ListTrashRequest)(nil),             // 26: go_idm.v1.ListTrashRequest
+	(*ListTrashResponse)(nil),            // 27: go_idm.v1.ListTrashResponse
+	(*GetDownloadTaskFiletRequest)(nil),  // 28: go_idm.v1.GetDownloadTaskFiletRequest
+	(*GetDownloadTaskFiletResponse)(nil), // 29: go_idm.v1.GetDownloadTaskFiletResponse
+	(*GetDownloadTaskRequest)(nil),       // 30: go_idm.v1.GetDownloadTaskRequest
+	(*GetDownloadTaskResponse)(nil),      // 31: go_idm.v1.GetDownloadTaskResponse
+	(*ShareDownloadTaskRequest)(nil),     // 32: go_idm.v1.ShareDownloadTaskRequest
+	(*ShareDownloadTaskResponse)(nil),    // 33: go_idm.v1.ShareDownloadTaskResponse
+	(*UnshareDownloadTaskRequest)(nil),   // 34: go_idm.v1.UnshareDownloadTaskRequest
+	(*UnshareDownloadTaskResponse)(nil),  // 35: go_idm.v1.UnshareDownloadTaskResponse
+	(*CreateTeamRequest)(nil),            // 36: go_idm.v1.CreateTeamRequest
+	(*CreateTeamResponse)(nil),           // 37: go_idm.v1.CreateTeamResponse
+	(*GetTeamListRequest)(nil),           // 38: go_idm.v1.GetTeamListRequest
+	(*GetTeamListResponse)(nil),          // 39: go_idm.v1.GetTeamListResponse
+	(*AddTeamMemberRequest)(nil),         // 40: go_idm.v1.AddTeamMemberRequest
+	(*AddTeamMemberResponse)(nil),        // 41: go_idm.v1.AddTeamMemberResponse
+	(*RemoveTeamMemberRequest)(nil),      // 42: go_idm.v1.RemoveTeamMemberRequest
+	(*RemoveTeamMemberResponse)(nil),     // 43: go_idm.v1.RemoveTeamMemberResponse
+	(*CreateShareLinkRequest)(nil),       // 44: go_idm.v1.CreateShareLinkRequest
+	(*CreateShareLinkResponse)(nil),      // 45: go_idm.v1.CreateShareLinkResponse
+	(*RevokeShareLinkRequest)(nil),       // 46: go_idm.v1.RevokeShareLinkRequest
+	(*RevokeShareLinkResponse)(nil),      // 47: go_idm.v1.RevokeShareLinkResponse
+	(*ListAuditEventsRequest)(nil),       // 48: go_idm.v1.ListAuditEventsRequest
+	(*ListAuditEventsResponse)(nil),      // 49: go_idm.v1.ListAuditEventsResponse
+	(*CheckDownloadFilesRequest)(nil),    // 50: go_idm.v1.CheckDownloadFilesRequest
+	(*CheckDownloadFilesResponse)(nil),   // 51: go_idm.v1.CheckDownloadFilesResponse
 }
 var file_proto_api_proto_depIdxs = []int32{
 	0,  // 0: go_idm.v1.DownloadTask.download_type:type_name -> go_idm.v1.DownloadType
 	1,  // 1: go_idm.v1.DownloadTask.download_status:type_name -> go_idm.v1.DownloadStatus
-	0,  // 2: go_idm.v1.CreateDownloadTaskRequest.download_type:type_name -> go_idm.v1.DownloadType
-	3,  // 3: go_idm.v1.CreateDownloadTaskResponse.download_task:type_name -> go_idm.v1.DownloadTask
-	3,  // 4: go_idm.v1.GetDownloadTaskListResponse.download_task_list:type_name -> go_idm.v1.DownloadTask
-	3,  // 5: go_idm.v1.UpdateDownloadTaskResponse.download_task:type_name -> go_idm.v1.DownloadTask
-	4,  // 6: go_idm.v1.GoIDMService.CreateAccount:input_type -> go_idm.v1.CreateAccountRequest
-	6,  // 7: go_idm.v1.GoIDMService.CreateSession:input_type -> go_idm.v1.CreateSessionRequest
-	8,  // 8: go_idm.v1.GoIDMService.CreateDownloadTask:input_type -> go_idm.v1.CreateDownloadTaskRequest
-	10, // 9: go_idm.v1.GoIDMService.GetDownloadTaskList:input_type -> go_idm.v1.GetDownloadTaskListRequest
-	12, // 10: go_idm.v1.GoIDMService.UpdateDownloadTask:input_type -> go_idm.v1.UpdateDownloadTaskRequest
-	14, // 11: go_idm.v1.GoIDMService.DeleteDownloadTask:input_type -> go_idm.v1.DeleteDownloadTaskRequest
-	16, // 12: go_idm.v1.GoIDMService.GetDownloadTaskFile:input_type -> go_idm.v1.GetDownloadTaskFiletRequest
-	5,  // 13: go_idm.v1.GoIDMService.CreateAccount:output_type -> go_idm.v1.CreateAccountResponse
-	7,  // 14: go_idm.v1.GoIDMService.CreateSession:output_type -> go_idm.v1.CreateSessionResponse
-	9,  // 15: go_idm.v1.GoIDMService.CreateDownloadTask:output_type -> go_idm.v1.CreateDownloadTaskResponse
-	11, // 16: go_idm.v1.GoIDMService.GetDownloadTaskList:output_type -> go_idm.v1.GetDownloadTaskListResponse
-	13, // 17: go_idm.v1.GoIDMService.UpdateDownloadTask:output_type -> go_idm.v1.UpdateDownloadTaskResponse
-	15, // 18: go_idm.v1.GoIDMService.DeleteDownloadTask:output_type -> go_idm.v1.DeleteDownloadTaskResponse
-	17, // 19: go_idm.v1.GoIDMService.GetDownloadTaskFile:output_type -> go_idm.v1.GetDownloadTaskFiletResponse
-	13, // [13:20] is the sub-list for method output_type
-	6,  // [6:13] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	2,  // 2: go_idm.v1.Team.team_role:type_name -> go_idm.v1.TeamRole
+	4,  // 3: go_idm.v1.DownloadFileIssue.kind:type_name -> go_idm.v1.DownloadFileIssueKind
+	0,  // 4: go_idm.v1.CreateDownloadTaskRequest.download_type:type_name -> go_idm.v1.DownloadType
+	7,  // 5: go_idm.v1.CreateDownloadTaskResponse.download_task:type_name -> go_idm.v1.DownloadTask
+	7,  // 6: go_idm.v1.GetDownloadTaskListResponse.download_task_list:type_name -> go_idm.v1.DownloadTask
+	7,  // 7: go_idm.v1.UpdateDownloadTaskResponse.download_task:type_name -> go_idm.v1.DownloadTask
+	7,  // 8: go_idm.v1.RestoreDownloadTaskResponse.download_task:type_name -> go_idm.v1.DownloadTask
+	7,  // 9: go_idm.v1.ListTrashResponse.download_task_list:type_name -> go_idm.v1.DownloadTask
+	7,  // 10: go_idm.v1.GetDownloadTaskResponse.download_task:type_name -> go_idm.v1.DownloadTask
+	3,  // 11: go_idm.v1.ShareDownloadTaskRequest.share_level:type_name -> go_idm.v1.DownloadTaskShareLevel
+	8,  // 12: go_idm.v1.CreateTeamResponse.team:type_name -> go_idm.v1.Team
+	8,  // 13: go_idm.v1.GetTeamListResponse.team_list:type_name -> go_idm.v1.Team
+	2,  // 14: go_idm.v1.AddTeamMemberRequest.team_role:type_name -> go_idm.v1.TeamRole
+	9,  // 15: go_idm.v1.CreateShareLinkResponse.share_link:type_name -> go_idm.v1.ShareLink
+	10, // 16: go_idm.v1.ListAuditEventsResponse.audit_event_list:type_name -> go_idm.v1.AuditEvent
+	5,  // 17: go_idm.v1.CheckDownloadFilesRequest.mismatch_fix:type_name -> go_idm.v1.DownloadFileMismatchFix
+	11, // 18: go_idm.v1.CheckDownloadFilesResponse.download_file_issue_list:type_name -> go_idm.v1.DownloadFileIssue
+	12, // 19: go_idm.v1.GoIDMService.CreateAccount:input_type -> go_idm.v1.CreateAccountRequest
+	14, // 20: go_idm.v1.GoIDMService.CreateSession:input_type -> go_idm.v1.CreateSessionRequest
+	16, // 21: go_idm.v1.GoIDMService.CreateDownloadTask:input_type -> go_idm.v1.CreateDownloadTaskRequest
+	18, // 22: go_idm.v1.GoIDMService.GetDownloadTaskList:input_type -> go_idm.v1.GetDownloadTaskListRequest
+	20, // 23: go_idm.v1.GoIDMService.UpdateDownloadTask:input_type -> go_idm.v1.UpdateDownloadTaskRequest
+	22, // 24: go_idm.v1.GoIDMService.DeleteDownloadTask:input_type -> go_idm.v1.DeleteDownloadTaskRequest
+	24, // 25: go_idm.v1.GoIDMService.RestoreDownloadTask:input_type -> go_idm.v1.RestoreDownloadTaskRequest
+	26, // 26: go_idm.v1.GoIDMService.ListTrash:input_type -> go_idm.v1.ListTrashRequest
+	28, // 27: go_idm.v1.GoIDMService.GetDownloadTaskFile:input_type -> go_idm.v1.GetDownloadTaskFiletRequest
+	30, // 28: go_idm.v1.GoIDMService.GetDownloadTask:input_type -> go_idm.v1.GetDownloadTaskRequest
+	32, // 29: go_idm.v1.GoIDMService.ShareDownloadTask:input_type -> go_idm.v1.ShareDownloadTaskRequest
+	34, // 30: go_idm.v1.GoIDMService.UnshareDownloadTask:input_type -> go_idm.v1.UnshareDownloadTaskRequest
+	36, // 31: go_idm.v1.GoIDMService.CreateTeam:input_type -> go_idm.v1.CreateTeamRequest
+	38, // 32: go_idm.v1.GoIDMService.GetTeamList:input_type -> go_idm.v1.GetTeamListRequest
+	40, // 33: go_idm.v1.GoIDMService.AddTeamMember:input_type -> go_idm.v1.AddTeamMemberRequest
+	42, // 34: go_idm.v1.GoIDMService.RemoveTeamMember:input_type -> go_idm.v1.RemoveTeamMemberRequest
+	44, // 35: go_idm.v1.GoIDMService.CreateShareLink:input_type -> go_idm.v1.CreateShareLinkRequest
+	46, // 36: go_idm.v1.GoIDMService.RevokeShareLink:input_type -> go_idm.v1.RevokeShareLinkRequest
+	48, // 37: go_idm.v1.GoIDMService.ListAuditEvents:input_type -> go_idm.v1.ListAuditEventsRequest
+	50, // 38: go_idm.v1.GoIDMService.CheckDownloadFiles:input_type -> go_idm.v1.CheckDownloadFilesRequest
+	13, // 39: go_idm.v1.GoIDMService.CreateAccount:output_type -> go_idm.v1.CreateAccountResponse
+	15, // 40: go_idm.v1.GoIDMService.CreateSession:output_type -> go_idm.v1.CreateSessionResponse
+	17, // 41: go_idm.v1.GoIDMService.CreateDownloadTask:output_type -> go_idm.v1.CreateDownloadTaskResponse
+	19, // 42: go_idm.v1.GoIDMService.GetDownloadTaskList:output_type -> go_idm.v1.GetDownloadTaskListResponse
+	21, // 43: go_idm.v1.GoIDMService.UpdateDownloadTask:output_type -> go_idm.v1.UpdateDownloadTaskResponse
+	23, // 44: go_idm.v1.GoIDMService.DeleteDownloadTask:output_type -> go_idm.v1.DeleteDownloadTaskResponse
+	25, // 45: go_idm.v1.GoIDMService.RestoreDownloadTask:output_type -> go_idm.v1.RestoreDownloadTaskResponse
+	27, // 46: go_idm.v1.GoIDMService.ListTrash:output_type -> go_idm.v1.ListTrashResponse
+	29, // 47: go_idm.v1.GoIDMService.GetDownloadTaskFile:output_type -> go_idm.v1.GetDownloadTaskFiletResponse
+	31, // 48: go_idm.v1.GoIDMService.GetDownloadTask:output_type -> go_idm.v1.GetDownloadTaskResponse
+	33, // 49: go_idm.v1.GoIDMService.ShareDownloadTask:output_type -> go_idm.v1.ShareDownloadTaskResponse
+	35, // 50: go_idm.v1.GoIDMService.UnshareDownloadTask:output_type -> go_idm.v1.UnshareDownloadTaskResponse
+	37, // 51: go_idm.v1.GoIDMService.CreateTeam:output_type -> go_idm.v1.CreateTeamResponse
+	39, // 52: go_idm.v1.GoIDMService.GetTeamList:output_type -> go_idm.v1.GetTeamListResponse
+	41, // 53: go_idm.v1.GoIDMService.AddTeamMember:output_type -> go_idm.v1.AddTeamMemberResponse
+	43, // 54: go_idm.v1.GoIDMService.RemoveTeamMember:output_type -> go_idm.v1.RemoveTeamMemberResponse
+	45, // 55: go_idm.v1.GoIDMService.CreateShareLink:output_type -> go_idm.v1.CreateShareLinkResponse
+	47, // 56: go_idm.v1.GoIDMService.RevokeShareLink:output_type -> go_idm.v1.RevokeShareLinkResponse
+	49, // 57: go_idm.v1.GoIDMService.ListAuditEvents:output_type -> go_idm.v1.ListAuditEventsResponse
+	51, // 58: go_idm.v1.GoIDMService.CheckDownloadFiles:output_type -> go_idm.v1.CheckDownloadFilesResponse
+	39, // [39:59] is the sub-list for method output_type
+	19, // [19:39] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
 }
 
 func init() { file_proto_api_proto_init() }
@@ -1206,207 +3312,13 @@ func file_proto_api_proto_init() {
 	if File_proto_api_proto != nil {
 		return
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_proto_api_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Account); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_api_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DownloadTask); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_api_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateAccountRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_api_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateAccountResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_api_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateSessionRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_api_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateSessionResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_api_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateDownloadTaskRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_api_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateDownloadTaskResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_api_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetDownloadTaskListRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_api_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetDownloadTaskListResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_api_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateDownloadTaskRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_api_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateDownloadTaskResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_api_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteDownloadTaskRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_api_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteDownloadTaskResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_api_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetDownloadTaskFiletRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_api_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetDownloadTaskFiletResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_proto_api_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   16,
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_api_proto_rawDesc), len(file_proto_api_proto_rawDesc)),
+			NumEnums:      6,
+			NumMessages:   46,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -1416,7 +3328,6 @@ func file_proto_api_proto_init() {
 		MessageInfos:      file_proto_api_proto_msgTypes,
 	}.Build()
 	File_proto_api_proto = out.File
-	file_proto_api_proto_rawDesc = nil
 	file_proto_api_proto_goTypes = nil
 	file_proto_api_proto_depIdxs = nil
 }
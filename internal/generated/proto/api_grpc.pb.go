@@ -25,7 +25,20 @@ const (
 	GoIDMService_GetDownloadTaskList_FullMethodName = "/go_idm.v1.GoIDMService/GetDownloadTaskList"
 	GoIDMService_UpdateDownloadTask_FullMethodName  = "/go_idm.v1.GoIDMService/UpdateDownloadTask"
 	GoIDMService_DeleteDownloadTask_FullMethodName  = "/go_idm.v1.GoIDMService/DeleteDownloadTask"
+	GoIDMService_RestoreDownloadTask_FullMethodName = "/go_idm.v1.GoIDMService/RestoreDownloadTask"
+	GoIDMService_ListTrash_FullMethodName           = "/go_idm.v1.GoIDMService/ListTrash"
 	GoIDMService_GetDownloadTaskFile_FullMethodName = "/go_idm.v1.GoIDMService/GetDownloadTaskFile"
+	GoIDMService_GetDownloadTask_FullMethodName     = "/go_idm.v1.GoIDMService/GetDownloadTask"
+	GoIDMService_ShareDownloadTask_FullMethodName   = "/go_idm.v1.GoIDMService/ShareDownloadTask"
+	GoIDMService_UnshareDownloadTask_FullMethodName = "/go_idm.v1.GoIDMService/UnshareDownloadTask"
+	GoIDMService_CreateTeam_FullMethodName          = "/go_idm.v1.GoIDMService/CreateTeam"
+	GoIDMService_GetTeamList_FullMethodName         = "/go_idm.v1.GoIDMService/GetTeamList"
+	GoIDMService_AddTeamMember_FullMethodName       = "/go_idm.v1.GoIDMService/AddTeamMember"
+	GoIDMService_RemoveTeamMember_FullMethodName    = "/go_idm.v1.GoIDMService/RemoveTeamMember"
+	GoIDMService_CreateShareLink_FullMethodName     = "/go_idm.v1.GoIDMService/CreateShareLink"
+	GoIDMService_RevokeShareLink_FullMethodName     = "/go_idm.v1.GoIDMService/RevokeShareLink"
+	GoIDMService_ListAuditEvents_FullMethodName     = "/go_idm.v1.GoIDMService/ListAuditEvents"
+	GoIDMService_CheckDownloadFiles_FullMethodName  = "/go_idm.v1.GoIDMService/CheckDownloadFiles"
 )
 
 // GoIDMServiceClient is the client API for GoIDMService service.
@@ -38,7 +51,20 @@ type GoIDMServiceClient interface {
 	GetDownloadTaskList(ctx context.Context, in *GetDownloadTaskListRequest, opts ...grpc.CallOption) (*GetDownloadTaskListResponse, error)
 	UpdateDownloadTask(ctx context.Context, in *UpdateDownloadTaskRequest, opts ...grpc.CallOption) (*UpdateDownloadTaskResponse, error)
 	DeleteDownloadTask(ctx context.Context, in *DeleteDownloadTaskRequest, opts ...grpc.CallOption) (*DeleteDownloadTaskResponse, error)
+	RestoreDownloadTask(ctx context.Context, in *RestoreDownloadTaskRequest, opts ...grpc.CallOption) (*RestoreDownloadTaskResponse, error)
+	ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (*ListTrashResponse, error)
 	GetDownloadTaskFile(ctx context.Context, in *GetDownloadTaskFiletRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetDownloadTaskFiletResponse], error)
+	GetDownloadTask(ctx context.Context, in *GetDownloadTaskRequest, opts ...grpc.CallOption) (*GetDownloadTaskResponse, error)
+	ShareDownloadTask(ctx context.Context, in *ShareDownloadTaskRequest, opts ...grpc.CallOption) (*ShareDownloadTaskResponse, error)
+	UnshareDownloadTask(ctx context.Context, in *UnshareDownloadTaskRequest, opts ...grpc.CallOption) (*UnshareDownloadTaskResponse, error)
+	CreateTeam(ctx context.Context, in *CreateTeamRequest, opts ...grpc.CallOption) (*CreateTeamResponse, error)
+	GetTeamList(ctx context.Context, in *GetTeamListRequest, opts ...grpc.CallOption) (*GetTeamListResponse, error)
+	AddTeamMember(ctx context.Context, in *AddTeamMemberRequest, opts ...grpc.CallOption) (*AddTeamMemberResponse, error)
+	RemoveTeamMember(ctx context.Context, in *RemoveTeamMemberRequest, opts ...grpc.CallOption) (*RemoveTeamMemberResponse, error)
+	CreateShareLink(ctx context.Context, in *CreateShareLinkRequest, opts ...grpc.CallOption) (*CreateShareLinkResponse, error)
+	RevokeShareLink(ctx context.Context, in *RevokeShareLinkRequest, opts ...grpc.CallOption) (*RevokeShareLinkResponse, error)
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
+	CheckDownloadFiles(ctx context.Context, in *CheckDownloadFilesRequest, opts ...grpc.CallOption) (*CheckDownloadFilesResponse, error)
 }
 
 type goIDMServiceClient struct {
@@ -109,6 +135,26 @@ func (c *goIDMServiceClient) DeleteDownloadTask(ctx context.Context, in *DeleteD
 	return out, nil
 }
 
+func (c *goIDMServiceClient) RestoreDownloadTask(ctx context.Context, in *RestoreDownloadTaskRequest, opts ...grpc.CallOption) (*RestoreDownloadTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreDownloadTaskResponse)
+	err := c.cc.Invoke(ctx, GoIDMService_RestoreDownloadTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goIDMServiceClient) ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (*ListTrashResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTrashResponse)
+	err := c.cc.Invoke(ctx, GoIDMService_ListTrash_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *goIDMServiceClient) GetDownloadTaskFile(ctx context.Context, in *GetDownloadTaskFiletRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetDownloadTaskFiletResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &GoIDMService_ServiceDesc.Streams[0], GoIDMService_GetDownloadTaskFile_FullMethodName, cOpts...)
@@ -128,6 +174,116 @@ func (c *goIDMServiceClient) GetDownloadTaskFile(ctx context.Context, in *GetDow
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type GoIDMService_GetDownloadTaskFileClient = grpc.ServerStreamingClient[GetDownloadTaskFiletResponse]
 
+func (c *goIDMServiceClient) GetDownloadTask(ctx context.Context, in *GetDownloadTaskRequest, opts ...grpc.CallOption) (*GetDownloadTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDownloadTaskResponse)
+	err := c.cc.Invoke(ctx, GoIDMService_GetDownloadTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goIDMServiceClient) ShareDownloadTask(ctx context.Context, in *ShareDownloadTaskRequest, opts ...grpc.CallOption) (*ShareDownloadTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShareDownloadTaskResponse)
+	err := c.cc.Invoke(ctx, GoIDMService_ShareDownloadTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goIDMServiceClient) UnshareDownloadTask(ctx context.Context, in *UnshareDownloadTaskRequest, opts ...grpc.CallOption) (*UnshareDownloadTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnshareDownloadTaskResponse)
+	err := c.cc.Invoke(ctx, GoIDMService_UnshareDownloadTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goIDMServiceClient) CreateTeam(ctx context.Context, in *CreateTeamRequest, opts ...grpc.CallOption) (*CreateTeamResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateTeamResponse)
+	err := c.cc.Invoke(ctx, GoIDMService_CreateTeam_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goIDMServiceClient) GetTeamList(ctx context.Context, in *GetTeamListRequest, opts ...grpc.CallOption) (*GetTeamListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTeamListResponse)
+	err := c.cc.Invoke(ctx, GoIDMService_GetTeamList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goIDMServiceClient) AddTeamMember(ctx context.Context, in *AddTeamMemberRequest, opts ...grpc.CallOption) (*AddTeamMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddTeamMemberResponse)
+	err := c.cc.Invoke(ctx, GoIDMService_AddTeamMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goIDMServiceClient) RemoveTeamMember(ctx context.Context, in *RemoveTeamMemberRequest, opts ...grpc.CallOption) (*RemoveTeamMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveTeamMemberResponse)
+	err := c.cc.Invoke(ctx, GoIDMService_RemoveTeamMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goIDMServiceClient) CreateShareLink(ctx context.Context, in *CreateShareLinkRequest, opts ...grpc.CallOption) (*CreateShareLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateShareLinkResponse)
+	err := c.cc.Invoke(ctx, GoIDMService_CreateShareLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goIDMServiceClient) RevokeShareLink(ctx context.Context, in *RevokeShareLinkRequest, opts ...grpc.CallOption) (*RevokeShareLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeShareLinkResponse)
+	err := c.cc.Invoke(ctx, GoIDMService_RevokeShareLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goIDMServiceClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, GoIDMService_ListAuditEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goIDMServiceClient) CheckDownloadFiles(ctx context.Context, in *CheckDownloadFilesRequest, opts ...grpc.CallOption) (*CheckDownloadFilesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckDownloadFilesResponse)
+	err := c.cc.Invoke(ctx, GoIDMService_CheckDownloadFiles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GoIDMServiceServer is the server API for GoIDMService service.
 // All implementations must embed UnimplementedGoIDMServiceServer
 // for forward compatibility.
@@ -138,7 +294,20 @@ type GoIDMServiceServer interface {
 	GetDownloadTaskList(context.Context, *GetDownloadTaskListRequest) (*GetDownloadTaskListResponse, error)
 	UpdateDownloadTask(context.Context, *UpdateDownloadTaskRequest) (*UpdateDownloadTaskResponse, error)
 	DeleteDownloadTask(context.Context, *DeleteDownloadTaskRequest) (*DeleteDownloadTaskResponse, error)
+	RestoreDownloadTask(context.Context, *RestoreDownloadTaskRequest) (*RestoreDownloadTaskResponse, error)
+	ListTrash(context.Context, *ListTrashRequest) (*ListTrashResponse, error)
 	GetDownloadTaskFile(*GetDownloadTaskFiletRequest, grpc.ServerStreamingServer[GetDownloadTaskFiletResponse]) error
+	GetDownloadTask(context.Context, *GetDownloadTaskRequest) (*GetDownloadTaskResponse, error)
+	ShareDownloadTask(context.Context, *ShareDownloadTaskRequest) (*ShareDownloadTaskResponse, error)
+	UnshareDownloadTask(context.Context, *UnshareDownloadTaskRequest) (*UnshareDownloadTaskResponse, error)
+	CreateTeam(context.Context, *CreateTeamRequest) (*CreateTeamResponse, error)
+	GetTeamList(context.Context, *GetTeamListRequest) (*GetTeamListResponse, error)
+	AddTeamMember(context.Context, *AddTeamMemberRequest) (*AddTeamMemberResponse, error)
+	RemoveTeamMember(context.Context, *RemoveTeamMemberRequest) (*RemoveTeamMemberResponse, error)
+	CreateShareLink(context.Context, *CreateShareLinkRequest) (*CreateShareLinkResponse, error)
+	RevokeShareLink(context.Context, *RevokeShareLinkRequest) (*RevokeShareLinkResponse, error)
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
+	CheckDownloadFiles(context.Context, *CheckDownloadFilesRequest) (*CheckDownloadFilesResponse, error)
 	mustEmbedUnimplementedGoIDMServiceServer()
 }
 
@@ -167,9 +336,48 @@ func (UnimplementedGoIDMServiceServer) UpdateDownloadTask(context.Context, *Upda
 func (UnimplementedGoIDMServiceServer) DeleteDownloadTask(context.Context, *DeleteDownloadTaskRequest) (*DeleteDownloadTaskResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteDownloadTask not implemented")
 }
+func (UnimplementedGoIDMServiceServer) RestoreDownloadTask(context.Context, *RestoreDownloadTaskRequest) (*RestoreDownloadTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreDownloadTask not implemented")
+}
+func (UnimplementedGoIDMServiceServer) ListTrash(context.Context, *ListTrashRequest) (*ListTrashResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrash not implemented")
+}
 func (UnimplementedGoIDMServiceServer) GetDownloadTaskFile(*GetDownloadTaskFiletRequest, grpc.ServerStreamingServer[GetDownloadTaskFiletResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method GetDownloadTaskFile not implemented")
 }
+func (UnimplementedGoIDMServiceServer) GetDownloadTask(context.Context, *GetDownloadTaskRequest) (*GetDownloadTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDownloadTask not implemented")
+}
+func (UnimplementedGoIDMServiceServer) ShareDownloadTask(context.Context, *ShareDownloadTaskRequest) (*ShareDownloadTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareDownloadTask not implemented")
+}
+func (UnimplementedGoIDMServiceServer) UnshareDownloadTask(context.Context, *UnshareDownloadTaskRequest) (*UnshareDownloadTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnshareDownloadTask not implemented")
+}
+func (UnimplementedGoIDMServiceServer) CreateTeam(context.Context, *CreateTeamRequest) (*CreateTeamResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTeam not implemented")
+}
+func (UnimplementedGoIDMServiceServer) GetTeamList(context.Context, *GetTeamListRequest) (*GetTeamListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTeamList not implemented")
+}
+func (UnimplementedGoIDMServiceServer) AddTeamMember(context.Context, *AddTeamMemberRequest) (*AddTeamMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTeamMember not implemented")
+}
+func (UnimplementedGoIDMServiceServer) RemoveTeamMember(context.Context, *RemoveTeamMemberRequest) (*RemoveTeamMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTeamMember not implemented")
+}
+func (UnimplementedGoIDMServiceServer) CreateShareLink(context.Context, *CreateShareLinkRequest) (*CreateShareLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateShareLink not implemented")
+}
+func (UnimplementedGoIDMServiceServer) RevokeShareLink(context.Context, *RevokeShareLinkRequest) (*RevokeShareLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeShareLink not implemented")
+}
+func (UnimplementedGoIDMServiceServer) ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
+func (UnimplementedGoIDMServiceServer) CheckDownloadFiles(context.Context, *CheckDownloadFilesRequest) (*CheckDownloadFilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckDownloadFiles not implemented")
+}
 func (UnimplementedGoIDMServiceServer) mustEmbedUnimplementedGoIDMServiceServer() {}
 func (UnimplementedGoIDMServiceServer) testEmbeddedByValue()                      {}
 
@@ -299,6 +507,42 @@ func _GoIDMService_DeleteDownloadTask_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GoIDMService_RestoreDownloadTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreDownloadTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoIDMServiceServer).RestoreDownloadTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoIDMService_RestoreDownloadTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoIDMServiceServer).RestoreDownloadTask(ctx, req.(*RestoreDownloadTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoIDMService_ListTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTrashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoIDMServiceServer).ListTrash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoIDMService_ListTrash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoIDMServiceServer).ListTrash(ctx, req.(*ListTrashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GoIDMService_GetDownloadTaskFile_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(GetDownloadTaskFiletRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -310,6 +554,204 @@ func _GoIDMService_GetDownloadTaskFile_Handler(srv interface{}, stream grpc.Serv
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type GoIDMService_GetDownloadTaskFileServer = grpc.ServerStreamingServer[GetDownloadTaskFiletResponse]
 
+func _GoIDMService_GetDownloadTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDownloadTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoIDMServiceServer).GetDownloadTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoIDMService_GetDownloadTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoIDMServiceServer).GetDownloadTask(ctx, req.(*GetDownloadTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoIDMService_ShareDownloadTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareDownloadTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoIDMServiceServer).ShareDownloadTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoIDMService_ShareDownloadTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoIDMServiceServer).ShareDownloadTask(ctx, req.(*ShareDownloadTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoIDMService_UnshareDownloadTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnshareDownloadTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoIDMServiceServer).UnshareDownloadTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoIDMService_UnshareDownloadTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoIDMServiceServer).UnshareDownloadTask(ctx, req.(*UnshareDownloadTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoIDMService_CreateTeam_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTeamRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoIDMServiceServer).CreateTeam(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoIDMService_CreateTeam_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoIDMServiceServer).CreateTeam(ctx, req.(*CreateTeamRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoIDMService_GetTeamList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTeamListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoIDMServiceServer).GetTeamList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoIDMService_GetTeamList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoIDMServiceServer).GetTeamList(ctx, req.(*GetTeamListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoIDMService_AddTeamMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTeamMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoIDMServiceServer).AddTeamMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoIDMService_AddTeamMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoIDMServiceServer).AddTeamMember(ctx, req.(*AddTeamMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoIDMService_RemoveTeamMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTeamMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoIDMServiceServer).RemoveTeamMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoIDMService_RemoveTeamMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoIDMServiceServer).RemoveTeamMember(ctx, req.(*RemoveTeamMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoIDMService_CreateShareLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateShareLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoIDMServiceServer).CreateShareLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoIDMService_CreateShareLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoIDMServiceServer).CreateShareLink(ctx, req.(*CreateShareLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoIDMService_RevokeShareLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeShareLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoIDMServiceServer).RevokeShareLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoIDMService_RevokeShareLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoIDMServiceServer).RevokeShareLink(ctx, req.(*RevokeShareLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoIDMService_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoIDMServiceServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoIDMService_ListAuditEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoIDMServiceServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoIDMService_CheckDownloadFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckDownloadFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoIDMServiceServer).CheckDownloadFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoIDMService_CheckDownloadFiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoIDMServiceServer).CheckDownloadFiles(ctx, req.(*CheckDownloadFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // GoIDMService_ServiceDesc is the grpc.ServiceDesc for GoIDMService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -341,6 +783,58 @@ var GoIDMService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteDownloadTask",
 			Handler:    _GoIDMService_DeleteDownloadTask_Handler,
 		},
+		{
+			MethodName: "RestoreDownloadTask",
+			Handler:    _GoIDMService_RestoreDownloadTask_Handler,
+		},
+		{
+			MethodName: "ListTrash",
+			Handler:    _GoIDMService_ListTrash_Handler,
+		},
+		{
+			MethodName: "GetDownloadTask",
+			Handler:    _GoIDMService_GetDownloadTask_Handler,
+		},
+		{
+			MethodName: "ShareDownloadTask",
+			Handler:    _GoIDMService_ShareDownloadTask_Handler,
+		},
+		{
+			MethodName: "UnshareDownloadTask",
+			Handler:    _GoIDMService_UnshareDownloadTask_Handler,
+		},
+		{
+			MethodName: "CreateTeam",
+			Handler:    _GoIDMService_CreateTeam_Handler,
+		},
+		{
+			MethodName: "GetTeamList",
+			Handler:    _GoIDMService_GetTeamList_Handler,
+		},
+		{
+			MethodName: "AddTeamMember",
+			Handler:    _GoIDMService_AddTeamMember_Handler,
+		},
+		{
+			MethodName: "RemoveTeamMember",
+			Handler:    _GoIDMService_RemoveTeamMember_Handler,
+		},
+		{
+			MethodName: "CreateShareLink",
+			Handler:    _GoIDMService_CreateShareLink_Handler,
+		},
+		{
+			MethodName: "RevokeShareLink",
+			Handler:    _GoIDMService_RevokeShareLink_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _GoIDMService_ListAuditEvents_Handler,
+		},
+		{
+			MethodName: "CheckDownloadFiles",
+			Handler:    _GoIDMService_CheckDownloadFiles_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
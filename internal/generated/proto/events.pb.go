@@ -0,0 +1,483 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.32.1
+// source: proto/events.proto
+
+package go_idm_v1pro
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type DownloadTaskStatusChangedEvent struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Version           uint32                 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	DownloadTaskId    uint64                 `protobuf:"varint,2,opt,name=download_task_id,json=downloadTaskId,proto3" json:"download_task_id,omitempty"`
+	OfAccountId       uint64                 `protobuf:"varint,3,opt,name=of_account_id,json=ofAccountId,proto3" json:"of_account_id,omitempty"`
+	OfTeamId          uint64                 `protobuf:"varint,4,opt,name=of_team_id,json=ofTeamId,proto3" json:"of_team_id,omitempty"`
+	OldDownloadStatus DownloadStatus         `protobuf:"varint,5,opt,name=old_download_status,json=oldDownloadStatus,proto3,enum=go_idm.v1.DownloadStatus" json:"old_download_status,omitempty"`
+	NewDownloadStatus DownloadStatus         `protobuf:"varint,6,opt,name=new_download_status,json=newDownloadStatus,proto3,enum=go_idm.v1.DownloadStatus" json:"new_download_status,omitempty"`
+	EventTime         uint64                 `protobuf:"varint,7,opt,name=event_time,json=eventTime,proto3" json:"event_time,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *DownloadTaskStatusChangedEvent) Reset() {
+	*x = DownloadTaskStatusChangedEvent{}
+	mi := &file_proto_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadTaskStatusChangedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadTaskStatusChangedEvent) ProtoMessage() {}
+
+func (x *DownloadTaskStatusChangedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadTaskStatusChangedEvent.ProtoReflect.Descriptor instead.
+func (*DownloadTaskStatusChangedEvent) Descriptor() ([]byte, []int) {
+	return file_proto_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DownloadTaskStatusChangedEvent) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *DownloadTaskStatusChangedEvent) GetDownloadTaskId() uint64 {
+	if x != nil {
+		return x.DownloadTaskId
+	}
+	return 0
+}
+
+func (x *DownloadTaskStatusChangedEvent) GetOfAccountId() uint64 {
+	if x != nil {
+		return x.OfAccountId
+	}
+	return 0
+}
+
+func (x *DownloadTaskStatusChangedEvent) GetOfTeamId() uint64 {
+	if x != nil {
+		return x.OfTeamId
+	}
+	return 0
+}
+
+func (x *DownloadTaskStatusChangedEvent) GetOldDownloadStatus() DownloadStatus {
+	if x != nil {
+		return x.OldDownloadStatus
+	}
+	return DownloadStatus_UndefinedDownloadStatus
+}
+
+func (x *DownloadTaskStatusChangedEvent) GetNewDownloadStatus() DownloadStatus {
+	if x != nil {
+		return x.NewDownloadStatus
+	}
+	return DownloadStatus_UndefinedDownloadStatus
+}
+
+func (x *DownloadTaskStatusChangedEvent) GetEventTime() uint64 {
+	if x != nil {
+		return x.EventTime
+	}
+	return 0
+}
+
+type DownloadTaskCompletedEvent struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Version        uint32                 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	DownloadTaskId uint64                 `protobuf:"varint,2,opt,name=download_task_id,json=downloadTaskId,proto3" json:"download_task_id,omitempty"`
+	OfAccountId    uint64                 `protobuf:"varint,3,opt,name=of_account_id,json=ofAccountId,proto3" json:"of_account_id,omitempty"`
+	OfTeamId       uint64                 `protobuf:"varint,4,opt,name=of_team_id,json=ofTeamId,proto3" json:"of_team_id,omitempty"`
+	FileName       string                 `protobuf:"bytes,5,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	FileSize       uint64                 `protobuf:"varint,6,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
+	// Hex encoded SHA-256 of the file.
+	Checksum      string `protobuf:"bytes,7,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	ContentType   string `protobuf:"bytes,8,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	EventTime     uint64 `protobuf:"varint,9,opt,name=event_time,json=eventTime,proto3" json:"event_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadTaskCompletedEvent) Reset() {
+	*x = DownloadTaskCompletedEvent{}
+	mi := &file_proto_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadTaskCompletedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadTaskCompletedEvent) ProtoMessage() {}
+
+func (x *DownloadTaskCompletedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadTaskCompletedEvent.ProtoReflect.Descriptor instead.
+func (*DownloadTaskCompletedEvent) Descriptor() ([]byte, []int) {
+	return file_proto_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DownloadTaskCompletedEvent) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *DownloadTaskCompletedEvent) GetDownloadTaskId() uint64 {
+	if x != nil {
+		return x.DownloadTaskId
+	}
+	return 0
+}
+
+func (x *DownloadTaskCompletedEvent) GetOfAccountId() uint64 {
+	if x != nil {
+		return x.OfAccountId
+	}
+	return 0
+}
+
+func (x *DownloadTaskCompletedEvent) GetOfTeamId() uint64 {
+	if x != nil {
+		return x.OfTeamId
+	}
+	return 0
+}
+
+func (x *DownloadTaskCompletedEvent) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *DownloadTaskCompletedEvent) GetFileSize() uint64 {
+	if x != nil {
+		return x.FileSize
+	}
+	return 0
+}
+
+func (x *DownloadTaskCompletedEvent) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+func (x *DownloadTaskCompletedEvent) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *DownloadTaskCompletedEvent) GetEventTime() uint64 {
+	if x != nil {
+		return x.EventTime
+	}
+	return 0
+}
+
+type DownloadTaskFailedEvent struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Version        uint32                 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	DownloadTaskId uint64                 `protobuf:"varint,2,opt,name=download_task_id,json=downloadTaskId,proto3" json:"download_task_id,omitempty"`
+	OfAccountId    uint64                 `protobuf:"varint,3,opt,name=of_account_id,json=ofAccountId,proto3" json:"of_account_id,omitempty"`
+	OfTeamId       uint64                 `protobuf:"varint,4,opt,name=of_team_id,json=ofTeamId,proto3" json:"of_team_id,omitempty"`
+	Error          string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	EventTime      uint64                 `protobuf:"varint,6,opt,name=event_time,json=eventTime,proto3" json:"event_time,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DownloadTaskFailedEvent) Reset() {
+	*x = DownloadTaskFailedEvent{}
+	mi := &file_proto_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadTaskFailedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadTaskFailedEvent) ProtoMessage() {}
+
+func (x *DownloadTaskFailedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadTaskFailedEvent.ProtoReflect.Descriptor instead.
+func (*DownloadTaskFailedEvent) Descriptor() ([]byte, []int) {
+	return file_proto_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DownloadTaskFailedEvent) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *DownloadTaskFailedEvent) GetDownloadTaskId() uint64 {
+	if x != nil {
+		return x.DownloadTaskId
+	}
+	return 0
+}
+
+func (x *DownloadTaskFailedEvent) GetOfAccountId() uint64 {
+	if x != nil {
+		return x.OfAccountId
+	}
+	return 0
+}
+
+func (x *DownloadTaskFailedEvent) GetOfTeamId() uint64 {
+	if x != nil {
+		return x.OfTeamId
+	}
+	return 0
+}
+
+func (x *DownloadTaskFailedEvent) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DownloadTaskFailedEvent) GetEventTime() uint64 {
+	if x != nil {
+		return x.EventTime
+	}
+	return 0
+}
+
+type DownloadTaskDeletedEvent struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Version        uint32                 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	DownloadTaskId uint64                 `protobuf:"varint,2,opt,name=download_task_id,json=downloadTaskId,proto3" json:"download_task_id,omitempty"`
+	OfAccountId    uint64                 `protobuf:"varint,3,opt,name=of_account_id,json=ofAccountId,proto3" json:"of_account_id,omitempty"`
+	OfTeamId       uint64                 `protobuf:"varint,4,opt,name=of_team_id,json=ofTeamId,proto3" json:"of_team_id,omitempty"`
+	EventTime      uint64                 `protobuf:"varint,5,opt,name=event_time,json=eventTime,proto3" json:"event_time,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DownloadTaskDeletedEvent) Reset() {
+	*x = DownloadTaskDeletedEvent{}
+	mi := &file_proto_events_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadTaskDeletedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadTaskDeletedEvent) ProtoMessage() {}
+
+func (x *DownloadTaskDeletedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_events_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadTaskDeletedEvent.ProtoReflect.Descriptor instead.
+func (*DownloadTaskDeletedEvent) Descriptor() ([]byte, []int) {
+	return file_proto_events_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DownloadTaskDeletedEvent) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *DownloadTaskDeletedEvent) GetDownloadTaskId() uint64 {
+	if x != nil {
+		return x.DownloadTaskId
+	}
+	return 0
+}
+
+func (x *DownloadTaskDeletedEvent) GetOfAccountId() uint64 {
+	if x != nil {
+		return x.OfAccountId
+	}
+	return 0
+}
+
+func (x *DownloadTaskDeletedEvent) GetOfTeamId() uint64 {
+	if x != nil {
+		return x.OfTeamId
+	}
+	return 0
+}
+
+func (x *DownloadTaskDeletedEvent) GetEventTime() uint64 {
+	if x != nil {
+		return x.EventTime
+	}
+	return 0
+}
+
+var File_proto_events_proto protoreflect.FileDescriptor
+
+const file_proto_events_proto_rawDesc = "" +
+	"\n" +
+	"\x12proto/events.proto\x12\tgo_idm.v1\x1a\x0fproto/api.proto\"\xdb\x02\n" +
+	"\x1eDownloadTaskStatusChangedEvent\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\rR\aversion\x12(\n" +
+	"\x10download_task_id\x18\x02 \x01(\x04R\x0edownloadTaskId\x12\"\n" +
+	"\rof_account_id\x18\x03 \x01(\x04R\vofAccountId\x12\x1c\n" +
+	"\n" +
+	"of_team_id\x18\x04 \x01(\x04R\bofTeamId\x12I\n" +
+	"\x13old_download_status\x18\x05 \x01(\x0e2\x19.go_idm.v1.DownloadStatusR\x11oldDownloadStatus\x12I\n" +
+	"\x13new_download_status\x18\x06 \x01(\x0e2\x19.go_idm.v1.DownloadStatusR\x11newDownloadStatus\x12\x1d\n" +
+	"\n" +
+	"event_time\x18\a \x01(\x04R\teventTime\"\xba\x02\n" +
+	"\x1aDownloadTaskCompletedEvent\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\rR\aversion\x12(\n" +
+	"\x10download_task_id\x18\x02 \x01(\x04R\x0edownloadTaskId\x12\"\n" +
+	"\rof_account_id\x18\x03 \x01(\x04R\vofAccountId\x12\x1c\n" +
+	"\n" +
+	"of_team_id\x18\x04 \x01(\x04R\bofTeamId\x12\x1b\n" +
+	"\tfile_name\x18\x05 \x01(\tR\bfileName\x12\x1b\n" +
+	"\tfile_size\x18\x06 \x01(\x04R\bfileSize\x12\x1a\n" +
+	"\bchecksum\x18\a \x01(\tR\bchecksum\x12!\n" +
+	"\fcontent_type\x18\b \x01(\tR\vcontentType\x12\x1d\n" +
+	"\n" +
+	"event_time\x18\t \x01(\x04R\teventTime\"\xd4\x01\n" +
+	"\x17DownloadTaskFailedEvent\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\rR\aversion\x12(\n" +
+	"\x10download_task_id\x18\x02 \x01(\x04R\x0edownloadTaskId\x12\"\n" +
+	"\rof_account_id\x18\x03 \x01(\x04R\vofAccountId\x12\x1c\n" +
+	"\n" +
+	"of_team_id\x18\x04 \x01(\x04R\bofTeamId\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"event_time\x18\x06 \x01(\x04R\teventTime\"\xbf\x01\n" +
+	"\x18DownloadTaskDeletedEvent\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\rR\aversion\x12(\n" +
+	"\x10download_task_id\x18\x02 \x01(\x04R\x0edownloadTaskId\x12\"\n" +
+	"\rof_account_id\x18\x03 \x01(\x04R\vofAccountId\x12\x1c\n" +
+	"\n" +
+	"of_team_id\x18\x04 \x01(\x04R\bofTeamId\x12\x1d\n" +
+	"\n" +
+	"event_time\x18\x05 \x01(\x04R\teventTimeB\x13Z\x11grpc/go_idm_v1prob\x06proto3"
+
+var (
+	file_proto_events_proto_rawDescOnce sync.Once
+	file_proto_events_proto_rawDescData []byte
+)
+
+func file_proto_events_proto_rawDescGZIP() []byte {
+	file_proto_events_proto_rawDescOnce.Do(func() {
+		file_proto_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_events_proto_rawDesc), len(file_proto_events_proto_rawDesc)))
+	})
+	return file_proto_events_proto_rawDescData
+}
+
+var file_proto_events_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_proto_events_proto_goTypes = []any{
+	(*DownloadTaskStatusChangedEvent)(nil), // 0: go_idm.v1.DownloadTaskStatusChangedEvent
+	(*DownloadTaskCompletedEvent)(nil),     // 1: go_idm.v1.DownloadTaskCompletedEvent
+	(*DownloadTaskFailedEvent)(nil),        // 2: go_idm.v1.DownloadTaskFailedEvent
+	(*DownloadTaskDeletedEvent)(nil),       // 3: go_idm.v1.DownloadTaskDeletedEvent
+	(DownloadStatus)(0),                    // 4: go_idm.v1.DownloadStatus
+}
+var file_proto_events_proto_depIdxs = []int32{
+	4, // 0: go_idm.v1.DownloadTaskStatusChangedEvent.old_download_status:type_name -> go_idm.v1.DownloadStatus
+	4, // 1: go_idm.v1.DownloadTaskStatusChangedEvent.new_download_status:type_name -> go_idm.v1.DownloadStatus
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_proto_events_proto_init() }
+func file_proto_events_proto_init() {
+	if File_proto_events_proto != nil {
+		return
+	}
+	file_proto_api_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_events_proto_rawDesc), len(file_proto_events_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_events_proto_goTypes,
+		DependencyIndexes: file_proto_events_proto_depIdxs,
+		MessageInfos:      file_proto_events_proto_msgTypes,
+	}.Build()
+	File_proto_events_proto = out.File
+	file_proto_events_proto_goTypes = nil
+	file_proto_events_proto_depIdxs = nil
+}
@@ -12,6 +12,7 @@ import (
 
 type Root interface {
 	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
 }
 
 type root struct {
@@ -47,3 +48,7 @@ func (r root) Start(ctx context.Context) error {
 
 	return r.consumer.Start(ctx)
 }
+
+func (r root) Stop(ctx context.Context) error {
+	return r.consumer.Stop(ctx)
+}
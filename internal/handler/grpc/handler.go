@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"time"
 
 	"github.com/manhhung2111/go-idm/internal/config"
 	go_idm_v1 "github.com/manhhung2111/go-idm/internal/generated/proto"
@@ -14,12 +15,20 @@ type Handler struct {
 	go_idm_v1.UnimplementedGoIDMServiceServer
 	accountLogic                                 logic.Account
 	downloadTaskLogic                            logic.DownloadTask
+	teamLogic                                    logic.Team
+	shareLinkLogic                               logic.ShareLink
+	auditEventLogic                              logic.AuditEvent
+	downloadFileCheckLogic                       logic.DownloadFileCheck
 	getDownloadTaskFileResponseBufferSizeInBytes uint64
 }
 
 func NewHandler(
 	accountLogic logic.Account,
 	downloadTaskLogic logic.DownloadTask,
+	teamLogic logic.Team,
+	shareLinkLogic logic.ShareLink,
+	auditEventLogic logic.AuditEvent,
+	downloadFileCheckLogic logic.DownloadFileCheck,
 	grpcConfig config.GRPC,
 ) (go_idm_v1.GoIDMServiceServer, error) {
 	getDownloadTaskFileResponseBufferSizeInBytes, err := grpcConfig.GetDownloadTaskFile.GetResponseBufferSizeInBytes()
@@ -30,6 +39,10 @@ func NewHandler(
 	return &Handler{
 		accountLogic:      accountLogic,
 		downloadTaskLogic: downloadTaskLogic,
+		teamLogic:         teamLogic,
+		shareLinkLogic:    shareLinkLogic,
+		auditEventLogic:   auditEventLogic,
+		downloadFileCheckLogic: downloadFileCheckLogic,
 		getDownloadTaskFileResponseBufferSizeInBytes: getDownloadTaskFileResponseBufferSizeInBytes,
 	}, nil
 }
@@ -68,6 +81,7 @@ func (h *Handler) CreateDownloadTask(ctx context.Context, req *go_idm_v1.CreateD
 		Token:        req.GetToken(),
 		DownloadType: req.GetDownloadType(),
 		URL:          req.GetUrl(),
+		TeamID:       req.GetTeamId(),
 	})
 	if err != nil {
 		return nil, err
@@ -120,10 +134,42 @@ func (h *Handler) DeleteDownloadTask(ctx context.Context, req *go_idm_v1.DeleteD
 	return &go_idm_v1.DeleteDownloadTaskResponse{}, nil
 }
 
+func (h *Handler) RestoreDownloadTask(ctx context.Context, req *go_idm_v1.RestoreDownloadTaskRequest) (*go_idm_v1.RestoreDownloadTaskResponse, error) {
+	output, err := h.downloadTaskLogic.RestoreDownloadTask(ctx, logic.RestoreDownloadTaskParams{
+		Token:          req.GetToken(),
+		DownloadTaskID: req.GetDownloadTaskId(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &go_idm_v1.RestoreDownloadTaskResponse{
+		DownloadTask: output.DownloadTask,
+	}, nil
+}
+
+func (h *Handler) ListTrash(ctx context.Context, req *go_idm_v1.ListTrashRequest) (*go_idm_v1.ListTrashResponse, error) {
+	output, err := h.downloadTaskLogic.ListTrash(ctx, logic.ListTrashParams{
+		Token:  req.GetToken(),
+		Offset: req.GetOffset(),
+		Limit:  req.GetLimit(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &go_idm_v1.ListTrashResponse{
+		DownloadTaskList:       output.DownloadTaskList,
+		TotalDownloadTaskCount: output.Total,
+	}, nil
+}
+
 func (h *Handler) GetDownloadTaskFile(req *go_idm_v1.GetDownloadTaskFiletRequest, server go_idm_v1.GoIDMService_GetDownloadTaskFileServer) error {
 	outputReader, err := h.downloadTaskLogic.GetDownloadTaskFile(context.Background(), logic.GetDownloadTaskFileParams{
 		Token:          req.Token,
 		DownloadTaskID: req.GetDownloadTaskId(),
+		Offset:         req.GetOffset(),
+		Length:         req.GetLength(),
 	})
 	if err != nil {
 		return err
@@ -156,4 +202,162 @@ func (h *Handler) GetDownloadTaskFile(req *go_idm_v1.GetDownloadTaskFiletRequest
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+func (h *Handler) GetDownloadTask(ctx context.Context, req *go_idm_v1.GetDownloadTaskRequest) (*go_idm_v1.GetDownloadTaskResponse, error) {
+	output, err := h.downloadTaskLogic.GetDownloadTask(ctx, logic.GetDownloadTaskParams{
+		Token:          req.GetToken(),
+		DownloadTaskID: req.GetDownloadTaskId(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &go_idm_v1.GetDownloadTaskResponse{
+		DownloadTask: output.DownloadTask,
+	}, nil
+}
+
+func (h *Handler) ShareDownloadTask(ctx context.Context, req *go_idm_v1.ShareDownloadTaskRequest) (*go_idm_v1.ShareDownloadTaskResponse, error) {
+	if err := h.downloadTaskLogic.ShareDownloadTask(ctx, logic.ShareDownloadTaskParams{
+		Token:          req.GetToken(),
+		DownloadTaskID: req.GetDownloadTaskId(),
+		AccountID:      req.GetAccountId(),
+		ShareLevel:     req.GetShareLevel(),
+	}); err != nil {
+		return nil, err
+	}
+
+	return &go_idm_v1.ShareDownloadTaskResponse{}, nil
+}
+
+func (h *Handler) UnshareDownloadTask(ctx context.Context, req *go_idm_v1.UnshareDownloadTaskRequest) (*go_idm_v1.UnshareDownloadTaskResponse, error) {
+	if err := h.downloadTaskLogic.UnshareDownloadTask(ctx, logic.UnshareDownloadTaskParams{
+		Token:          req.GetToken(),
+		DownloadTaskID: req.GetDownloadTaskId(),
+		AccountID:      req.GetAccountId(),
+	}); err != nil {
+		return nil, err
+	}
+
+	return &go_idm_v1.UnshareDownloadTaskResponse{}, nil
+}
+
+func (h *Handler) CreateTeam(ctx context.Context, req *go_idm_v1.CreateTeamRequest) (*go_idm_v1.CreateTeamResponse, error) {
+	output, err := h.teamLogic.CreateTeam(ctx, logic.CreateTeamParams{
+		Token:    req.GetToken(),
+		TeamName: req.GetTeamName(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &go_idm_v1.CreateTeamResponse{
+		Team: output.Team,
+	}, nil
+}
+
+func (h *Handler) GetTeamList(ctx context.Context, req *go_idm_v1.GetTeamListRequest) (*go_idm_v1.GetTeamListResponse, error) {
+	output, err := h.teamLogic.GetTeamList(ctx, logic.GetTeamListParams{
+		Token: req.GetToken(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &go_idm_v1.GetTeamListResponse{
+		TeamList: output.TeamList,
+	}, nil
+}
+
+func (h *Handler) AddTeamMember(ctx context.Context, req *go_idm_v1.AddTeamMemberRequest) (*go_idm_v1.AddTeamMemberResponse, error) {
+	if err := h.teamLogic.AddTeamMember(ctx, logic.AddTeamMemberParams{
+		Token:     req.GetToken(),
+		TeamID:    req.GetTeamId(),
+		AccountID: req.GetAccountId(),
+		TeamRole:  req.GetTeamRole(),
+	}); err != nil {
+		return nil, err
+	}
+
+	return &go_idm_v1.AddTeamMemberResponse{}, nil
+}
+
+func (h *Handler) RemoveTeamMember(ctx context.Context, req *go_idm_v1.RemoveTeamMemberRequest) (*go_idm_v1.RemoveTeamMemberResponse, error) {
+	if err := h.teamLogic.RemoveTeamMember(ctx, logic.RemoveTeamMemberParams{
+		Token:     req.GetToken(),
+		TeamID:    req.GetTeamId(),
+		AccountID: req.GetAccountId(),
+	}); err != nil {
+		return nil, err
+	}
+
+	return &go_idm_v1.RemoveTeamMemberResponse{}, nil
+}
+
+func (h *Handler) CreateShareLink(ctx context.Context, req *go_idm_v1.CreateShareLinkRequest) (*go_idm_v1.CreateShareLinkResponse, error) {
+	output, err := h.shareLinkLogic.CreateShareLink(ctx, logic.CreateShareLinkParams{
+		Token:            req.GetToken(),
+		DownloadTaskID:   req.GetDownloadTaskId(),
+		Password:         req.GetPassword(),
+		ExpiresIn:        time.Duration(req.GetExpiresInSeconds()) * time.Second,
+		MaxDownloadCount: req.GetMaxDownloadCount(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &go_idm_v1.CreateShareLinkResponse{
+		ShareLink: output.ShareLink,
+	}, nil
+}
+
+func (h *Handler) RevokeShareLink(ctx context.Context, req *go_idm_v1.RevokeShareLinkRequest) (*go_idm_v1.RevokeShareLinkResponse, error) {
+	if err := h.shareLinkLogic.RevokeShareLink(ctx, logic.RevokeShareLinkParams{
+		Token:       req.GetToken(),
+		ShareLinkID: req.GetShareLinkId(),
+	}); err != nil {
+		return nil, err
+	}
+
+	return &go_idm_v1.RevokeShareLinkResponse{}, nil
+}
+
+func (h *Handler) ListAuditEvents(ctx context.Context, req *go_idm_v1.ListAuditEventsRequest) (*go_idm_v1.ListAuditEventsResponse, error) {
+	output, err := h.auditEventLogic.ListAuditEvents(ctx, logic.ListAuditEventsParams{
+		Token:          req.GetToken(),
+		ActorAccountID: req.GetActorAccountId(),
+		ResourceType:   req.GetResourceType(),
+		ResourceID:     req.GetResourceId(),
+		Offset:         req.GetOffset(),
+		Limit:          req.GetLimit(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &go_idm_v1.ListAuditEventsResponse{
+		AuditEventList:       output.AuditEventList,
+		TotalAuditEventCount: output.Total,
+	}, nil
+}
+
+func (h *Handler) CheckDownloadFiles(ctx context.Context, req *go_idm_v1.CheckDownloadFilesRequest) (*go_idm_v1.CheckDownloadFilesResponse, error) {
+	output, err := h.downloadFileCheckLogic.CheckDownloadFiles(ctx, logic.CheckDownloadFilesParams{
+		Token: req.GetToken(),
+		Options: logic.DownloadFileCheckOptions{
+			MismatchFix:   req.GetMismatchFix(),
+			DeleteOrphans: req.GetDeleteOrphans(),
+			SkipChecksum:  req.GetSkipChecksum(),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &go_idm_v1.CheckDownloadFilesResponse{
+		CheckedDownloadTaskCount: output.CheckedDownloadTaskCount,
+		CheckedFileCount:         output.CheckedFileCount,
+		DownloadFileIssueList:    output.DownloadFileIssueList,
+	}, nil
+}
@@ -2,22 +2,36 @@ package grpc
 
 import (
 	"context"
+	"crypto/subtle"
 	"net"
+	"strings"
 
+	"github.com/google/uuid"
 	"github.com/manhhung2111/go-idm/internal/config"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/database"
 	go_idm_v1 "github.com/manhhung2111/go-idm/internal/generated/proto"
 	"github.com/manhhung2111/go-idm/internal/utils"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	requestIdMetadataKey    = "x-request-id"
+	forwardedForMetadataKey = "x-forwarded-for"
 )
 
 type Server interface {
 	Start(ctx context.Context) error
+	// Stop stops accepting new RPCs and waits for the pending ones, closing their connections once ctx is done.
+	Stop(ctx context.Context) error
 }
 
 type server struct {
 	handler    go_idm_v1.GoIDMServiceServer
 	grpcConfig config.GRPC
+	grpcServer *grpc.Server
 	logger     *zap.Logger
 }
 
@@ -29,10 +43,111 @@ func NewServer(
 	return &server{
 		handler: handler,
 		grpcConfig: grpcConfig,
+		grpcServer: grpc.NewServer(
+			grpc.ChainUnaryInterceptor(requestInfoInterceptor, primaryStickinessInterceptor),
+			grpc.ChainStreamInterceptor(streamRequestInfoInterceptor, streamPrimaryStickinessInterceptor),
+		),
 		logger: logger,
 	}
 }
 
+// requestInfoInterceptor attaches the info of the request to the context of an RPC.
+func requestInfoInterceptor(
+	ctx context.Context,
+	req any,
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	return handler(withRequestInfo(ctx), req)
+}
+
+// streamRequestInfoInterceptor is the requestInfoInterceptor of the streaming RPCs.
+func streamRequestInfoInterceptor(
+	srv any,
+	stream grpc.ServerStream,
+	_ *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	return handler(srv, &contextServerStream{ServerStream: stream, ctx: withRequestInfo(stream.Context())})
+}
+
+// withRequestInfo returns ctx with the info of its request. The request id and the address of the HTTP
+// client are taken from the x-request-id and x-forwarded-for headers forwarded by the HTTP gateway, and only
+// from it as any other client could forge them. Otherwise the request id is generated, and the address is the
+// one of the gRPC client.
+func withRequestInfo(ctx context.Context) context.Context {
+	requestInfo := utils.RequestInfo{}
+
+	incomingMetadata, _ := metadata.FromIncomingContext(ctx)
+	fromGateway := isFromGateway(incomingMetadata)
+
+	if valueList := incomingMetadata.Get(requestIdMetadataKey); fromGateway && len(valueList) > 0 &&
+		valueList[0] != "" {
+		requestInfo.RequestID = valueList[0]
+	} else {
+		requestInfo.RequestID = uuid.NewString()
+	}
+
+	// The gateway appends the address of the HTTP client to the x-forwarded-for header the client sent, the
+	// addresses before it are only what the client claims.
+	if valueList := incomingMetadata.Get(forwardedForMetadataKey); fromGateway && len(valueList) > 0 {
+		addressList := strings.Split(valueList[len(valueList)-1], ",")
+		requestInfo.IPAddress = strings.TrimSpace(addressList[len(addressList)-1])
+	} else if clientPeer, ok := peer.FromContext(ctx); ok {
+		requestInfo.IPAddress = clientPeer.Addr.String()
+		if host, _, err := net.SplitHostPort(requestInfo.IPAddress); err == nil {
+			requestInfo.IPAddress = host
+		}
+	}
+
+	return utils.WithRequestInfo(ctx, requestInfo)
+}
+
+func isFromGateway(incomingMetadata metadata.MD) bool {
+	gatewaySecret := []byte(utils.GatewaySecret())
+	for _, value := range incomingMetadata.Get(utils.GatewaySecretMetadataKey) {
+		if subtle.ConstantTimeCompare([]byte(value), gatewaySecret) == 1 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// primaryStickinessInterceptor makes the reads of an RPC that follow one of its writes run on the primary
+// database rather than on a replica.
+func primaryStickinessInterceptor(
+	ctx context.Context,
+	req any,
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	return handler(database.WithPrimaryStickiness(ctx), req)
+}
+
+// streamPrimaryStickinessInterceptor is the primaryStickinessInterceptor of the streaming RPCs.
+func streamPrimaryStickinessInterceptor(
+	srv any,
+	stream grpc.ServerStream,
+	_ *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	return handler(srv, &contextServerStream{
+		ServerStream: stream,
+		ctx:          database.WithPrimaryStickiness(stream.Context()),
+	})
+}
+
+// contextServerStream is a grpc.ServerStream with the context given by an interceptor.
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (c *contextServerStream) Context() context.Context {
+	return c.ctx
+}
+
 func (s *server) Start(ctx context.Context) error {
 	logger := utils.LoggerWithContext(ctx, s.logger)
 
@@ -44,9 +159,28 @@ func (s *server) Start(ctx context.Context) error {
 
 	defer listener.Close()
 
-	server := grpc.NewServer()
-	go_idm_v1.RegisterGoIDMServiceServer(server, s.handler)
+	go_idm_v1.RegisterGoIDMServiceServer(s.grpcServer, s.handler)
 
 	logger.With(zap.String("address", s.grpcConfig.Address)).Info("starting grpc server")
-	return server.Serve(listener)
+	return s.grpcServer.Serve(listener)
+}
+
+func (s *server) Stop(ctx context.Context) error {
+	logger := utils.LoggerWithContext(ctx, s.logger)
+
+	doneChannel := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(doneChannel)
+	}()
+
+	select {
+	case <-doneChannel:
+		return nil
+	case <-ctx.Done():
+		logger.Warn("grpc server did not stop in time, closing pending rpcs")
+		s.grpcServer.Stop()
+		<-doneChannel
+		return ctx.Err()
+	}
 }
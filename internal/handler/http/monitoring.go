@@ -0,0 +1,115 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/manhhung2111/go-idm/internal/config"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/kafka/consumer"
+	"github.com/manhhung2111/go-idm/internal/logic"
+	"github.com/manhhung2111/go-idm/internal/utils"
+	"go.uber.org/zap"
+)
+
+const (
+	workerPoolPattern = "GET /monitoring/worker-pool"
+)
+
+// MonitoringServer serves the monitoring endpoints of the processes consuming the message queue, which the
+// API server is not.
+type MonitoringServer interface {
+	Start(ctx context.Context) error
+	// Stop stops accepting new requests and waits for the pending ones until ctx is done.
+	Stop(ctx context.Context) error
+}
+
+type monitoringServer struct {
+	workerPool        consumer.WorkerPool
+	connectionLimiter logic.ConnectionLimiter
+	httpServer        *http.Server
+	logger            *zap.Logger
+}
+
+func NewMonitoringServer(
+	httpConfig config.HTTP,
+	workerPool consumer.WorkerPool,
+	connectionLimiter logic.ConnectionLimiter,
+	logger *zap.Logger,
+) MonitoringServer {
+	return &monitoringServer{
+		workerPool:        workerPool,
+		connectionLimiter: connectionLimiter,
+		httpServer: &http.Server{
+			Addr:              httpConfig.GetMonitoringAddress(),
+			ReadHeaderTimeout: time.Minute,
+		},
+		logger: logger,
+	}
+}
+
+func (m *monitoringServer) Start(ctx context.Context) error {
+	logger := utils.LoggerWithContext(ctx, m.logger)
+
+	mux := http.NewServeMux()
+	mux.Handle(workerPoolPattern, newWorkerPoolHandler(m.workerPool, m.connectionLimiter, m.logger))
+
+	m.httpServer.Handler = mux
+
+	logger.With(zap.String("address", m.httpServer.Addr)).Info("starting monitoring http server")
+	if err := m.httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+func (m *monitoringServer) Stop(ctx context.Context) error {
+	logger := utils.LoggerWithContext(ctx, m.logger)
+
+	if err := m.httpServer.Shutdown(ctx); err != nil {
+		logger.With(zap.Error(err)).Warn("monitoring http server did not stop in time, closing pending requests")
+		return m.httpServer.Close()
+	}
+
+	return nil
+}
+
+type workerPoolOccupancy struct {
+	Downloads   utils.LimiterOccupancy `json:"downloads"`
+	Connections utils.LimiterOccupancy `json:"connections"`
+}
+
+// workerPoolHandler reports how much of the download worker pool and of the connection limit of the
+// process are in use, and how many downloads and connections are waiting for them.
+type workerPoolHandler struct {
+	workerPool        consumer.WorkerPool
+	connectionLimiter logic.ConnectionLimiter
+	logger            *zap.Logger
+}
+
+func newWorkerPoolHandler(
+	workerPool consumer.WorkerPool,
+	connectionLimiter logic.ConnectionLimiter,
+	logger *zap.Logger,
+) http.Handler {
+	return &workerPoolHandler{
+		workerPool:        workerPool,
+		connectionLimiter: connectionLimiter,
+		logger:            logger,
+	}
+}
+
+func (w workerPoolHandler) ServeHTTP(responseWriter http.ResponseWriter, r *http.Request) {
+	logger := utils.LoggerWithContext(r.Context(), w.logger)
+
+	responseWriter.Header().Set(httpResponseHeaderContentType, "application/json")
+	if err := json.NewEncoder(responseWriter).Encode(workerPoolOccupancy{
+		Downloads:   w.workerPool.GetOccupancy(),
+		Connections: w.connectionLimiter.GetOccupancy(),
+	}); err != nil {
+		logger.With(zap.Error(err)).Error("failed to write worker pool occupancy")
+	}
+}
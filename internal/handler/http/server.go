@@ -2,44 +2,65 @@ package http
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/manhhung2111/go-idm/internal/config"
 	go_idm_v1 "github.com/manhhung2111/go-idm/internal/generated/proto"
+	"github.com/manhhung2111/go-idm/internal/logic"
 	"github.com/manhhung2111/go-idm/internal/utils"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	requestIdHeader = "X-Request-Id"
 )
 
 type Server interface {
 	Start(ctx context.Context) error
+	// Stop stops accepting new requests and waits for the pending ones until ctx is done.
+	Stop(ctx context.Context) error
 }
 
-type server struct{
-	grpcConfig config.GRPC
-	httpConfig config.HTTP
-	logger     *zap.Logger
+type server struct {
+	grpcConfig     config.GRPC
+	httpConfig     config.HTTP
+	shareLinkLogic logic.ShareLink
+	httpServer     *http.Server
+	logger         *zap.Logger
 }
 
 func NewServer(
 	grpcConfig config.GRPC,
 	httpConfig config.HTTP,
+	shareLinkLogic logic.ShareLink,
 	logger *zap.Logger,
 ) Server {
 	return &server{
-		grpcConfig: grpcConfig,
-		httpConfig: httpConfig,
-		logger:     logger,
+		grpcConfig:     grpcConfig,
+		httpConfig:     httpConfig,
+		shareLinkLogic: shareLinkLogic,
+		httpServer: &http.Server{
+			Addr:              httpConfig.Address,
+			ReadHeaderTimeout: time.Minute,
+		},
+		logger: logger,
 	}
 }
 
 func (s *server) Start(ctx context.Context) error {
 	logger := utils.LoggerWithContext(ctx, s.logger)
 
-	grpcMux := runtime.NewServeMux()
+	grpcMux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher),
+		runtime.WithMetadata(gatewayMetadata),
+	)
 	if err := go_idm_v1.RegisterGoIDMServiceHandlerFromEndpoint(
 		ctx,
 		grpcMux,
@@ -50,12 +71,45 @@ func (s *server) Start(ctx context.Context) error {
 		return err
 	}
 
-	httpServer := http.Server{
-		Addr:              s.httpConfig.Address,
-		ReadHeaderTimeout: time.Minute,
-		Handler:           grpcMux,
-	}
+	mux := http.NewServeMux()
+	mux.Handle("/", grpcMux)
+	shareLinkHandler := newShareLinkHandler(s.shareLinkLogic, s.logger)
+	mux.Handle(shareLinkPattern, shareLinkHandler)
+	mux.Handle(shareLinkPostPattern, shareLinkHandler)
+
+	s.httpServer.Handler = mux
 
 	logger.With(zap.String("address", s.httpConfig.Address)).Info("starting http server")
-	return httpServer.ListenAndServe()
+	if err := s.httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// incomingHeaderMatcher forwards the request id header to the gRPC server along with the headers forwarded by
+// default, for the request to keep the id the client gave it.
+func incomingHeaderMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, requestIdHeader) {
+		return strings.ToLower(requestIdHeader), true
+	}
+
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// gatewayMetadata proves to the gRPC server that the RPC comes from the gateway, for it to trust the headers
+// forwarded with it.
+func gatewayMetadata(context.Context, *http.Request) metadata.MD {
+	return metadata.Pairs(utils.GatewaySecretMetadataKey, utils.GatewaySecret())
+}
+
+func (s *server) Stop(ctx context.Context) error {
+	logger := utils.LoggerWithContext(ctx, s.logger)
+
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		logger.With(zap.Error(err)).Warn("http server did not stop in time, closing pending requests")
+		return s.httpServer.Close()
+	}
+
+	return nil
 }
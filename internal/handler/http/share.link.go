@@ -0,0 +1,78 @@
+package http
+
+import (
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/manhhung2111/go-idm/internal/logic"
+	"github.com/manhhung2111/go-idm/internal/utils"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	shareLinkPattern              = "GET /share-links/{link_token}"
+	shareLinkPostPattern          = "POST /share-links/{link_token}"
+	shareLinkPathValueLinkToken   = "link_token"
+	shareLinkFormValuePassword    = "password"
+	shareLinkHeaderPassword       = "X-Share-Link-Password"
+	httpResponseHeaderDisposition = "Content-Disposition"
+	httpResponseHeaderContentType = "Content-Type"
+)
+
+// shareLinkHandler serves the files behind public share links. It is deliberately not exposed through gRPC,
+// as the people opening these links do not have an account.
+type shareLinkHandler struct {
+	shareLinkLogic logic.ShareLink
+	logger         *zap.Logger
+}
+
+func newShareLinkHandler(
+	shareLinkLogic logic.ShareLink,
+	logger *zap.Logger,
+) http.Handler {
+	return &shareLinkHandler{
+		shareLinkLogic: shareLinkLogic,
+		logger:         logger,
+	}
+}
+
+func (s shareLinkHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := utils.LoggerWithContext(ctx, s.logger)
+
+	// The password is never read from the query, as URLs end up in the access logs and the browser history. A
+	// browser without a way to set the header posts it as a form instead.
+	password := r.Header.Get(shareLinkHeaderPassword)
+	if password == "" && r.Method == http.MethodPost {
+		password = r.PostFormValue(shareLinkFormValuePassword)
+	}
+
+	output, err := s.shareLinkLogic.GetShareLinkFile(ctx, logic.GetShareLinkFileParams{
+		LinkToken: r.PathValue(shareLinkPathValueLinkToken),
+		Password:  password,
+	})
+	if err != nil {
+		errStatus := status.Convert(err)
+		http.Error(w, errStatus.Message(), runtime.HTTPStatusFromCode(errStatus.Code()))
+		return
+	}
+
+	if output.RedirectURL != "" {
+		http.Redirect(w, r, output.RedirectURL, http.StatusFound)
+		return
+	}
+
+	defer output.Reader.Close()
+
+	w.Header().Set(httpResponseHeaderContentType, output.ContentType)
+	w.Header().Set(httpResponseHeaderDisposition, mime.FormatMediaType("attachment", map[string]string{
+		"filename": output.FileName,
+	}))
+
+	if _, err = io.Copy(w, output.Reader); err != nil {
+		logger.With(zap.Error(err)).Warn("failed to stream share link file")
+	}
+}
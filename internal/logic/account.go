@@ -39,6 +39,7 @@ type account struct {
 	goquDatabase                *goqu.Database
 	accountDataAccessor         database.AccountDataAccessor
 	accountPasswordDataAccessor database.AccountPasswordDataAccessor
+	auditEventDataAccessor      database.AuditEventDataAccessor
 	hashLogic                   Hash
 	tokenLogic                  Token
 	accountNameCache            cache.AccountNameCache
@@ -49,6 +50,7 @@ func NewAccount(
 	goquDatabase *goqu.Database,
 	accountDataAccessor database.AccountDataAccessor,
 	accountPasswordDataAccessor database.AccountPasswordDataAccessor,
+	auditEventDataAccessor database.AuditEventDataAccessor,
 	hashLogic Hash,
 	tokenLogic Token,
 	accountNameCache cache.AccountNameCache,
@@ -58,6 +60,7 @@ func NewAccount(
 		goquDatabase:                goquDatabase,
 		accountDataAccessor:         accountDataAccessor,
 		accountPasswordDataAccessor: accountPasswordDataAccessor,
+		auditEventDataAccessor:      auditEventDataAccessor,
 		hashLogic:                   hashLogic,
 		tokenLogic:                  tokenLogic,
 		accountNameCache:            accountNameCache,
@@ -109,7 +112,11 @@ func (a *account) CreateAccount(ctx context.Context, params CreateAccountParams)
 			return nil
 		}
 
-		return nil
+		return recordAuditEvent(
+			ctx, a.auditEventDataAccessor.WithDatabase(td), &accountId,
+			AuditEventActionAccountCreate, AuditEventResourceTypeAccount, accountId,
+			nil, map[string]any{"account_name": params.AccountName},
+		)
 	})
 
 	if txError != nil {
@@ -152,6 +159,14 @@ func (a *account) CreateSession(ctx context.Context, params CreateSessionParams)
 	if err != nil {
 		return "", err
 	}
+
+	if err = recordAuditEvent(
+		ctx, a.auditEventDataAccessor, &existingAccount.ID,
+		AuditEventActionSessionCreate, AuditEventResourceTypeSession, existingAccount.ID,
+		nil, nil,
+	); err != nil {
+		return "", err
+	}
 	
 	return token, nil
 }
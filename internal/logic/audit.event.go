@@ -0,0 +1,197 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/manhhung2111/go-idm/internal/config"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/database"
+	go_idm_v1 "github.com/manhhung2111/go-idm/internal/generated/proto"
+	"github.com/manhhung2111/go-idm/internal/utils"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	AuditEventResourceTypeAccount      = "account"
+	AuditEventResourceTypeSession      = "session"
+	AuditEventResourceTypeDownloadTask = "download_task"
+
+	AuditEventActionAccountCreate       = "account.create"
+	AuditEventActionSessionCreate       = "session.create"
+	AuditEventActionDownloadTaskCreate  = "download_task.create"
+	AuditEventActionDownloadTaskUpdate  = "download_task.update"
+	AuditEventActionDownloadTaskDelete  = "download_task.delete"
+	AuditEventActionDownloadTaskRestore = "download_task.restore"
+	AuditEventActionDownloadTaskPurge   = "download_task.purge"
+	AuditEventActionDownloadTaskRepair  = "download_task.repair"
+	AuditEventActionDownloadTaskShare   = "download_task.share"
+	AuditEventActionDownloadTaskUnshare = "download_task.unshare"
+)
+
+type ListAuditEventsParams struct {
+	Token          string
+	ActorAccountID uint64
+	ResourceType   string
+	ResourceID     uint64
+	Offset         uint64
+	Limit          uint64
+}
+
+type ListAuditEventsOutput struct {
+	AuditEventList []*go_idm_v1.AuditEvent
+	Total          uint64
+}
+
+type AuditEvent interface {
+	// ListAuditEvents lists the audit events matching the params, the latest first. Admins can list the
+	// audit events of every account, other accounts only the ones of what they did.
+	ListAuditEvents(ctx context.Context, params ListAuditEventsParams) (ListAuditEventsOutput, error)
+}
+
+type auditEvent struct {
+	tokenLogic             Token
+	accountDataAccessor    database.AccountDataAccessor
+	auditEventDataAccessor database.AuditEventDataAccessor
+	authConfig             config.Auth
+	logger                 *zap.Logger
+}
+
+func NewAuditEvent(
+	tokenLogic Token,
+	accountDataAccessor database.AccountDataAccessor,
+	auditEventDataAccessor database.AuditEventDataAccessor,
+	authConfig config.Auth,
+	logger *zap.Logger,
+) AuditEvent {
+	return &auditEvent{
+		tokenLogic:             tokenLogic,
+		accountDataAccessor:    accountDataAccessor,
+		auditEventDataAccessor: auditEventDataAccessor,
+		authConfig:             authConfig,
+		logger:                 logger,
+	}
+}
+
+// ListAuditEvents implements AuditEvent.
+func (a *auditEvent) ListAuditEvents(ctx context.Context, params ListAuditEventsParams) (ListAuditEventsOutput, error) {
+	accountId, _, err := a.tokenLogic.GetAccountIDAndExpireTime(ctx, params.Token)
+	if err != nil {
+		return ListAuditEventsOutput{}, err
+	}
+
+	account, err := a.accountDataAccessor.GetAccountById(ctx, accountId)
+	if err != nil {
+		return ListAuditEventsOutput{}, err
+	}
+
+	filter := database.AuditEventFilter{
+		ResourceType: params.ResourceType,
+	}
+
+	if params.ActorAccountID != 0 {
+		filter.ActorAccountID = &params.ActorAccountID
+	}
+
+	if params.ResourceID != 0 {
+		filter.ResourceID = &params.ResourceID
+	}
+
+	if !a.authConfig.IsAdminAccountName(account.AccountName) {
+		if filter.ActorAccountID != nil && *filter.ActorAccountID != accountId {
+			return ListAuditEventsOutput{}, status.Error(codes.PermissionDenied, "trying to list the audit events of another account")
+		}
+
+		filter.ActorAccountID = &accountId
+	}
+
+	total, err := a.auditEventDataAccessor.GetAuditEventCount(ctx, filter)
+	if err != nil {
+		return ListAuditEventsOutput{}, err
+	}
+
+	auditEventList, err := a.auditEventDataAccessor.GetAuditEventList(ctx, filter, params.Offset, params.Limit)
+	if err != nil {
+		return ListAuditEventsOutput{}, err
+	}
+
+	return ListAuditEventsOutput{
+		AuditEventList: lo.Map(auditEventList, func(item database.AuditEvent, _ int) *go_idm_v1.AuditEvent {
+			return a.databaseAuditEventToProtoAuditEvent(ctx, item)
+		}),
+		Total: total,
+	}, nil
+}
+
+func (a auditEvent) databaseAuditEventToProtoAuditEvent(
+	ctx context.Context,
+	auditEvent database.AuditEvent,
+) *go_idm_v1.AuditEvent {
+	return &go_idm_v1.AuditEvent{
+		Id:             auditEvent.ID,
+		ActorAccountId: lo.FromPtr(auditEvent.ActorAccountID),
+		Action:         auditEvent.Action,
+		ResourceType:   auditEvent.ResourceType,
+		ResourceId:     auditEvent.ResourceID,
+		BeforeValue:    a.auditEventValueToString(ctx, auditEvent.BeforeValue),
+		AfterValue:     a.auditEventValueToString(ctx, auditEvent.AfterValue),
+		IpAddress:      auditEvent.IPAddress,
+		RequestId:      auditEvent.RequestID,
+		CreatedTime:    uint64(auditEvent.CreatedTime.Unix()),
+	}
+}
+
+func (a auditEvent) auditEventValueToString(ctx context.Context, value database.JSON) string {
+	if value.Data == nil {
+		return ""
+	}
+
+	valueBytes, err := json.Marshal(value.Data)
+	if err != nil {
+		utils.LoggerWithContext(ctx, a.logger).With(zap.Error(err)).Warn("failed to marshal audit event value")
+		return ""
+	}
+
+	return string(valueBytes)
+}
+
+// recordAuditEvent appends an audit event, with the info of the request of ctx. Call it with a data accessor
+// bound to the transaction of the change, so that the change is not made without being recorded. The actor is
+// nil for the changes made by go-idm itself or from the command line.
+func recordAuditEvent(
+	ctx context.Context,
+	auditEventDataAccessor database.AuditEventDataAccessor,
+	actorAccountId *uint64,
+	action string,
+	resourceType string,
+	resourceId uint64,
+	beforeValue any,
+	afterValue any,
+) error {
+	requestInfo := utils.RequestInfoFromContext(ctx)
+	_, err := auditEventDataAccessor.CreateAuditEvent(ctx, database.AuditEvent{
+		ActorAccountID: actorAccountId,
+		Action:         action,
+		ResourceType:   resourceType,
+		ResourceID:     resourceId,
+		BeforeValue:    database.JSON{Data: beforeValue},
+		AfterValue:     database.JSON{Data: afterValue},
+		IPAddress:      requestInfo.IPAddress,
+		RequestID:      requestInfo.RequestID,
+		CreatedTime:    time.Now().UTC(),
+	})
+	return err
+}
+
+// downloadTaskAuditEventValue is the value of a download task recorded in the audit trail.
+func downloadTaskAuditEventValue(downloadTask database.DownloadTask) map[string]any {
+	return map[string]any{
+		"download_type": downloadTask.DownloadType.String(),
+		"url":           downloadTask.URL,
+		"of_account_id": downloadTask.OfAccountID,
+		"of_team_id":    lo.FromPtr(downloadTask.OfTeamID),
+	}
+}
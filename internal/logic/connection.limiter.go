@@ -0,0 +1,18 @@
+package logic
+
+import (
+	"github.com/manhhung2111/go-idm/internal/config"
+	"github.com/manhhung2111/go-idm/internal/utils"
+)
+
+// ConnectionLimiter bounds the connections opened by the downloaders of a process, across all the download
+// tasks it executes. A downloader waits for a free connection before opening a new one.
+type ConnectionLimiter interface {
+	utils.Limiter
+}
+
+func NewConnectionLimiter(
+	downloadConfig config.Download,
+) ConnectionLimiter {
+	return utils.NewLimiter(downloadConfig.WorkerPool.GetMaxConnections())
+}
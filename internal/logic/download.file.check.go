@@ -0,0 +1,474 @@
+package logic
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/manhhung2111/go-idm/internal/config"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/database"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/file"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/kafka/producer"
+	go_idm_v1 "github.com/manhhung2111/go-idm/internal/generated/proto"
+	"github.com/manhhung2111/go-idm/internal/utils"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	downloadFileCheckBatchSize = 100
+)
+
+// DownloadFileCheckOptions chooses what a check of the download files fixes. By default, the issues found are
+// only reported.
+type DownloadFileCheckOptions struct {
+	// MismatchFix is applied to the succeeded download tasks whose file is missing, or does not match the
+	// size and checksum recorded when it was downloaded.
+	MismatchFix go_idm_v1.DownloadFileMismatchFix
+	// DeleteOrphans deletes the orphaned files, the same the download file reconciler deletes.
+	DeleteOrphans bool
+	// SkipChecksum only compares the size of the files, without reading them to compute their checksum.
+	SkipChecksum bool
+}
+
+type DownloadFileCheckOutput struct {
+	CheckedDownloadTaskCount uint64
+	CheckedFileCount         uint64
+	DownloadFileIssueList    []*go_idm_v1.DownloadFileIssue
+}
+
+type CheckDownloadFilesParams struct {
+	Token   string
+	Options DownloadFileCheckOptions
+}
+
+// DownloadFileCheck checks that the storage matches the download tasks: that the file of every succeeded download
+// task is stored with the size and checksum recorded when it was downloaded, and that every file written for a
+// download task still belongs to one.
+type DownloadFileCheck interface {
+	// CheckDownloadFiles runs the check for the admin account of params.Token.
+	CheckDownloadFiles(ctx context.Context, params CheckDownloadFilesParams) (DownloadFileCheckOutput, error)
+	// Check runs the check on behalf of no account, for the command line.
+	Check(ctx context.Context, options DownloadFileCheckOptions) (DownloadFileCheckOutput, error)
+}
+
+type downloadFileCheck struct {
+	tokenLogic                  Token
+	accountDataAccessor         database.AccountDataAccessor
+	downloadTaskDataAccessor    database.DownloadTaskDataAccessor
+	auditEventDataAccessor      database.AuditEventDataAccessor
+	downloadTaskCreatedProducer producer.DownloadTaskCreatedProducer
+	downloadTaskEventProducer   producer.DownloadTaskEventProducer
+	goquDatabase                *goqu.Database
+	storageSet                  file.StorageSet
+	orphanedDownloadFileFinder  *orphanedDownloadFileFinder
+	authConfig                  config.Auth
+	logger                      *zap.Logger
+}
+
+func NewDownloadFileCheck(
+	tokenLogic Token,
+	accountDataAccessor database.AccountDataAccessor,
+	downloadTaskDataAccessor database.DownloadTaskDataAccessor,
+	auditEventDataAccessor database.AuditEventDataAccessor,
+	downloadTaskCreatedProducer producer.DownloadTaskCreatedProducer,
+	downloadTaskEventProducer producer.DownloadTaskEventProducer,
+	goquDatabase *goqu.Database,
+	storageSet file.StorageSet,
+	downloadConfig config.Download,
+	authConfig config.Auth,
+	logger *zap.Logger,
+) (DownloadFileCheck, error) {
+	orphanedDownloadFileFinder, err := newOrphanedDownloadFileFinder(
+		downloadTaskDataAccessor, storageSet, downloadConfig, logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &downloadFileCheck{
+		tokenLogic:                  tokenLogic,
+		accountDataAccessor:         accountDataAccessor,
+		downloadTaskDataAccessor:    downloadTaskDataAccessor,
+		auditEventDataAccessor:      auditEventDataAccessor,
+		downloadTaskCreatedProducer: downloadTaskCreatedProducer,
+		downloadTaskEventProducer:   downloadTaskEventProducer,
+		goquDatabase:                goquDatabase,
+		storageSet:                  storageSet,
+		orphanedDownloadFileFinder:  orphanedDownloadFileFinder,
+		authConfig:                  authConfig,
+		logger:                      logger,
+	}, nil
+}
+
+// CheckDownloadFiles implements DownloadFileCheck.
+func (d *downloadFileCheck) CheckDownloadFiles(
+	ctx context.Context,
+	params CheckDownloadFilesParams,
+) (DownloadFileCheckOutput, error) {
+	accountId, _, err := d.tokenLogic.GetAccountIDAndExpireTime(ctx, params.Token)
+	if err != nil {
+		return DownloadFileCheckOutput{}, err
+	}
+
+	account, err := d.accountDataAccessor.GetAccountById(ctx, accountId)
+	if err != nil {
+		return DownloadFileCheckOutput{}, err
+	}
+
+	if !d.authConfig.IsAdminAccountName(account.AccountName) {
+		return DownloadFileCheckOutput{}, status.Error(codes.PermissionDenied, "only admins can check the download files")
+	}
+
+	return d.check(ctx, &accountId, params.Options)
+}
+
+// Check implements DownloadFileCheck.
+func (d *downloadFileCheck) Check(ctx context.Context, options DownloadFileCheckOptions) (DownloadFileCheckOutput, error) {
+	return d.check(ctx, nil, options)
+}
+
+func (d *downloadFileCheck) check(
+	ctx context.Context,
+	actorAccountId *uint64,
+	options DownloadFileCheckOptions,
+) (DownloadFileCheckOutput, error) {
+	if _, ok := go_idm_v1.DownloadFileMismatchFix_name[int32(options.MismatchFix)]; !ok {
+		return DownloadFileCheckOutput{}, status.Errorf(codes.InvalidArgument, "unsupported download file mismatch fix: %d", options.MismatchFix)
+	}
+
+	output := DownloadFileCheckOutput{
+		DownloadFileIssueList: make([]*go_idm_v1.DownloadFileIssue, 0),
+	}
+
+	if err := d.checkDownloadTaskFiles(ctx, actorAccountId, options, &output); err != nil {
+		return DownloadFileCheckOutput{}, err
+	}
+
+	if err := d.checkOrphanedFiles(ctx, options, &output); err != nil {
+		return DownloadFileCheckOutput{}, err
+	}
+
+	return output, nil
+}
+
+// checkDownloadTaskFiles checks the file of every succeeded download task not in the trash.
+func (d *downloadFileCheck) checkDownloadTaskFiles(
+	ctx context.Context,
+	actorAccountId *uint64,
+	options DownloadFileCheckOptions,
+	output *DownloadFileCheckOutput,
+) error {
+	var afterId uint64
+	for {
+		downloadTaskList, err := d.downloadTaskDataAccessor.GetDownloadTaskListOfStatusAfterId(
+			ctx, go_idm_v1.DownloadStatus_Succeeded, false, afterId, downloadFileCheckBatchSize,
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, downloadTask := range downloadTaskList {
+			issue, err := d.checkDownloadTaskFile(ctx, downloadTask, options)
+			if err != nil {
+				return err
+			}
+
+			output.CheckedDownloadTaskCount++
+			if issue == nil {
+				continue
+			}
+
+			if options.MismatchFix != go_idm_v1.DownloadFileMismatchFix_UndefinedDownloadFileMismatchFix {
+				issue.Fixed, err = d.fixDownloadTask(ctx, actorAccountId, downloadTask.ID, issue, options.MismatchFix)
+				if err != nil {
+					return err
+				}
+			}
+
+			output.DownloadFileIssueList = append(output.DownloadFileIssueList, issue)
+		}
+
+		if uint64(len(downloadTaskList)) < downloadFileCheckBatchSize {
+			return nil
+		}
+
+		afterId = downloadTaskList[len(downloadTaskList)-1].ID
+	}
+}
+
+// checkDownloadTaskFile returns the issue of the file of a succeeded download task, or nil if there is none.
+func (d *downloadFileCheck) checkDownloadTaskFile(
+	ctx context.Context,
+	downloadTask database.DownloadTask,
+	options DownloadFileCheckOptions,
+) (*go_idm_v1.DownloadFileIssue, error) {
+	issue := &go_idm_v1.DownloadFileIssue{
+		DownloadTaskId: downloadTask.ID,
+	}
+
+	fileName, err := getDownloadTaskFileName(downloadTask)
+	if err != nil {
+		issue.Kind = go_idm_v1.DownloadFileIssueKind_FileMetadataInvalid
+		return issue, nil
+	}
+
+	issue.FileName = fileName
+	downloadTaskMetadata := downloadTask.Metadata.Data.(map[string]any)
+	// The size of a compressed file is checked against its size once compressed, as it is stored.
+	fileSizeFieldName := downloadTaskMetadataFieldNameFileSize
+	if getDownloadTaskCompressionCodec(downloadTask) != "" {
+		fileSizeFieldName = downloadTaskMetadataFieldNameCompressedFileSize
+	}
+
+	expectedFileSize, ok := downloadTaskMetadata[fileSizeFieldName].(float64)
+	if !ok {
+		issue.Kind = go_idm_v1.DownloadFileIssueKind_FileMetadataInvalid
+		return issue, nil
+	}
+
+	issue.ExpectedFileSize = uint64(expectedFileSize)
+	issue.ExpectedChecksum, _ = downloadTaskMetadata[downloadTaskMetadataFieldNameChecksum].(string)
+
+	// The download task points to a storage that is not configured.
+	fileClient, err := getDownloadTaskFileClient(d.storageSet, downloadTask)
+	if err != nil {
+		issue.Kind = go_idm_v1.DownloadFileIssueKind_FileMetadataInvalid
+		return issue, nil
+	}
+
+	fileInfo, err := fileClient.Stat(ctx, fileName)
+	if err != nil {
+		if errors.Is(err, file.ErrFileNotFound) {
+			issue.Kind = go_idm_v1.DownloadFileIssueKind_FileMissing
+			return issue, nil
+		}
+
+		return nil, err
+	}
+
+	issue.ActualFileSize = fileInfo.Size
+	if issue.ActualFileSize != issue.ExpectedFileSize {
+		issue.Kind = go_idm_v1.DownloadFileIssueKind_FileSizeMismatch
+		return issue, nil
+	}
+
+	// The download tasks downloaded before checksums were recorded can only be checked by size.
+	if options.SkipChecksum || issue.ExpectedChecksum == "" {
+		return nil, nil
+	}
+
+	issue.ActualChecksum, err = getDownloadTaskFileChecksum(ctx, fileClient, downloadTask, fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	if issue.ActualChecksum != issue.ExpectedChecksum {
+		issue.Kind = go_idm_v1.DownloadFileIssueKind_FileChecksumMismatch
+		return issue, nil
+	}
+
+	return nil, nil
+}
+
+// getDownloadTaskFileChecksum reads the file of a download task, decompressed if it is compressed, to compute
+// its checksum as recorded in the metadata of download tasks.
+func getDownloadTaskFileChecksum(
+	ctx context.Context,
+	fileClient file.Client,
+	downloadTask database.DownloadTask,
+	fileName string,
+) (string, error) {
+	fileReadCloser, err := readDownloadTaskFile(ctx, fileClient, downloadTask, fileName, 0, 0)
+	if err != nil {
+		return "", err
+	}
+
+	return getReadCloserChecksum(fileReadCloser, fileName)
+}
+
+func getReadCloserChecksum(fileReadCloser io.ReadCloser, fileName string) (string, error) {
+	defer fileReadCloser.Close()
+
+	fileChecksumHash := sha256.New()
+	if _, err := io.Copy(fileChecksumHash, fileReadCloser); err != nil {
+		return "", status.Errorf(codes.Internal, "failed to read file %s: %s", fileName, err)
+	}
+
+	return hex.EncodeToString(fileChecksumHash.Sum(nil)), nil
+}
+
+// fixDownloadTask applies mismatchFix to a download task whose file has an issue. It returns false without
+// changing anything if the download task changed since it was checked.
+func (d *downloadFileCheck) fixDownloadTask(
+	ctx context.Context,
+	actorAccountId *uint64,
+	id uint64,
+	issue *go_idm_v1.DownloadFileIssue,
+	mismatchFix go_idm_v1.DownloadFileMismatchFix,
+) (bool, error) {
+	logger := utils.LoggerWithContext(ctx, d.logger).
+		With(zap.Uint64("id", id)).
+		With(zap.Any("kind", issue.Kind)).
+		With(zap.Any("mismatch_fix", mismatchFix))
+
+	fixed := false
+	txErr := d.goquDatabase.WithTx(func(td *goqu.TxDatabase) error {
+		downloadTaskDataAccessor := d.downloadTaskDataAccessor.WithDatabase(td)
+		downloadTaskEventProducer := d.downloadTaskEventProducer.WithDatabase(td)
+
+		downloadTask, err := downloadTaskDataAccessor.GetDownloadTaskWithXLock(ctx, id)
+		if err != nil {
+			if errors.Is(err, database.ErrDownloadTaskNotFound) {
+				return nil
+			}
+
+			return err
+		}
+
+		if downloadTask.DownloadStatus != go_idm_v1.DownloadStatus_Succeeded || downloadTask.IsTrashed() {
+			logger.Warn("download task changed since its file was checked, will not fix")
+			return nil
+		}
+
+		switch mismatchFix {
+		case go_idm_v1.DownloadFileMismatchFix_MarkFailed:
+			downloadTask.DownloadStatus = go_idm_v1.DownloadStatus_Failed
+			if err = downloadTaskDataAccessor.UpdateDownloadTask(ctx, downloadTask); err != nil {
+				return err
+			}
+
+			if err = downloadTaskEventProducer.SendStatusChanged(ctx, newDownloadTaskStatusChangedEvent(
+				downloadTask, go_idm_v1.DownloadStatus_Succeeded,
+			)); err != nil {
+				return err
+			}
+
+			if err = downloadTaskEventProducer.SendFailed(ctx, &go_idm_v1.DownloadTaskFailedEvent{
+				DownloadTaskId: downloadTask.ID,
+				OfAccountId:    downloadTask.OfAccountID,
+				OfTeamId:       lo.FromPtr(downloadTask.OfTeamID),
+				Error:          fmt.Sprintf("stored file check failed: %s", issue.Kind),
+			}); err != nil {
+				return err
+			}
+
+		case go_idm_v1.DownloadFileMismatchFix_Reenqueue:
+			if err = putDownloadTaskBackIntoPending(
+				ctx, downloadTaskDataAccessor, downloadTaskEventProducer,
+				d.downloadTaskCreatedProducer.WithDatabase(td), downloadTask,
+			); err != nil {
+				return err
+			}
+
+			downloadTask.DownloadStatus = go_idm_v1.DownloadStatus_Pending
+
+		default:
+			return status.Errorf(codes.InvalidArgument, "unsupported download file mismatch fix: %s", mismatchFix)
+		}
+
+		logger.Info("fixed download task with a download file issue")
+		fixed = true
+		return recordAuditEvent(
+			ctx, d.auditEventDataAccessor.WithDatabase(td), actorAccountId,
+			AuditEventActionDownloadTaskRepair, AuditEventResourceTypeDownloadTask, downloadTask.ID,
+			map[string]any{"download_status": go_idm_v1.DownloadStatus_Succeeded.String()},
+			map[string]any{"download_status": downloadTask.DownloadStatus.String(), "issue": issue.Kind.String()},
+		)
+	})
+	if txErr != nil {
+		return false, txErr
+	}
+
+	return fixed, nil
+}
+
+// checkOrphanedFiles reports the orphaned files of every storage, deleting them if asked to. The files are
+// told apart as by the download file reconciler: a file older than its min age is orphaned if its download
+// task does not exist anymore, trashed download tasks included, or if it is a copy left in another storage
+// than the one its download task records.
+func (d *downloadFileCheck) checkOrphanedFiles(
+	ctx context.Context,
+	options DownloadFileCheckOptions,
+	output *DownloadFileCheckOutput,
+) error {
+	for _, storageName := range d.storageSet.GetStorageNameList() {
+		fileClient, err := d.storageSet.GetClient(storageName)
+		if err != nil {
+			return err
+		}
+
+		if err = d.checkOrphanedFilesOfStorage(ctx, storageName, fileClient, options, output); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *downloadFileCheck) checkOrphanedFilesOfStorage(
+	ctx context.Context,
+	storageName string,
+	fileClient file.Client,
+	options DownloadFileCheckOptions,
+	output *DownloadFileCheckOutput,
+) error {
+	modifiedTimeLimit := d.orphanedDownloadFileFinder.getModifiedTimeLimit()
+	fileInfoBatch := make(map[uint64]file.FileInfo, downloadFileCheckBatchSize)
+	checkFileInfoBatch := func() error {
+		orphanedDownloadTaskIdMap, err := d.orphanedDownloadFileFinder.
+			getOrphanedDownloadTaskIdMap(ctx, storageName, lo.Keys(fileInfoBatch))
+		if err != nil {
+			return err
+		}
+
+		for downloadTaskId := range orphanedDownloadTaskIdMap {
+			fileInfo := fileInfoBatch[downloadTaskId]
+			issue := &go_idm_v1.DownloadFileIssue{
+				Kind:           go_idm_v1.DownloadFileIssueKind_FileOrphaned,
+				DownloadTaskId: downloadTaskId,
+				FileName:       fileInfo.Path,
+				ActualFileSize: fileInfo.Size,
+			}
+
+			if options.DeleteOrphans {
+				if err = fileClient.Delete(ctx, fileInfo.Path); err != nil {
+					return err
+				}
+
+				issue.Fixed = true
+			}
+
+			output.DownloadFileIssueList = append(output.DownloadFileIssueList, issue)
+		}
+
+		clear(fileInfoBatch)
+		return nil
+	}
+
+	if err := fileClient.Walk(ctx, func(fileInfo file.FileInfo) error {
+		output.CheckedFileCount++
+
+		downloadTaskId, ok := getOrphanCandidateDownloadTaskId(fileInfo, modifiedTimeLimit)
+		if !ok {
+			return nil
+		}
+
+		fileInfoBatch[downloadTaskId] = fileInfo
+		if len(fileInfoBatch) < downloadFileCheckBatchSize {
+			return nil
+		}
+
+		return checkFileInfoBatch()
+	}); err != nil {
+		return err
+	}
+
+	return checkFileInfoBatch()
+}
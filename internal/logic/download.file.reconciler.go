@@ -0,0 +1,307 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/manhhung2111/go-idm/internal/config"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/database"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/file"
+	"github.com/manhhung2111/go-idm/internal/utils"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+// DownloadFileReconciler deletes the orphaned files of the storages: the files written for a download task
+// that does not exist anymore, typically because deleting the file failed after the download task was
+// purged, and the files of a download task in another storage than the one its metadata records, such as the
+// copies a storage migration or the tiering job left in the source storage. The files whose name was not
+// given by go-idm are left alone, they may belong to someone else.
+type DownloadFileReconciler interface {
+	Start(ctx context.Context) error
+}
+
+type downloadFileReconciler struct {
+	orphanedDownloadFileFinder *orphanedDownloadFileFinder
+	storageSet                 file.StorageSet
+	reconcilerInterval         time.Duration
+	batchSize                  uint64
+	logger                     *zap.Logger
+}
+
+func NewDownloadFileReconciler(
+	downloadTaskDataAccessor database.DownloadTaskDataAccessor,
+	storageSet file.StorageSet,
+	downloadConfig config.Download,
+	logger *zap.Logger,
+) (DownloadFileReconciler, error) {
+	reconcilerInterval, err := downloadConfig.OrphanReconciler.GetIntervalDuration()
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to parse download orphan reconciler interval")
+		return nil, err
+	}
+
+	orphanedDownloadFileFinder, err := newOrphanedDownloadFileFinder(
+		downloadTaskDataAccessor, storageSet, downloadConfig, logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &downloadFileReconciler{
+		orphanedDownloadFileFinder: orphanedDownloadFileFinder,
+		storageSet:                 storageSet,
+		reconcilerInterval:         reconcilerInterval,
+		batchSize:                  downloadConfig.OrphanReconciler.GetBatchSize(),
+		logger:                     logger,
+	}, nil
+}
+
+// Start implements DownloadFileReconciler.
+func (d *downloadFileReconciler) Start(ctx context.Context) error {
+	logger := utils.LoggerWithContext(ctx, d.logger)
+
+	ticker := time.NewTicker(d.reconcilerInterval)
+	defer ticker.Stop()
+
+	for {
+		deletedCount, err := d.reconcile(ctx)
+		if err != nil && ctx.Err() == nil {
+			logger.With(zap.Error(err)).Error("failed to reconcile orphaned download files")
+		}
+
+		if deletedCount > 0 {
+			logger.With(zap.Uint64("count", deletedCount)).Info("deleted orphaned download files")
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// reconcile walks the storages once, deleting the orphaned files it finds, and returns how many it deleted.
+func (d *downloadFileReconciler) reconcile(ctx context.Context) (uint64, error) {
+	var deletedCount uint64
+	for _, storageName := range d.storageSet.GetStorageNameList() {
+		fileClient, err := d.storageSet.GetClient(storageName)
+		if err != nil {
+			return deletedCount, err
+		}
+
+		storageDeletedCount, err := d.reconcileStorage(ctx, storageName, fileClient)
+		deletedCount += storageDeletedCount
+		if err != nil {
+			return deletedCount, err
+		}
+	}
+
+	return deletedCount, nil
+}
+
+func (d *downloadFileReconciler) reconcileStorage(
+	ctx context.Context,
+	storageName string,
+	fileClient file.Client,
+) (uint64, error) {
+	var (
+		deletedCount      uint64
+		modifiedTimeLimit = d.orphanedDownloadFileFinder.getModifiedTimeLimit()
+		fileNameBatch     = make(map[uint64]string, d.batchSize)
+	)
+
+	err := fileClient.Walk(ctx, func(fileInfo file.FileInfo) error {
+		downloadTaskId, ok := getOrphanCandidateDownloadTaskId(fileInfo, modifiedTimeLimit)
+		if !ok {
+			return nil
+		}
+
+		fileNameBatch[downloadTaskId] = fileInfo.Path
+		if uint64(len(fileNameBatch)) < d.batchSize {
+			return nil
+		}
+
+		batchDeletedCount, err := d.deleteOrphanedFileBatch(ctx, storageName, fileClient, fileNameBatch)
+		deletedCount += batchDeletedCount
+		clear(fileNameBatch)
+		return err
+	})
+	if err != nil {
+		return deletedCount, err
+	}
+
+	batchDeletedCount, err := d.deleteOrphanedFileBatch(ctx, storageName, fileClient, fileNameBatch)
+	return deletedCount + batchDeletedCount, err
+}
+
+// deleteOrphanedFileBatch deletes the orphaned files of fileNameBatch, keyed by the id of their download task.
+func (d *downloadFileReconciler) deleteOrphanedFileBatch(
+	ctx context.Context,
+	storageName string,
+	fileClient file.Client,
+	fileNameBatch map[uint64]string,
+) (uint64, error) {
+	logger := utils.LoggerWithContext(ctx, d.logger).With(zap.String("storage", storageName))
+
+	orphanedDownloadTaskIdMap, err := d.orphanedDownloadFileFinder.
+		getOrphanedDownloadTaskIdMap(ctx, storageName, lo.Keys(fileNameBatch))
+	if err != nil {
+		return 0, err
+	}
+
+	var deletedCount uint64
+	for downloadTaskId, downloadTaskExists := range orphanedDownloadTaskIdMap {
+		fileName := fileNameBatch[downloadTaskId]
+		logger.
+			With(zap.Uint64("download_task_id", downloadTaskId)).
+			With(zap.String("file_name", fileName)).
+			With(zap.Bool("download_task_exists", downloadTaskExists)).
+			Warn("deleting orphaned download file")
+		if err = fileClient.Delete(ctx, fileName); err != nil {
+			return deletedCount, err
+		}
+
+		deletedCount++
+	}
+
+	return deletedCount, nil
+}
+
+// orphanedDownloadFileFinder tells which files of a storage are orphaned, for the reconciler that deletes them
+// and the download file check that reports them.
+type orphanedDownloadFileFinder struct {
+	downloadTaskDataAccessor database.DownloadTaskDataAccessor
+	storageSet               file.StorageSet
+	minAge                   time.Duration
+	logger                   *zap.Logger
+}
+
+func newOrphanedDownloadFileFinder(
+	downloadTaskDataAccessor database.DownloadTaskDataAccessor,
+	storageSet file.StorageSet,
+	downloadConfig config.Download,
+	logger *zap.Logger,
+) (*orphanedDownloadFileFinder, error) {
+	minAge, err := downloadConfig.OrphanReconciler.GetMinAgeDuration()
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to parse download orphan reconciler min_age")
+		return nil, err
+	}
+
+	return &orphanedDownloadFileFinder{
+		downloadTaskDataAccessor: downloadTaskDataAccessor,
+		storageSet:               storageSet,
+		minAge:                   minAge,
+		logger:                   logger,
+	}, nil
+}
+
+// getModifiedTimeLimit returns the time a file must have been modified before to be possibly orphaned. A
+// recent file may be being written for a download task that can not be seen yet, or be copied by a storage
+// migration that did not record its new storage yet.
+func (o *orphanedDownloadFileFinder) getModifiedTimeLimit() time.Time {
+	return time.Now().Add(-o.minAge)
+}
+
+// getOrphanCandidateDownloadTaskId returns the id of the download task a file was written for, if the file is
+// older than modifiedTimeLimit. The files whose name was not given by go-idm are never orphaned.
+func getOrphanCandidateDownloadTaskId(fileInfo file.FileInfo, modifiedTimeLimit time.Time) (uint64, bool) {
+	if fileInfo.ModifiedTime.After(modifiedTimeLimit) {
+		return 0, false
+	}
+
+	return parseDownloadTaskFileName(fileInfo.Path)
+}
+
+// getOrphanedDownloadTaskIdMap returns which of the download tasks of downloadTaskIdList have an orphaned file
+// in storageName, mapped to whether the download task exists: the download task does not exist, or records
+// another storage than storageName which holds its file. The download tasks that do not record their storage
+// are left alone, the storage their file is in is not certain.
+func (o *orphanedDownloadFileFinder) getOrphanedDownloadTaskIdMap(
+	ctx context.Context,
+	storageName string,
+	downloadTaskIdList []uint64,
+) (map[uint64]bool, error) {
+	logger := utils.LoggerWithContext(ctx, o.logger).With(zap.String("storage", storageName))
+
+	downloadTaskList, err := o.downloadTaskDataAccessor.GetDownloadTaskListOfIdList(ctx, downloadTaskIdList)
+	if err != nil {
+		return nil, err
+	}
+
+	downloadTaskMap := lo.SliceToMap(downloadTaskList, func(downloadTask database.DownloadTask) (uint64, database.DownloadTask) {
+		return downloadTask.ID, downloadTask
+	})
+
+	orphanedDownloadTaskIdMap := make(map[uint64]bool)
+	for _, downloadTaskId := range downloadTaskIdList {
+		downloadTask, ok := downloadTaskMap[downloadTaskId]
+		if ok {
+			extraCopy, err := o.isExtraCopy(
+				ctx, storageName, downloadTask, logger.With(zap.Uint64("download_task_id", downloadTaskId)),
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			if !extraCopy {
+				continue
+			}
+		}
+
+		orphanedDownloadTaskIdMap[downloadTaskId] = ok
+	}
+
+	return orphanedDownloadTaskIdMap, nil
+}
+
+// isExtraCopy returns whether the file of a download task found in storageName is a copy of the one in the
+// storage the download task records, such as the copies a storage migration or the tiering job left in the
+// storage they copied from.
+func (o *orphanedDownloadFileFinder) isExtraCopy(
+	ctx context.Context,
+	storageName string,
+	downloadTask database.DownloadTask,
+	logger *zap.Logger,
+) (bool, error) {
+	recordedStorageName, ok := getRecordedDownloadTaskStorage(downloadTask)
+	if !ok {
+		if storageName != o.storageSet.GetLegacyStorageName() {
+			logger.Info("download task does not record its storage, not deleting its file")
+		}
+
+		return false, nil
+	}
+
+	if recordedStorageName == storageName {
+		return false, nil
+	}
+
+	// The storage recorded may not be configured anymore, or be renamed.
+	recordedFileClient, err := o.storageSet.GetClient(recordedStorageName)
+	if err != nil {
+		logger.With(zap.String("recorded_storage", recordedStorageName)).
+			Info("storage recorded by download task is not configured, not deleting its file")
+		return false, nil
+	}
+
+	recordedFileName, err := getDownloadTaskFileName(downloadTask)
+	if err != nil {
+		return false, nil
+	}
+
+	if _, err = recordedFileClient.Stat(ctx, recordedFileName); err != nil {
+		if errors.Is(err, file.ErrFileNotFound) {
+			logger.With(zap.String("recorded_storage", recordedStorageName)).
+				Warn("file of download task is missing from its recorded storage, not deleting its copy")
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,104 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	"github.com/manhhung2111/go-idm/internal/config"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/file"
+	"github.com/manhhung2111/go-idm/internal/utils"
+	"go.uber.org/zap"
+)
+
+// DownloadFileTiering periodically moves the files that have not been modified for a while from a hot storage
+// to a cold one, with the storage migration. The download tasks point to the storage their file was moved to,
+// so their file can still be downloaded wherever it is.
+type DownloadFileTiering interface {
+	Start(ctx context.Context) error
+}
+
+type downloadFileTiering struct {
+	storageMigration StorageMigration
+	tieringConfig    config.DownloadTiering
+	minAge           time.Duration
+	interval         time.Duration
+	logger           *zap.Logger
+}
+
+func NewDownloadFileTiering(
+	storageMigration StorageMigration,
+	storageSet file.StorageSet,
+	downloadConfig config.Download,
+	logger *zap.Logger,
+) (DownloadFileTiering, error) {
+	minAge, err := downloadConfig.Tiering.GetMinAgeDuration()
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to parse download tiering min_age")
+		return nil, err
+	}
+
+	interval, err := downloadConfig.Tiering.GetIntervalDuration()
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to parse download tiering interval")
+		return nil, err
+	}
+
+	if downloadConfig.Tiering.IsEnabled() {
+		if _, err = storageSet.GetClient(downloadConfig.Tiering.FromStorage); err != nil {
+			return nil, err
+		}
+
+		if _, err = storageSet.GetClient(downloadConfig.Tiering.ToStorage); err != nil {
+			return nil, err
+		}
+	}
+
+	return &downloadFileTiering{
+		storageMigration: storageMigration,
+		tieringConfig:    downloadConfig.Tiering,
+		minAge:           minAge,
+		interval:         interval,
+		logger:           logger,
+	}, nil
+}
+
+// Start implements DownloadFileTiering. It does nothing but wait for ctx to be done if tiering is disabled.
+func (d *downloadFileTiering) Start(ctx context.Context) error {
+	if !d.tieringConfig.IsEnabled() {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	logger := utils.LoggerWithContext(ctx, d.logger).
+		With(zap.String("from", d.tieringConfig.FromStorage)).
+		With(zap.String("to", d.tieringConfig.ToStorage))
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		output, err := d.storageMigration.MigrateStorage(ctx, MigrateStorageParams{
+			From:         d.tieringConfig.FromStorage,
+			To:           d.tieringConfig.ToStorage,
+			Concurrency:  d.tieringConfig.GetConcurrency(),
+			DeleteSource: true,
+			MinFileAge:   d.minAge,
+		})
+		if err != nil && ctx.Err() == nil {
+			logger.With(zap.Error(err)).Error("failed to move download files to the cold storage")
+		}
+
+		if output.MigratedCount > 0 || len(output.FailureList) > 0 {
+			logger.
+				With(zap.Uint64("migrated_count", output.MigratedCount)).
+				With(zap.Int("failure_count", len(output.FailureList))).
+				Info("moved download files to the cold storage")
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -2,11 +2,23 @@ package logic
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/doug-martin/goqu/v9"
+	"github.com/manhhung2111/go-idm/internal/config"
 	"github.com/manhhung2111/go-idm/internal/dataaccess/database"
 	"github.com/manhhung2111/go-idm/internal/dataaccess/file"
 	"github.com/manhhung2111/go-idm/internal/dataaccess/kafka/producer"
@@ -20,12 +32,22 @@ import (
 
 const (
 	downloadTaskMetadataFieldNameFileName = "file-name"
+	downloadTaskMetadataFieldNameFileSize = "file-size"
+	downloadTaskMetadataFieldNameChecksum = "checksum"
+	downloadTaskMetadataFieldNameStorage  = "storage"
+	// The file size and checksum of a compressed file are the ones of its original content, its size once
+	// compressed is recorded next to them.
+	downloadTaskMetadataFieldNameCompressionCodec   = "compression-codec"
+	downloadTaskMetadataFieldNameCompressedFileSize = "compressed-file-size"
+
+	downloadTaskFileNamePrefix = "download_file_"
 )
 
 type CreateDownloadTaskParams struct {
 	Token        string
 	DownloadType go_idm_v1.DownloadType
 	URL          string
+	TeamID       uint64
 }
 
 type CreateDownloadTaskOutput struct {
@@ -60,50 +82,140 @@ type DeleteDownloadTaskParams struct {
 
 type DeleteDownloadTaskOutput struct{}
 
+type RestoreDownloadTaskParams struct {
+	Token          string
+	DownloadTaskID uint64
+}
+
+type RestoreDownloadTaskOutput struct {
+	DownloadTask *go_idm_v1.DownloadTask
+}
+
+type ListTrashParams struct {
+	Token  string
+	Offset uint64
+	Limit  uint64
+}
+
+type ListTrashOutput struct {
+	DownloadTaskList []*go_idm_v1.DownloadTask
+	Total            uint64
+}
+
 type GetDownloadTaskFileParams struct {
 	Token          string
 	DownloadTaskID uint64
+	// Offset and Length select the range of the file to get, up to its end if Length is 0.
+	Offset uint64
+	Length uint64
 }
 
+type GetDownloadTaskParams struct {
+	Token          string
+	DownloadTaskID uint64
+}
 
+type GetDownloadTaskOutput struct {
+	DownloadTask *go_idm_v1.DownloadTask
+}
+
+type ShareDownloadTaskParams struct {
+	Token          string
+	DownloadTaskID uint64
+	AccountID      uint64
+	ShareLevel     go_idm_v1.DownloadTaskShareLevel
+}
+
+type UnshareDownloadTaskParams struct {
+	Token          string
+	DownloadTaskID uint64
+	AccountID      uint64
+}
 
 type DownloadTask interface {
 	CreateDownloadTask(ctx context.Context, params CreateDownloadTaskParams) (CreateDownloadTaskOutput, error)
 	GetDownloadTaskList(ctx context.Context, params GetDownloadTaskListParams) (GetDownloadTaskListOutput, error)
 	UpdateDownloadTask(ctx context.Context, params UpdateDownloadTaskParams) (UpdateDownloadTaskOutput, error)
+	// DeleteDownloadTask moves a download task to the trash, where it is hidden from the other methods until it
+	// is restored or purged for good once the trash retention is over.
 	DeleteDownloadTask(ctx context.Context, params DeleteDownloadTaskParams) error
+	RestoreDownloadTask(ctx context.Context, params RestoreDownloadTaskParams) (RestoreDownloadTaskOutput, error)
+	ListTrash(ctx context.Context, params ListTrashParams) (ListTrashOutput, error)
 	ExecuteDownloadTask(context.Context, uint64) error
 	GetDownloadTaskFile(context.Context, GetDownloadTaskFileParams) (io.ReadCloser, error)
+	GetDownloadTask(ctx context.Context, params GetDownloadTaskParams) (GetDownloadTaskOutput, error)
+	ShareDownloadTask(ctx context.Context, params ShareDownloadTaskParams) error
+	UnshareDownloadTask(ctx context.Context, params UnshareDownloadTaskParams) error
 }
 
 type downloadTask struct {
-	tokenLogic                  Token
-	accountDataAccessor         database.AccountDataAccessor
-	downloadTaskDataAccessor    database.DownloadTaskDataAccessor
-	goquDatabase                *goqu.Database
-	logger                      *zap.Logger
-	downloadTaskCreatedProducer producer.DownloadTaskCreatedProducer
-	fileClient                  file.Client
+	tokenLogic                    Token
+	accountDataAccessor           database.AccountDataAccessor
+	downloadTaskDataAccessor      database.DownloadTaskDataAccessor
+	downloadTaskShareDataAccessor database.DownloadTaskShareDataAccessor
+	teamMemberDataAccessor        database.TeamMemberDataAccessor
+	auditEventDataAccessor        database.AuditEventDataAccessor
+	downloadTaskPermissionLogic   DownloadTaskPermission
+	goquDatabase                  *goqu.Database
+	logger                        *zap.Logger
+	downloadTaskCreatedProducer   producer.DownloadTaskCreatedProducer
+	downloadTaskEventProducer     producer.DownloadTaskEventProducer
+	connectionLimiter             ConnectionLimiter
+	storageSet                    file.StorageSet
+	storagePlacement              StoragePlacement
+	compressionConfig             config.DownloadCompression
+	workerId                      string
+	leaseHeartbeatInterval        time.Duration
 }
 
 func NewDownloadTask(
 	tokenLogic Token,
 	accountDataAccessor database.AccountDataAccessor,
 	downloadTaskDataAccessor database.DownloadTaskDataAccessor,
+	downloadTaskShareDataAccessor database.DownloadTaskShareDataAccessor,
+	teamMemberDataAccessor database.TeamMemberDataAccessor,
+	auditEventDataAccessor database.AuditEventDataAccessor,
+	downloadTaskPermissionLogic DownloadTaskPermission,
 	goquDatabase *goqu.Database,
 	logger *zap.Logger,
 	downloadTaskCreatedProducer producer.DownloadTaskCreatedProducer,
-	fileClient file.Client,
-) DownloadTask {
-	return &downloadTask{
-		tokenLogic:                  tokenLogic,
-		accountDataAccessor:         accountDataAccessor,
-		downloadTaskDataAccessor:    downloadTaskDataAccessor,
-		goquDatabase:                goquDatabase,
-		logger:                      logger,
-		downloadTaskCreatedProducer: downloadTaskCreatedProducer,
-		fileClient: fileClient,
+	downloadTaskEventProducer producer.DownloadTaskEventProducer,
+	connectionLimiter ConnectionLimiter,
+	storageSet file.StorageSet,
+	storagePlacement StoragePlacement,
+	downloadConfig config.Download,
+) (DownloadTask, error) {
+	leaseHeartbeatInterval, err := downloadConfig.Lease.GetHeartbeatIntervalDuration()
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to parse download lease heartbeat_interval")
+		return nil, err
 	}
+
+	workerId, err := newWorkerId()
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to generate worker id")
+		return nil, err
+	}
+
+	return &downloadTask{
+		tokenLogic:                    tokenLogic,
+		accountDataAccessor:           accountDataAccessor,
+		downloadTaskDataAccessor:      downloadTaskDataAccessor,
+		downloadTaskShareDataAccessor: downloadTaskShareDataAccessor,
+		teamMemberDataAccessor:        teamMemberDataAccessor,
+		auditEventDataAccessor:        auditEventDataAccessor,
+		downloadTaskPermissionLogic:   downloadTaskPermissionLogic,
+		goquDatabase:                  goquDatabase,
+		logger:                        logger,
+		downloadTaskCreatedProducer:   downloadTaskCreatedProducer,
+		downloadTaskEventProducer:     downloadTaskEventProducer,
+		connectionLimiter:             connectionLimiter,
+		storageSet:                    storageSet,
+		storagePlacement:              storagePlacement,
+		compressionConfig:             downloadConfig.Compression,
+		workerId:                      workerId,
+		leaseHeartbeatInterval:        leaseHeartbeatInterval,
+	}, nil
 }
 
 // CreateDownloadTask implements DownloadTask.
@@ -128,20 +240,36 @@ func (d *downloadTask) CreateDownloadTask(ctx context.Context, params CreateDown
 		},
 	}
 
+	if params.TeamID != 0 {
+		if _, err = d.teamMemberDataAccessor.GetTeamMember(ctx, params.TeamID, accountId); err != nil {
+			if errors.Is(err, database.ErrTeamMemberNotFound) {
+				return CreateDownloadTaskOutput{}, status.Error(codes.PermissionDenied, "trying to create a download task in a team the account is not a member of")
+			}
+
+			return CreateDownloadTaskOutput{}, err
+		}
+
+		downloadTask.OfTeamID = &params.TeamID
+	}
+
 	txErr := d.goquDatabase.WithTx(func(td *goqu.TxDatabase) error {
-		downloadTaskId, err := d.downloadTaskDataAccessor.CreateDownloadTask(ctx, downloadTask)
+		downloadTaskId, err := d.downloadTaskDataAccessor.WithDatabase(td).CreateDownloadTask(ctx, downloadTask)
 		if err != nil {
 			return err
 		}
 
 		downloadTask.ID = downloadTaskId
-		if err = d.downloadTaskCreatedProducer.Send(ctx, producer.DownloadTaskCreated{
+		if err = d.downloadTaskCreatedProducer.WithDatabase(td).Send(ctx, producer.DownloadTaskCreated{
 			Id: downloadTaskId,
 		}); err != nil {
 			return err
 		}
 
-		return nil
+		return recordAuditEvent(
+			ctx, d.auditEventDataAccessor.WithDatabase(td), &accountId,
+			AuditEventActionDownloadTaskCreate, AuditEventResourceTypeDownloadTask, downloadTaskId,
+			nil, downloadTaskAuditEventValue(downloadTask),
+		)
 	})
 
 	if txErr != nil {
@@ -167,11 +295,34 @@ func (d *downloadTask) DeleteDownloadTask(ctx context.Context, params DeleteDown
 			return getDownloadTaskWithXLockErr
 		}
 
-		if downloadTask.OfAccountID != accountId {
-			return status.Error(codes.PermissionDenied, "trying to delete a download task the account does not own")
+		if err := checkDownloadTaskNotTrashed(downloadTask); err != nil {
+			return err
+		}
+
+		if err := d.downloadTaskPermissionLogic.CheckShareLevel(
+			ctx, accountId, downloadTask, go_idm_v1.DownloadTaskShareLevel_Manage,
+		); err != nil {
+			return err
+		}
+
+		if err := d.downloadTaskDataAccessor.WithDatabase(td).
+			UpdateDownloadTaskDeletedTime(ctx, params.DownloadTaskId, lo.ToPtr(time.Now().UTC())); err != nil {
+			return err
+		}
+
+		if err := recordAuditEvent(
+			ctx, d.auditEventDataAccessor.WithDatabase(td), &accountId,
+			AuditEventActionDownloadTaskDelete, AuditEventResourceTypeDownloadTask, downloadTask.ID,
+			downloadTaskAuditEventValue(downloadTask), nil,
+		); err != nil {
+			return err
 		}
 
-		return d.downloadTaskDataAccessor.WithDatabase(td).DeleteDownloadTask(ctx, params.DownloadTaskId)
+		return d.downloadTaskEventProducer.WithDatabase(td).SendDeleted(ctx, &go_idm_v1.DownloadTaskDeletedEvent{
+			DownloadTaskId: downloadTask.ID,
+			OfAccountId:    downloadTask.OfAccountID,
+			OfTeamId:       lo.FromPtr(downloadTask.OfTeamID),
+		})
 	})
 }
 
@@ -206,6 +357,87 @@ func (d *downloadTask) GetDownloadTaskList(ctx context.Context, params GetDownlo
 	}, nil
 }
 
+// RestoreDownloadTask implements DownloadTask.
+func (d *downloadTask) RestoreDownloadTask(ctx context.Context, params RestoreDownloadTaskParams) (RestoreDownloadTaskOutput, error) {
+	accountId, _, err := d.tokenLogic.GetAccountIDAndExpireTime(ctx, params.Token)
+	if err != nil {
+		return RestoreDownloadTaskOutput{}, err
+	}
+
+	account, err := d.accountDataAccessor.GetAccountById(ctx, accountId)
+	if err != nil {
+		return RestoreDownloadTaskOutput{}, err
+	}
+
+	output := RestoreDownloadTaskOutput{}
+	txErr := d.goquDatabase.WithTx(func(td *goqu.TxDatabase) error {
+		downloadTask, getDownloadTaskWithXLockErr := d.downloadTaskDataAccessor.WithDatabase(td).
+			GetDownloadTaskWithXLock(ctx, params.DownloadTaskID)
+		if getDownloadTaskWithXLockErr != nil {
+			return getDownloadTaskWithXLockErr
+		}
+
+		if err := d.downloadTaskPermissionLogic.CheckShareLevel(
+			ctx, accountId, downloadTask, go_idm_v1.DownloadTaskShareLevel_Manage,
+		); err != nil {
+			return err
+		}
+
+		if !downloadTask.IsTrashed() {
+			return status.Error(codes.FailedPrecondition, "download task is not in the trash")
+		}
+
+		if err := d.downloadTaskDataAccessor.WithDatabase(td).
+			UpdateDownloadTaskDeletedTime(ctx, downloadTask.ID, nil); err != nil {
+			return err
+		}
+
+		downloadTask.DeletedTime = nil
+		output.DownloadTask = d.databaseDownloadTaskToProtoDownloadTask(downloadTask, account)
+		return recordAuditEvent(
+			ctx, d.auditEventDataAccessor.WithDatabase(td), &accountId,
+			AuditEventActionDownloadTaskRestore, AuditEventResourceTypeDownloadTask, downloadTask.ID,
+			nil, downloadTaskAuditEventValue(downloadTask),
+		)
+	})
+	if txErr != nil {
+		return RestoreDownloadTaskOutput{}, txErr
+	}
+
+	return output, nil
+}
+
+// ListTrash implements DownloadTask.
+func (d *downloadTask) ListTrash(ctx context.Context, params ListTrashParams) (ListTrashOutput, error) {
+	accountId, _, err := d.tokenLogic.GetAccountIDAndExpireTime(ctx, params.Token)
+	if err != nil {
+		return ListTrashOutput{}, err
+	}
+
+	account, err := d.accountDataAccessor.GetAccountById(ctx, accountId)
+	if err != nil {
+		return ListTrashOutput{}, err
+	}
+
+	totalDownloadTaskCount, err := d.downloadTaskDataAccessor.GetTrashedDownloadTaskCountOfAccount(ctx, accountId)
+	if err != nil {
+		return ListTrashOutput{}, err
+	}
+
+	downloadTaskList, err := d.downloadTaskDataAccessor.
+		GetTrashedDownloadTaskListOfAccount(ctx, accountId, params.Offset, params.Limit)
+	if err != nil {
+		return ListTrashOutput{}, err
+	}
+
+	return ListTrashOutput{
+		Total: totalDownloadTaskCount,
+		DownloadTaskList: lo.Map(downloadTaskList, func(item database.DownloadTask, _ int) *go_idm_v1.DownloadTask {
+			return d.databaseDownloadTaskToProtoDownloadTask(item, account)
+		}),
+	}, nil
+}
+
 // UpdateDownloadTask implements DownloadTask.
 func (d *downloadTask) UpdateDownloadTask(ctx context.Context, params UpdateDownloadTaskParams) (UpdateDownloadTaskOutput, error) {
 	accountId, _, err := d.tokenLogic.GetAccountIDAndExpireTime(ctx, params.Token)
@@ -226,13 +458,28 @@ func (d *downloadTask) UpdateDownloadTask(ctx context.Context, params UpdateDown
 			return getDownloadTaskWithXLockErr
 		}
 
-		if downloadTask.OfAccountID != accountId {
-			return status.Error(codes.PermissionDenied, "trying to update a download task the account does not own")
+		if err := checkDownloadTaskNotTrashed(downloadTask); err != nil {
+			return err
+		}
+
+		if err := d.downloadTaskPermissionLogic.CheckShareLevel(
+			ctx, accountId, downloadTask, go_idm_v1.DownloadTaskShareLevel_Manage,
+		); err != nil {
+			return err
 		}
 
+		beforeValue := downloadTaskAuditEventValue(downloadTask)
 		downloadTask.URL = params.URL
 		output.DownloadTask = d.databaseDownloadTaskToProtoDownloadTask(downloadTask, account)
-		return d.downloadTaskDataAccessor.WithDatabase(td).UpdateDownloadTask(ctx, downloadTask)
+		if err := d.downloadTaskDataAccessor.WithDatabase(td).UpdateDownloadTask(ctx, downloadTask); err != nil {
+			return err
+		}
+
+		return recordAuditEvent(
+			ctx, d.auditEventDataAccessor.WithDatabase(td), &accountId,
+			AuditEventActionDownloadTaskUpdate, AuditEventResourceTypeDownloadTask, downloadTask.ID,
+			beforeValue, downloadTaskAuditEventValue(downloadTask),
+		)
 	})
 	if txErr != nil {
 		return UpdateDownloadTaskOutput{}, txErr
@@ -245,12 +492,20 @@ func (d downloadTask) databaseDownloadTaskToProtoDownloadTask(
 	downloadTask database.DownloadTask,
 	account database.Account,
 ) *go_idm_v1.DownloadTask {
-	return &go_idm_v1.DownloadTask{
+	protoDownloadTask := &go_idm_v1.DownloadTask{
 		Id:             downloadTask.ID,
 		DownloadType:   downloadTask.DownloadType,
 		Url:            downloadTask.URL,
-		DownloadStatus: go_idm_v1.DownloadStatus_Pending,
+		DownloadStatus: downloadTask.DownloadStatus,
+		OfAccountId:    downloadTask.OfAccountID,
+		OfTeamId:       lo.FromPtr(downloadTask.OfTeamID),
 	}
+
+	if downloadTask.DeletedTime != nil {
+		protoDownloadTask.DeletedTime = uint64(downloadTask.DeletedTime.Unix())
+	}
+
+	return protoDownloadTask
 }
 
 func (d *downloadTask) updateDownloadTaskStatusFromPendingToDownloading(
@@ -282,6 +537,12 @@ func (d *downloadTask) updateDownloadTaskStatusFromPendingToDownloading(
 			return nil
 		}
 
+		if downloadTask.IsTrashed() {
+			logger.Warn("download task is in the trash, will not execute")
+			updated = false
+			return nil
+		}
+
 		downloadTask.DownloadStatus = go_idm_v1.DownloadStatus_Downloading
 		err = d.downloadTaskDataAccessor.WithDatabase(td).UpdateDownloadTask(ctx, downloadTask)
 		if err != nil {
@@ -289,11 +550,24 @@ func (d *downloadTask) updateDownloadTaskStatusFromPendingToDownloading(
 			return err
 		}
 
+		err = d.downloadTaskDataAccessor.WithDatabase(td).
+			UpdateDownloadTaskLease(ctx, downloadTask.ID, &d.workerId, lo.ToPtr(time.Now()))
+		if err != nil {
+			return err
+		}
+
+		err = d.downloadTaskEventProducer.WithDatabase(td).SendStatusChanged(ctx, newDownloadTaskStatusChangedEvent(
+			downloadTask, go_idm_v1.DownloadStatus_Pending,
+		))
+		if err != nil {
+			return err
+		}
+
 		updated = true
 		return nil
 	})
 	if txErr != nil {
-		return false, database.DownloadTask{}, err
+		return false, database.DownloadTask{}, txErr
 	}
 
 	return updated, downloadTask, nil
@@ -315,35 +589,140 @@ func (d *downloadTask) ExecuteDownloadTask(ctx context.Context, id uint64) error
 	//nolint:exhaustive // No need to check unsupported download type
 	switch downloadTask.DownloadType {
 	case go_idm_v1.DownloadType_HTTP:
-		downloader = NewHTTPDownloader(downloadTask.URL, d.logger)
+		downloader = NewHTTPDownloader(downloadTask.URL, d.connectionLimiter, d.logger)
 
 	default:
 		logger.With(zap.Any("download_type", downloadTask.DownloadType)).Error("unsupported download type")
-		return nil
+		return d.updateDownloadTaskStatusFromDownloadingToFailed(
+			ctx, downloadTask, fmt.Errorf("unsupported download type: %s", downloadTask.DownloadType),
+		)
 	}
 
-	fileName := fmt.Sprintf("download_file_%d", id)
-	fileWriteCloser, err := d.fileClient.Write(ctx, fileName)
+	// The download is stopped if the lease of the download task is lost, as the download task was put back
+	// into pending by the reaper and may already be executed by another worker.
+	leaseCtx, stopHeartbeat := d.keepDownloadTaskLeaseAlive(ctx, downloadTask.ID)
+	defer stopHeartbeat()
+
+	downloadProbe, err := downloader.Probe(leaseCtx)
+	if err != nil {
+		logger.With(zap.Error(err)).Warn("failed to probe download, placing it as of unknown size and content type")
+		downloadProbe = DownloadProbe{FileSize: -1}
+	}
+
+	storageName := d.storagePlacement.GetStorageName(downloadTask, downloadProbe)
+	fileClient, err := d.storageSet.GetClient(storageName)
 	if err != nil {
 		return err
 	}
 
-	defer fileWriteCloser.Close()
+	fileName := newDownloadTaskFileName(id)
+	var fileWriteCloser file.FileWriter
+	if sizedWriteClient, ok := fileClient.(file.SizedWriteClient); ok && downloadProbe.FileSize > 0 {
+		fileWriteCloser, err = sizedWriteClient.WriteSized(leaseCtx, fileName, uint64(downloadProbe.FileSize))
+	} else {
+		fileWriteCloser, err = fileClient.Write(leaseCtx, fileName)
+	}
 
-	metadata, err := downloader.Download(ctx, fileWriteCloser)
 	if err != nil {
-		logger.With(zap.Error(err)).Error("failed to download")
+		if errors.Is(err, file.ErrNotEnoughSpace) {
+			logger.With(zap.Error(err)).Error("not enough space to store downloaded file")
+			return d.updateDownloadTaskStatusFromDownloadingToFailed(ctx, downloadTask, err)
+		}
+
 		return err
 	}
 
-	metadata["file-name"] = fileName
+	// The file is discarded unless it is closed once completely written.
+	defer fileWriteCloser.Abort()
+
+	compressedFileSizeWriter := new(byteCountWriter)
+	contentWriter := io.MultiWriter(fileWriteCloser, compressedFileSizeWriter)
+	compressionCodec := d.getCompressionCodec(downloadTask, downloadProbe)
+	var compressingWriteCloser io.WriteCloser
+	if compressionCodec != "" {
+		compressingWriteCloser, err = file.NewCompressingWriter(contentWriter, compressionCodec)
+		if err != nil {
+			return err
+		}
+
+		contentWriter = compressingWriteCloser
+	}
+
+	fileSizeWriter := new(byteCountWriter)
+	fileChecksumHash := sha256.New()
+	metadata, err := downloader.Download(leaseCtx, io.MultiWriter(contentWriter, fileSizeWriter, fileChecksumHash))
+	if err != nil {
+		if ctx.Err() != nil {
+			logger.With(zap.Error(err)).Warn("download interrupted, putting download task back into pending")
+			return d.pauseDownloadTask(context.WithoutCancel(ctx), downloadTask)
+		}
+
+		if leaseCtx.Err() != nil {
+			logger.Warn("download task lease lost, stopped downloading")
+			return nil
+		}
+
+		logger.With(zap.Error(err)).Error("failed to download")
+		return d.updateDownloadTaskStatusFromDownloadingToFailed(ctx, downloadTask, err)
+	}
+
+	// The file is only complete once closed, which has to happen before the download task points to it.
+	if compressingWriteCloser != nil {
+		err = compressingWriteCloser.Close()
+	}
+
+	if err == nil {
+		err = fileWriteCloser.Close()
+	}
+
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to write file")
+		return d.updateDownloadTaskStatusFromDownloadingToFailed(ctx, downloadTask, err)
+	}
+
+	fileChecksum := hex.EncodeToString(fileChecksumHash.Sum(nil))
+	metadata[downloadTaskMetadataFieldNameFileName] = fileName
+	metadata[downloadTaskMetadataFieldNameFileSize] = fileSizeWriter.count
+	metadata[downloadTaskMetadataFieldNameChecksum] = fileChecksum
+	metadata[downloadTaskMetadataFieldNameStorage] = storageName
+	if compressionCodec != "" {
+		metadata[downloadTaskMetadataFieldNameCompressionCodec] = string(compressionCodec)
+		metadata[downloadTaskMetadataFieldNameCompressedFileSize] = compressedFileSizeWriter.count
+	}
 	downloadTask.DownloadStatus = go_idm_v1.DownloadStatus_Succeeded
 	downloadTask.Metadata = database.JSON{
 		Data: metadata,
 	}
-	err = d.downloadTaskDataAccessor.UpdateDownloadTask(ctx, downloadTask)
+
+	err = d.goquDatabase.WithTx(func(td *goqu.TxDatabase) error {
+		leaseHeld, err := d.releaseDownloadTaskLease(ctx, td, downloadTask.ID)
+		if err != nil || !leaseHeld {
+			return err
+		}
+
+		if err := d.downloadTaskDataAccessor.WithDatabase(td).UpdateDownloadTask(ctx, downloadTask); err != nil {
+			logger.With(zap.Error(err)).Error("failed to update download task status to success")
+			return err
+		}
+
+		downloadTaskEventProducer := d.downloadTaskEventProducer.WithDatabase(td)
+		if err := downloadTaskEventProducer.SendStatusChanged(ctx, newDownloadTaskStatusChangedEvent(
+			downloadTask, go_idm_v1.DownloadStatus_Downloading,
+		)); err != nil {
+			return err
+		}
+
+		return downloadTaskEventProducer.SendCompleted(ctx, &go_idm_v1.DownloadTaskCompletedEvent{
+			DownloadTaskId: downloadTask.ID,
+			OfAccountId:    downloadTask.OfAccountID,
+			OfTeamId:       lo.FromPtr(downloadTask.OfTeamID),
+			FileName:       getDownloadTaskAttachmentName(downloadTask, fileName),
+			FileSize:       fileSizeWriter.count,
+			Checksum:       fileChecksum,
+			ContentType:    getDownloadTaskContentType(downloadTask),
+		})
+	})
 	if err != nil {
-		logger.With(zap.Error(err)).Error("failed to update download task status to success")
 		return err
 	}
 
@@ -352,6 +731,147 @@ func (d *downloadTask) ExecuteDownloadTask(ctx context.Context, id uint64) error
 	return nil
 }
 
+// updateDownloadTaskStatusFromDownloadingToFailed records that a download task failed for downloadErr. Failed
+// is a final status, the download task is not retried once it is recorded.
+func (d *downloadTask) updateDownloadTaskStatusFromDownloadingToFailed(
+	ctx context.Context,
+	downloadTask database.DownloadTask,
+	downloadErr error,
+) error {
+	logger := utils.LoggerWithContext(ctx, d.logger).With(zap.Uint64("id", downloadTask.ID))
+
+	downloadTask.DownloadStatus = go_idm_v1.DownloadStatus_Failed
+	return d.goquDatabase.WithTx(func(td *goqu.TxDatabase) error {
+		leaseHeld, err := d.releaseDownloadTaskLease(ctx, td, downloadTask.ID)
+		if err != nil || !leaseHeld {
+			return err
+		}
+
+		if err := d.downloadTaskDataAccessor.WithDatabase(td).UpdateDownloadTask(ctx, downloadTask); err != nil {
+			logger.With(zap.Error(err)).Error("failed to update download task status to failed")
+			return err
+		}
+
+		downloadTaskEventProducer := d.downloadTaskEventProducer.WithDatabase(td)
+		if err := downloadTaskEventProducer.SendStatusChanged(ctx, newDownloadTaskStatusChangedEvent(
+			downloadTask, go_idm_v1.DownloadStatus_Downloading,
+		)); err != nil {
+			return err
+		}
+
+		return downloadTaskEventProducer.SendFailed(ctx, &go_idm_v1.DownloadTaskFailedEvent{
+			DownloadTaskId: downloadTask.ID,
+			OfAccountId:    downloadTask.OfAccountID,
+			OfTeamId:       lo.FromPtr(downloadTask.OfTeamID),
+			Error:          downloadErr.Error(),
+		})
+	})
+}
+
+// pauseDownloadTask puts a download task whose download was interrupted, typically by a shutdown, back into
+// pending so that it is downloaded again from the start. If that fails, the reaper recovers the download task
+// once its lease expires.
+func (d *downloadTask) pauseDownloadTask(ctx context.Context, downloadTask database.DownloadTask) error {
+	return d.goquDatabase.WithTx(func(td *goqu.TxDatabase) error {
+		leaseHeld, err := d.releaseDownloadTaskLease(ctx, td, downloadTask.ID)
+		if err != nil || !leaseHeld {
+			return err
+		}
+
+		return putDownloadTaskBackIntoPending(
+			ctx,
+			d.downloadTaskDataAccessor.WithDatabase(td),
+			d.downloadTaskEventProducer.WithDatabase(td),
+			d.downloadTaskCreatedProducer.WithDatabase(td),
+			downloadTask,
+		)
+	})
+}
+
+// putDownloadTaskBackIntoPending moves a download task back into pending and sends it to be executed again.
+// The data accessor and producers must be bound to the same transaction.
+func putDownloadTaskBackIntoPending(
+	ctx context.Context,
+	downloadTaskDataAccessor database.DownloadTaskDataAccessor,
+	downloadTaskEventProducer producer.DownloadTaskEventProducer,
+	downloadTaskCreatedProducer producer.DownloadTaskCreatedProducer,
+	downloadTask database.DownloadTask,
+) error {
+	oldDownloadStatus := downloadTask.DownloadStatus
+	downloadTask.DownloadStatus = go_idm_v1.DownloadStatus_Pending
+	if err := downloadTaskDataAccessor.UpdateDownloadTask(ctx, downloadTask); err != nil {
+		return err
+	}
+
+	if err := downloadTaskEventProducer.SendStatusChanged(ctx, newDownloadTaskStatusChangedEvent(
+		downloadTask, oldDownloadStatus,
+	)); err != nil {
+		return err
+	}
+
+	return downloadTaskCreatedProducer.Send(ctx, producer.DownloadTaskCreated{
+		Id: downloadTask.ID,
+	})
+}
+
+// keepDownloadTaskLeaseAlive renews the lease of a download task every heartbeat interval until the returned
+// stop function is called. The returned context is canceled if the lease is lost.
+func (d *downloadTask) keepDownloadTaskLeaseAlive(ctx context.Context, id uint64) (context.Context, func()) {
+	logger := utils.LoggerWithContext(ctx, d.logger).
+		With(zap.Uint64("id", id)).
+		With(zap.String("worker_id", d.workerId))
+
+	leaseCtx, cancel := context.WithCancel(ctx)
+	go func() {
+		ticker := time.NewTicker(d.leaseHeartbeatInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-leaseCtx.Done():
+				return
+
+			case <-ticker.C:
+				leaseHeld, err := d.downloadTaskDataAccessor.RenewDownloadTaskLease(leaseCtx, id, d.workerId, time.Now())
+				if err != nil {
+					// The lease is kept until it is known to be lost, it may well be renewed on the next beat.
+					logger.With(zap.Error(err)).Warn("failed to renew download task lease")
+					continue
+				}
+
+				if !leaseHeld {
+					logger.Warn("download task lease lost")
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	return leaseCtx, cancel
+}
+
+// releaseDownloadTaskLease locks a download task and clears its lease, if it is still held by this worker.
+// It returns false if the lease was lost in the meantime, in which case the result of the execution must be
+// discarded.
+func (d *downloadTask) releaseDownloadTaskLease(ctx context.Context, td *goqu.TxDatabase, id uint64) (bool, error) {
+	logger := utils.LoggerWithContext(ctx, d.logger).
+		With(zap.Uint64("id", id)).
+		With(zap.String("worker_id", d.workerId))
+
+	downloadTask, err := d.downloadTaskDataAccessor.WithDatabase(td).GetDownloadTaskWithXLock(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	if downloadTask.DownloadStatus != go_idm_v1.DownloadStatus_Downloading || lo.FromPtr(downloadTask.WorkerID) != d.workerId {
+		logger.Warn("download task lease lost, discarding execution result")
+		return false, nil
+	}
+
+	return true, d.downloadTaskDataAccessor.WithDatabase(td).UpdateDownloadTaskLease(ctx, id, nil, nil)
+}
+
 func (d downloadTask) GetDownloadTaskFile(
 	ctx context.Context,
 	params GetDownloadTaskFileParams,
@@ -366,23 +886,307 @@ func (d downloadTask) GetDownloadTaskFile(
 		return nil, err
 	}
 
-	if downloadTask.OfAccountID != accountID {
-		return nil, status.Error(codes.PermissionDenied, "trying to get file of a download task the account does not own")
+	if err = checkDownloadTaskNotTrashed(downloadTask); err != nil {
+		return nil, err
+	}
+
+	if err = d.downloadTaskPermissionLogic.CheckShareLevel(
+		ctx, accountID, downloadTask, go_idm_v1.DownloadTaskShareLevel_Read,
+	); err != nil {
+		return nil, err
+	}
+
+	fileName, err := getDownloadTaskFileName(downloadTask)
+	if err != nil {
+		return nil, err
+	}
+
+	fileClient, err := getDownloadTaskFileClient(d.storageSet, downloadTask)
+	if err != nil {
+		return nil, err
+	}
+
+	return readDownloadTaskFile(ctx, fileClient, downloadTask, fileName, params.Offset, params.Length)
+}
+
+// getCompressionCodec returns the codec to compress the file of a download task with, or an empty codec if it
+// is not worth compressing: compression is disabled, or neither the content type nor the extension of the
+// file is one of the compressible ones.
+func (d downloadTask) getCompressionCodec(
+	downloadTask database.DownloadTask,
+	downloadProbe DownloadProbe,
+) config.DownloadCompressionCodec {
+	if !d.compressionConfig.IsEnabled() {
+		return ""
+	}
+
+	contentType, _, err := mime.ParseMediaType(downloadProbe.ContentType)
+	if err == nil && matchContentType(d.compressionConfig.GetContentTypes(), contentType) {
+		return d.compressionConfig.Codec
+	}
+
+	downloadURL, err := url.Parse(downloadTask.URL)
+	if err != nil {
+		return ""
+	}
+
+	extension := strings.ToLower(path.Ext(downloadURL.Path))
+	if extension != "" && slices.ContainsFunc(d.compressionConfig.GetExtensions(), func(compressibleExtension string) bool {
+		return strings.EqualFold(compressibleExtension, extension)
+	}) {
+		return d.compressionConfig.Codec
+	}
+
+	return ""
+}
+
+// getDownloadTaskCompressionCodec returns the codec the file of a download task is compressed with, or an
+// empty codec if it is stored as it was downloaded.
+func getDownloadTaskCompressionCodec(downloadTask database.DownloadTask) config.DownloadCompressionCodec {
+	downloadTaskMetadata, ok := downloadTask.Metadata.Data.(map[string]any)
+	if !ok {
+		return ""
 	}
 
+	compressionCodec, _ := downloadTaskMetadata[downloadTaskMetadataFieldNameCompressionCodec].(string)
+	return config.DownloadCompressionCodec(compressionCodec)
+}
+
+// readDownloadTaskFile reads length bytes from offset of the file of a download task, up to its end if length
+// is 0, decompressing it if it is compressed.
+func readDownloadTaskFile(
+	ctx context.Context,
+	fileClient file.Client,
+	downloadTask database.DownloadTask,
+	fileName string,
+	offset uint64,
+	length uint64,
+) (io.ReadCloser, error) {
+	if compressionCodec := getDownloadTaskCompressionCodec(downloadTask); compressionCodec != "" {
+		return file.ReadDecompressedRange(ctx, fileClient, fileName, compressionCodec, offset, length)
+	}
+
+	if offset > 0 || length > 0 {
+		return fileClient.ReadRange(ctx, fileName, offset, length)
+	}
+
+	return fileClient.Read(ctx, fileName)
+}
+
+// checkDownloadTaskNotTrashed returns database.ErrDownloadTaskNotFound for a download task in the trash, which
+// is only seen through the trash until it is restored.
+func checkDownloadTaskNotTrashed(downloadTask database.DownloadTask) error {
+	if downloadTask.IsTrashed() {
+		return database.ErrDownloadTaskNotFound
+	}
+
+	return nil
+}
+
+// newDownloadTaskFileName returns the name the file of a download task is written under when it is executed.
+func newDownloadTaskFileName(id uint64) string {
+	return fmt.Sprintf("%s%d", downloadTaskFileNamePrefix, id)
+}
+
+// parseDownloadTaskFileName returns the id of the download task a file name was made for by
+// newDownloadTaskFileName, and false for the file names it did not make.
+func parseDownloadTaskFileName(fileName string) (uint64, bool) {
+	idString, ok := strings.CutPrefix(fileName, downloadTaskFileNamePrefix)
+	if !ok {
+		return 0, false
+	}
+
+	id, err := strconv.ParseUint(idString, 10, 64)
+	if err != nil || newDownloadTaskFileName(id) != fileName {
+		return 0, false
+	}
+
+	return id, true
+}
+
+// getDownloadTaskStoredFileName returns the name of the file stored for a download task, whether its download
+// succeeded or left a partial file behind.
+func getDownloadTaskStoredFileName(downloadTask database.DownloadTask) string {
+	downloadTaskMetadata, ok := downloadTask.Metadata.Data.(map[string]any)
+	if ok {
+		if fileName, ok := downloadTaskMetadata[downloadTaskMetadataFieldNameFileName].(string); ok {
+			return fileName
+		}
+	}
+
+	return newDownloadTaskFileName(downloadTask.ID)
+}
+
+// getDownloadTaskFileName returns the name the file of a succeeded download task is stored under.
+func getDownloadTaskFileName(downloadTask database.DownloadTask) (string, error) {
 	if downloadTask.DownloadStatus != go_idm_v1.DownloadStatus_Succeeded {
-		return nil, status.Error(codes.InvalidArgument, "download task does not have status of success")
+		return "", status.Error(codes.InvalidArgument, "download task does not have status of success")
 	}
 
 	downloadTaskMetadata, ok := downloadTask.Metadata.Data.(map[string]any)
 	if !ok {
-		return nil, status.Error(codes.Internal, "download task metadata is not a map[string]any")
+		return "", status.Error(codes.Internal, "download task metadata is not a map[string]any")
 	}
 
-	fileName, ok := downloadTaskMetadata[downloadTaskMetadataFieldNameFileName]
+	fileName, ok := downloadTaskMetadata[downloadTaskMetadataFieldNameFileName].(string)
 	if !ok {
-		return nil, status.Error(codes.Internal, "download task metadata does not contain file name")
+		return "", status.Error(codes.Internal, "download task metadata does not contain file name")
+	}
+
+	return fileName, nil
+}
+
+// GetDownloadTask implements DownloadTask.
+func (d *downloadTask) GetDownloadTask(ctx context.Context, params GetDownloadTaskParams) (GetDownloadTaskOutput, error) {
+	accountId, _, err := d.tokenLogic.GetAccountIDAndExpireTime(ctx, params.Token)
+	if err != nil {
+		return GetDownloadTaskOutput{}, err
+	}
+
+	account, err := d.accountDataAccessor.GetAccountById(ctx, accountId)
+	if err != nil {
+		return GetDownloadTaskOutput{}, err
+	}
+
+	downloadTask, err := d.downloadTaskDataAccessor.GetDownloadTask(ctx, params.DownloadTaskID)
+	if err != nil {
+		return GetDownloadTaskOutput{}, err
+	}
+
+	if err = checkDownloadTaskNotTrashed(downloadTask); err != nil {
+		return GetDownloadTaskOutput{}, err
+	}
+
+	if err = d.downloadTaskPermissionLogic.CheckShareLevel(
+		ctx, accountId, downloadTask, go_idm_v1.DownloadTaskShareLevel_Read,
+	); err != nil {
+		return GetDownloadTaskOutput{}, err
+	}
+
+	return GetDownloadTaskOutput{
+		DownloadTask: d.databaseDownloadTaskToProtoDownloadTask(downloadTask, account),
+	}, nil
+}
+
+// ShareDownloadTask implements DownloadTask.
+func (d *downloadTask) ShareDownloadTask(ctx context.Context, params ShareDownloadTaskParams) error {
+	accountId, _, err := d.tokenLogic.GetAccountIDAndExpireTime(ctx, params.Token)
+	if err != nil {
+		return err
+	}
+
+	if params.ShareLevel == go_idm_v1.DownloadTaskShareLevel_UndefinedDownloadTaskShareLevel {
+		return status.Error(codes.InvalidArgument, "share level must be specified")
+	}
+
+	if _, err = d.accountDataAccessor.GetAccountById(ctx, params.AccountID); err != nil {
+		return err
+	}
+
+	return d.goquDatabase.WithTx(func(td *goqu.TxDatabase) error {
+		downloadTask, getDownloadTaskWithXLockErr := d.downloadTaskDataAccessor.WithDatabase(td).
+			GetDownloadTaskWithXLock(ctx, params.DownloadTaskID)
+		if getDownloadTaskWithXLockErr != nil {
+			return getDownloadTaskWithXLockErr
+		}
+
+		if err := checkDownloadTaskNotTrashed(downloadTask); err != nil {
+			return err
+		}
+
+		if err := d.downloadTaskPermissionLogic.CheckShareLevel(
+			ctx, accountId, downloadTask, go_idm_v1.DownloadTaskShareLevel_Manage,
+		); err != nil {
+			return err
+		}
+
+		if err := d.downloadTaskShareDataAccessor.WithDatabase(td).UpsertDownloadTaskShare(ctx, database.DownloadTaskShare{
+			OfDownloadTaskID: params.DownloadTaskID,
+			OfAccountID:      params.AccountID,
+			ShareLevel:       params.ShareLevel,
+		}); err != nil {
+			return err
+		}
+
+		return recordAuditEvent(
+			ctx, d.auditEventDataAccessor.WithDatabase(td), &accountId,
+			AuditEventActionDownloadTaskShare, AuditEventResourceTypeDownloadTask, params.DownloadTaskID,
+			nil, map[string]any{"account_id": params.AccountID, "share_level": params.ShareLevel.String()},
+		)
+	})
+}
+
+// UnshareDownloadTask implements DownloadTask.
+func (d *downloadTask) UnshareDownloadTask(ctx context.Context, params UnshareDownloadTaskParams) error {
+	accountId, _, err := d.tokenLogic.GetAccountIDAndExpireTime(ctx, params.Token)
+	if err != nil {
+		return err
+	}
+
+	return d.goquDatabase.WithTx(func(td *goqu.TxDatabase) error {
+		downloadTask, getDownloadTaskWithXLockErr := d.downloadTaskDataAccessor.WithDatabase(td).
+			GetDownloadTaskWithXLock(ctx, params.DownloadTaskID)
+		if getDownloadTaskWithXLockErr != nil {
+			return getDownloadTaskWithXLockErr
+		}
+
+		if err := checkDownloadTaskNotTrashed(downloadTask); err != nil {
+			return err
+		}
+
+		if err := d.downloadTaskPermissionLogic.CheckShareLevel(
+			ctx, accountId, downloadTask, go_idm_v1.DownloadTaskShareLevel_Manage,
+		); err != nil {
+			return err
+		}
+
+		if err := d.downloadTaskShareDataAccessor.WithDatabase(td).
+			DeleteDownloadTaskShare(ctx, params.DownloadTaskID, params.AccountID); err != nil {
+			return err
+		}
+
+		return recordAuditEvent(
+			ctx, d.auditEventDataAccessor.WithDatabase(td), &accountId,
+			AuditEventActionDownloadTaskUnshare, AuditEventResourceTypeDownloadTask, params.DownloadTaskID,
+			map[string]any{"account_id": params.AccountID}, nil,
+		)
+	})
+}
+
+func newDownloadTaskStatusChangedEvent(
+	downloadTask database.DownloadTask,
+	oldDownloadStatus go_idm_v1.DownloadStatus,
+) *go_idm_v1.DownloadTaskStatusChangedEvent {
+	return &go_idm_v1.DownloadTaskStatusChangedEvent{
+		DownloadTaskId:    downloadTask.ID,
+		OfAccountId:       downloadTask.OfAccountID,
+		OfTeamId:          lo.FromPtr(downloadTask.OfTeamID),
+		OldDownloadStatus: oldDownloadStatus,
+		NewDownloadStatus: downloadTask.DownloadStatus,
+	}
+}
+
+// byteCountWriter counts the bytes written to it, to get the size of a downloaded file while it is written.
+type byteCountWriter struct {
+	count uint64
+}
+
+func (b *byteCountWriter) Write(p []byte) (int, error) {
+	b.count += uint64(len(p))
+	return len(p), nil
+}
+
+// newWorkerId returns an id identifying this process among the workers holding download task leases.
+func newWorkerId() (string, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", err
+	}
+
+	randomBytes := make([]byte, 4)
+	if _, err = rand.Read(randomBytes); err != nil {
+		return "", err
 	}
 
-	return d.fileClient.Read(ctx, fileName.(string))
+	return fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), hex.EncodeToString(randomBytes)), nil
 }
@@ -0,0 +1,97 @@
+package logic
+
+import (
+	"context"
+	"errors"
+
+	"github.com/manhhung2111/go-idm/internal/dataaccess/database"
+	go_idm_v1 "github.com/manhhung2111/go-idm/internal/generated/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type DownloadTaskPermission interface {
+	GetShareLevel(ctx context.Context, accountId uint64, downloadTask database.DownloadTask) (go_idm_v1.DownloadTaskShareLevel, error)
+	CheckShareLevel(
+		ctx context.Context,
+		accountId uint64,
+		downloadTask database.DownloadTask,
+		requiredShareLevel go_idm_v1.DownloadTaskShareLevel,
+	) error
+}
+
+type downloadTaskPermission struct {
+	teamMemberDataAccessor        database.TeamMemberDataAccessor
+	downloadTaskShareDataAccessor database.DownloadTaskShareDataAccessor
+}
+
+func NewDownloadTaskPermission(
+	teamMemberDataAccessor database.TeamMemberDataAccessor,
+	downloadTaskShareDataAccessor database.DownloadTaskShareDataAccessor,
+) DownloadTaskPermission {
+	return &downloadTaskPermission{
+		teamMemberDataAccessor:        teamMemberDataAccessor,
+		downloadTaskShareDataAccessor: downloadTaskShareDataAccessor,
+	}
+}
+
+// GetShareLevel returns the highest access level the account has on the download task, either from owning
+// it, from being a member of the team it was created in, or from it being shared directly.
+func (d downloadTaskPermission) GetShareLevel(
+	ctx context.Context,
+	accountId uint64,
+	downloadTask database.DownloadTask,
+) (go_idm_v1.DownloadTaskShareLevel, error) {
+	if downloadTask.OfAccountID == accountId {
+		return go_idm_v1.DownloadTaskShareLevel_Manage, nil
+	}
+
+	shareLevel := go_idm_v1.DownloadTaskShareLevel_UndefinedDownloadTaskShareLevel
+	if downloadTask.OfTeamID != nil {
+		teamMember, err := d.teamMemberDataAccessor.GetTeamMember(ctx, *downloadTask.OfTeamID, accountId)
+		if err != nil && !errors.Is(err, database.ErrTeamMemberNotFound) {
+			return shareLevel, err
+		}
+
+		if err == nil {
+			if isTeamRoleAllowedToManageMembers(teamMember.TeamRole) {
+				return go_idm_v1.DownloadTaskShareLevel_Manage, nil
+			}
+
+			shareLevel = go_idm_v1.DownloadTaskShareLevel_Read
+		}
+	}
+
+	downloadTaskShare, err := d.downloadTaskShareDataAccessor.GetDownloadTaskShare(ctx, downloadTask.ID, accountId)
+	if err != nil {
+		if errors.Is(err, database.ErrDownloadTaskShareNotFound) {
+			return shareLevel, nil
+		}
+
+		return shareLevel, err
+	}
+
+	return max(shareLevel, downloadTaskShare.ShareLevel), nil
+}
+
+// CheckShareLevel implements DownloadTaskPermission.
+func (d downloadTaskPermission) CheckShareLevel(
+	ctx context.Context,
+	accountId uint64,
+	downloadTask database.DownloadTask,
+	requiredShareLevel go_idm_v1.DownloadTaskShareLevel,
+) error {
+	shareLevel, err := d.GetShareLevel(ctx, accountId, downloadTask)
+	if err != nil {
+		return err
+	}
+
+	if shareLevel < requiredShareLevel {
+		return status.Errorf(
+			codes.PermissionDenied,
+			"account does not have %s access to the download task", requiredShareLevel.String(),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/manhhung2111/go-idm/internal/config"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/database"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/file"
+	"github.com/manhhung2111/go-idm/internal/utils"
+	"go.uber.org/zap"
+)
+
+// DownloadTaskPurger empties the trash: once a download task has been in the trash for longer than the trash
+// retention, it is deleted for good along with its file.
+type DownloadTaskPurger interface {
+	Start(ctx context.Context) error
+}
+
+type downloadTaskPurger struct {
+	downloadTaskDataAccessor database.DownloadTaskDataAccessor
+	auditEventDataAccessor   database.AuditEventDataAccessor
+	goquDatabase             *goqu.Database
+	storageSet               file.StorageSet
+	trashRetention           time.Duration
+	purgeInterval            time.Duration
+	purgeBatchSize           uint64
+	logger                   *zap.Logger
+}
+
+func NewDownloadTaskPurger(
+	downloadTaskDataAccessor database.DownloadTaskDataAccessor,
+	auditEventDataAccessor database.AuditEventDataAccessor,
+	goquDatabase *goqu.Database,
+	storageSet file.StorageSet,
+	downloadConfig config.Download,
+	logger *zap.Logger,
+) (DownloadTaskPurger, error) {
+	trashRetention, err := downloadConfig.Trash.GetRetentionDuration()
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to parse download trash retention")
+		return nil, err
+	}
+
+	purgeInterval, err := downloadConfig.Trash.GetPurgeIntervalDuration()
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to parse download trash purge_interval")
+		return nil, err
+	}
+
+	return &downloadTaskPurger{
+		downloadTaskDataAccessor: downloadTaskDataAccessor,
+		auditEventDataAccessor:   auditEventDataAccessor,
+		goquDatabase:             goquDatabase,
+		storageSet:               storageSet,
+		trashRetention:           trashRetention,
+		purgeInterval:            purgeInterval,
+		purgeBatchSize:           downloadConfig.Trash.GetPurgeBatchSize(),
+		logger:                   logger,
+	}, nil
+}
+
+// Start implements DownloadTaskPurger.
+func (d *downloadTaskPurger) Start(ctx context.Context) error {
+	logger := utils.LoggerWithContext(ctx, d.logger)
+
+	ticker := time.NewTicker(d.purgeInterval)
+	defer ticker.Stop()
+
+	for {
+		purgedCount, err := d.purgeBatch(ctx)
+		if err != nil {
+			logger.With(zap.Error(err)).Error("failed to purge trashed download tasks")
+		}
+
+		// Keep going right away while there may be more download tasks to purge.
+		if err == nil && purgedCount == d.purgeBatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *downloadTaskPurger) purgeBatch(ctx context.Context) (uint64, error) {
+	logger := utils.LoggerWithContext(ctx, d.logger)
+
+	var downloadTaskList []database.DownloadTask
+	txErr := d.goquDatabase.WithTx(func(td *goqu.TxDatabase) error {
+		downloadTaskDataAccessor := d.downloadTaskDataAccessor.WithDatabase(td)
+		auditEventDataAccessor := d.auditEventDataAccessor.WithDatabase(td)
+
+		var err error
+		downloadTaskList, err = downloadTaskDataAccessor.
+			GetExpiredTrashedDownloadTaskListWithXLock(ctx, time.Now().Add(-d.trashRetention), d.purgeBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, downloadTask := range downloadTaskList {
+			if err = downloadTaskDataAccessor.DeleteDownloadTask(ctx, downloadTask.ID); err != nil {
+				return err
+			}
+
+			if err = recordAuditEvent(
+				ctx, auditEventDataAccessor, nil,
+				AuditEventActionDownloadTaskPurge, AuditEventResourceTypeDownloadTask, downloadTask.ID,
+				downloadTaskAuditEventValue(downloadTask), nil,
+			); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if txErr != nil {
+		return 0, txErr
+	}
+
+	// Files are only deleted once the rows are, so that a download task is never left without its file. A file
+	// that fails to be deleted is left behind, it does not belong to any download task anymore.
+	for _, downloadTask := range downloadTaskList {
+		fileClient, err := getDownloadTaskFileClient(d.storageSet, downloadTask)
+		if err == nil {
+			err = fileClient.Delete(ctx, getDownloadTaskStoredFileName(downloadTask))
+		}
+
+		if err != nil {
+			logger.
+				With(zap.Uint64("id", downloadTask.ID)).
+				With(zap.Error(err)).
+				Warn("failed to delete the file of purged download task")
+		}
+	}
+
+	if len(downloadTaskList) > 0 {
+		logger.With(zap.Int("count", len(downloadTaskList))).Info("purged trashed download tasks")
+	}
+
+	return uint64(len(downloadTaskList)), nil
+}
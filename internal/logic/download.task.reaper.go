@@ -0,0 +1,132 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/manhhung2111/go-idm/internal/config"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/database"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/kafka/producer"
+	"github.com/manhhung2111/go-idm/internal/utils"
+	"go.uber.org/zap"
+)
+
+// DownloadTaskReaper recovers the download tasks left in downloading by a worker that died: once the lease
+// of such a download task expires, it is put back into pending and enqueued again, to be executed from the
+// start by whichever worker picks it up.
+type DownloadTaskReaper interface {
+	Start(ctx context.Context) error
+}
+
+type downloadTaskReaper struct {
+	downloadTaskDataAccessor    database.DownloadTaskDataAccessor
+	downloadTaskCreatedProducer producer.DownloadTaskCreatedProducer
+	downloadTaskEventProducer   producer.DownloadTaskEventProducer
+	goquDatabase                *goqu.Database
+	leaseExpiresIn              time.Duration
+	reaperInterval              time.Duration
+	reaperBatchSize             uint64
+	logger                      *zap.Logger
+}
+
+func NewDownloadTaskReaper(
+	downloadTaskDataAccessor database.DownloadTaskDataAccessor,
+	downloadTaskCreatedProducer producer.DownloadTaskCreatedProducer,
+	downloadTaskEventProducer producer.DownloadTaskEventProducer,
+	goquDatabase *goqu.Database,
+	downloadConfig config.Download,
+	logger *zap.Logger,
+) (DownloadTaskReaper, error) {
+	leaseExpiresIn, err := downloadConfig.Lease.GetExpiresInDuration()
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to parse download lease expires_in")
+		return nil, err
+	}
+
+	reaperInterval, err := downloadConfig.Lease.GetReaperIntervalDuration()
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to parse download lease reaper_interval")
+		return nil, err
+	}
+
+	return &downloadTaskReaper{
+		downloadTaskDataAccessor:    downloadTaskDataAccessor,
+		downloadTaskCreatedProducer: downloadTaskCreatedProducer,
+		downloadTaskEventProducer:   downloadTaskEventProducer,
+		goquDatabase:                goquDatabase,
+		leaseExpiresIn:              leaseExpiresIn,
+		reaperInterval:              reaperInterval,
+		reaperBatchSize:             downloadConfig.Lease.GetReaperBatchSize(),
+		logger:                      logger,
+	}, nil
+}
+
+// Start implements DownloadTaskReaper.
+func (d *downloadTaskReaper) Start(ctx context.Context) error {
+	logger := utils.LoggerWithContext(ctx, d.logger)
+
+	ticker := time.NewTicker(d.reaperInterval)
+	defer ticker.Stop()
+
+	for {
+		reapedCount, err := d.reapBatch(ctx)
+		if err != nil {
+			logger.With(zap.Error(err)).Error("failed to reap expired download task leases")
+		}
+
+		// Keep going right away while there may be more expired leases.
+		if err == nil && reapedCount == d.reaperBatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *downloadTaskReaper) reapBatch(ctx context.Context) (uint64, error) {
+	logger := utils.LoggerWithContext(ctx, d.logger)
+
+	var reapedCount uint64
+	txErr := d.goquDatabase.WithTx(func(td *goqu.TxDatabase) error {
+		downloadTaskDataAccessor := d.downloadTaskDataAccessor.WithDatabase(td)
+		downloadTaskEventProducer := d.downloadTaskEventProducer.WithDatabase(td)
+		downloadTaskCreatedProducer := d.downloadTaskCreatedProducer.WithDatabase(td)
+
+		downloadTaskList, err := downloadTaskDataAccessor.
+			GetExpiredLeaseDownloadTaskListWithXLock(ctx, time.Now().Add(-d.leaseExpiresIn), d.reaperBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, downloadTask := range downloadTaskList {
+			logger.
+				With(zap.Uint64("id", downloadTask.ID)).
+				With(zap.Stringp("worker_id", downloadTask.WorkerID)).
+				With(zap.Timep("heartbeat_time", downloadTask.HeartbeatTime)).
+				Warn("download task lease expired, putting download task back into pending")
+
+			if err = downloadTaskDataAccessor.UpdateDownloadTaskLease(ctx, downloadTask.ID, nil, nil); err != nil {
+				return err
+			}
+
+			if err = putDownloadTaskBackIntoPending(
+				ctx, downloadTaskDataAccessor, downloadTaskEventProducer, downloadTaskCreatedProducer, downloadTask,
+			); err != nil {
+				return err
+			}
+		}
+
+		reapedCount = uint64(len(downloadTaskList))
+		return nil
+	})
+	if txErr != nil {
+		return 0, txErr
+	}
+
+	return reapedCount, nil
+}
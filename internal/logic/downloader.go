@@ -19,25 +19,67 @@ const (
     chunkSize   = 5 * 1024 * 1024 // 5MB per chunk (IDM default 5–8MB)
 )
 
+// DownloadProbe is what is known of a file before downloading it. FileSize is -1 if unknown.
+type DownloadProbe struct {
+	FileSize    int64
+	ContentType string
+}
+
 type Downloader interface {
+	// Probe looks up the size and content type of the file without downloading it, to decide where to store
+	// it.
+	Probe(ctx context.Context) (DownloadProbe, error)
 	Download(ctx context.Context, writer io.Writer) (map[string]any, error)
 }
 
 type HTTPDownloader struct {
-	url    string
-	logger *zap.Logger
+	url               string
+	connectionLimiter ConnectionLimiter
+	logger            *zap.Logger
 }
 
 func NewHTTPDownloader(
 	url string,
+	connectionLimiter ConnectionLimiter,
 	logger *zap.Logger,
 ) Downloader {
 	return &HTTPDownloader{
-		url:    url,
-		logger: logger,
+		url:               url,
+		connectionLimiter: connectionLimiter,
+		logger:            logger,
 	}
 }
 
+// Probe implements Downloader, with a HEAD request.
+func (h HTTPDownloader) Probe(ctx context.Context) (DownloadProbe, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, h.url, http.NoBody)
+	if err != nil {
+		return DownloadProbe{}, err
+	}
+
+	if err = h.connectionLimiter.Acquire(ctx); err != nil {
+		return DownloadProbe{}, err
+	}
+
+	defer h.connectionLimiter.Release()
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return DownloadProbe{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return DownloadProbe{}, fmt.Errorf("unexpected status of HEAD request: %d", resp.StatusCode)
+	}
+
+	return DownloadProbe{
+		FileSize:    resp.ContentLength,
+		ContentType: resp.Header.Get(HTTPResponseHeaderContentType),
+	}, nil
+}
+
 func (h HTTPDownloader) Download(ctx context.Context, writer io.Writer) (map[string]any, error) {
 	logger := utils.LoggerWithContext(ctx, h.logger)
 
@@ -80,6 +122,12 @@ func (h HTTPDownloader) sequentialDownload(
 		return nil, err
 	}
 
+	if err = h.connectionLimiter.Acquire(ctx); err != nil {
+		return nil, err
+	}
+
+	defer h.connectionLimiter.Release()
+
 	start := time.Now()
 
 	resp, err := http.DefaultClient.Do(req)
@@ -159,27 +207,7 @@ func (h HTTPDownloader) parallelDownload(
                 startByte := int64(idx) * chunkSize
                 endByte := min(startByte+chunkSize-1, totalSize-1)
 
-                req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.url, nil)
-                if err != nil {
-                    results <- chunkResult{idx, nil, err}
-                    return
-                }
-                req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", startByte, endByte))
-
-                resp, err := httpClient.Do(req)
-                if err != nil {
-                    results <- chunkResult{idx, nil, err}
-                    return
-                }
-
-                if resp.StatusCode != http.StatusPartialContent {
-                    resp.Body.Close()
-                    results <- chunkResult{idx, nil, fmt.Errorf("expected 206, got %d", resp.StatusCode)}
-                    return
-                }
-
-                data, err := io.ReadAll(resp.Body)
-                resp.Body.Close()
+                data, err := h.downloadChunk(ctx, httpClient, startByte, endByte)
                 if err != nil {
                     results <- chunkResult{idx, nil, err}
                     return
@@ -242,4 +270,37 @@ func (h HTTPDownloader) parallelDownload(
     }, nil
 }
 
+// downloadChunk downloads the bytes from startByte to endByte of the file, holding a connection of the
+// connection limiter while doing so.
+func (h HTTPDownloader) downloadChunk(
+	ctx context.Context,
+	httpClient *http.Client,
+	startByte int64,
+	endByte int64,
+) ([]byte, error) {
+	if err := h.connectionLimiter.Acquire(ctx); err != nil {
+		return nil, err
+	}
+
+	defer h.connectionLimiter.Release()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", startByte, endByte))
 
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusPartialContent {
+		return nil, fmt.Errorf("expected 206, got %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(resp.Body)
+}
@@ -0,0 +1,75 @@
+package logic
+
+import (
+	"context"
+	"errors"
+
+	"github.com/manhhung2111/go-idm/internal/dataaccess/file"
+	"github.com/manhhung2111/go-idm/internal/utils"
+	"go.uber.org/zap"
+)
+
+var errEncryptionNotEnabled = errors.New("download file encryption is not enabled")
+
+type RotateMasterKeyOutput struct {
+	RewrappedCount uint64
+}
+
+// MasterKeyRotation wraps the data keys of the encrypted files of every storage with the current master key.
+// Rotating the master key is done by adding a new master key, making it the current one, then rotating: the
+// previous master key can be removed once no data key is wrapped by it anymore. The files are written again
+// with their new header, but their content is not encrypted again.
+type MasterKeyRotation interface {
+	RotateMasterKey(ctx context.Context) (RotateMasterKeyOutput, error)
+}
+
+type masterKeyRotation struct {
+	storageSet file.StorageSet
+	logger     *zap.Logger
+}
+
+func NewMasterKeyRotation(
+	storageSet file.StorageSet,
+	logger *zap.Logger,
+) MasterKeyRotation {
+	return &masterKeyRotation{
+		storageSet: storageSet,
+		logger:     logger,
+	}
+}
+
+// RotateMasterKey implements MasterKeyRotation.
+func (m *masterKeyRotation) RotateMasterKey(ctx context.Context) (RotateMasterKeyOutput, error) {
+	logger := utils.LoggerWithContext(ctx, m.logger)
+
+	var (
+		output    RotateMasterKeyOutput
+		encrypted bool
+	)
+
+	for _, storageName := range m.storageSet.GetStorageNameList() {
+		fileClient, err := m.storageSet.GetClient(storageName)
+		if err != nil {
+			return output, err
+		}
+
+		dataKeyRewrapClient, ok := fileClient.(file.DataKeyRewrapClient)
+		if !ok {
+			continue
+		}
+
+		encrypted = true
+		rewrappedCount, err := dataKeyRewrapClient.RewrapDataKeys(ctx)
+		output.RewrappedCount += rewrappedCount
+		if err != nil {
+			logger.With(zap.String("storage", storageName)).With(zap.Error(err)).Error("failed to wrap data keys again")
+			return output, err
+		}
+	}
+
+	if !encrypted {
+		return output, errEncryptionNotEnabled
+	}
+
+	return output, nil
+}
@@ -0,0 +1,406 @@
+package logic
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/manhhung2111/go-idm/internal/config"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/cache"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/database"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/file"
+	go_idm_v1 "github.com/manhhung2111/go-idm/internal/generated/proto"
+	"github.com/manhhung2111/go-idm/internal/utils"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	shareLinkTokenByteCount = 32
+	shareLinkURLFormat      = "%s/share-links/%s"
+	defaultContentType      = "application/octet-stream"
+)
+
+var (
+	errShareLinkNotFound                = status.Error(codes.NotFound, "share link not found")
+	errShareLinkExpired                 = status.Error(codes.NotFound, "share link has expired")
+	errShareLinkDownloadExceeded        = status.Error(codes.ResourceExhausted, "share link has reached its maximum download count")
+	errShareLinkIncorrectPassword       = status.Error(codes.Unauthenticated, "incorrect share link password")
+	errShareLinkPasswordFailureExceeded = status.Error(
+		codes.ResourceExhausted, "too many incorrect share link passwords, try again later",
+	)
+)
+
+type CreateShareLinkParams struct {
+	Token            string
+	DownloadTaskID   uint64
+	Password         string
+	ExpiresIn        time.Duration
+	MaxDownloadCount uint64
+}
+
+type CreateShareLinkOutput struct {
+	ShareLink *go_idm_v1.ShareLink
+}
+
+type RevokeShareLinkParams struct {
+	Token       string
+	ShareLinkID uint64
+}
+
+type GetShareLinkFileParams struct {
+	LinkToken string
+	Password  string
+}
+
+// GetShareLinkFileOutput holds either a reader of the shared file, or a URL the client should be redirected
+// to in order to download it directly from the storage.
+type GetShareLinkFileOutput struct {
+	Reader      io.ReadCloser
+	RedirectURL string
+	FileName    string
+	ContentType string
+}
+
+type ShareLink interface {
+	CreateShareLink(ctx context.Context, params CreateShareLinkParams) (CreateShareLinkOutput, error)
+	RevokeShareLink(ctx context.Context, params RevokeShareLinkParams) error
+	GetShareLinkFile(ctx context.Context, params GetShareLinkFileParams) (GetShareLinkFileOutput, error)
+}
+
+type shareLink struct {
+	tokenLogic                    Token
+	hashLogic                     Hash
+	downloadTaskPermissionLogic   DownloadTaskPermission
+	downloadTaskDataAccessor      database.DownloadTaskDataAccessor
+	shareLinkDataAccessor         database.ShareLinkDataAccessor
+	shareLinkPasswordFailureCache cache.ShareLinkPasswordFailureCache
+	goquDatabase                  *goqu.Database
+	storageSet                    file.StorageSet
+	baseURL                       string
+	presignedURLExpiresIn         time.Duration
+	passwordFailureLimit          uint64
+	passwordFailureWindow         time.Duration
+	logger                        *zap.Logger
+}
+
+func NewShareLink(
+	tokenLogic Token,
+	hashLogic Hash,
+	downloadTaskPermissionLogic DownloadTaskPermission,
+	downloadTaskDataAccessor database.DownloadTaskDataAccessor,
+	shareLinkDataAccessor database.ShareLinkDataAccessor,
+	shareLinkPasswordFailureCache cache.ShareLinkPasswordFailureCache,
+	goquDatabase *goqu.Database,
+	storageSet file.StorageSet,
+	httpConfig config.HTTP,
+	logger *zap.Logger,
+) (ShareLink, error) {
+	presignedURLExpiresIn, err := httpConfig.ShareLink.GetPresignedURLExpiresInDuration()
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to parse presigned_url_expires_in")
+		return nil, err
+	}
+
+	passwordFailureWindow, err := httpConfig.ShareLink.GetPasswordFailureWindowDuration()
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to parse password_failure_window")
+		return nil, err
+	}
+
+	return &shareLink{
+		tokenLogic:                    tokenLogic,
+		hashLogic:                     hashLogic,
+		downloadTaskPermissionLogic:   downloadTaskPermissionLogic,
+		downloadTaskDataAccessor:      downloadTaskDataAccessor,
+		shareLinkDataAccessor:         shareLinkDataAccessor,
+		shareLinkPasswordFailureCache: shareLinkPasswordFailureCache,
+		goquDatabase:                  goquDatabase,
+		storageSet:                    storageSet,
+		baseURL:                       strings.TrimSuffix(httpConfig.ShareLink.BaseURL, "/"),
+		presignedURLExpiresIn:         presignedURLExpiresIn,
+		passwordFailureLimit:          httpConfig.ShareLink.GetPasswordFailureLimit(),
+		passwordFailureWindow:         passwordFailureWindow,
+		logger:                        logger,
+	}, nil
+}
+
+// CreateShareLink implements ShareLink.
+func (s *shareLink) CreateShareLink(ctx context.Context, params CreateShareLinkParams) (CreateShareLinkOutput, error) {
+	logger := utils.LoggerWithContext(ctx, s.logger).With(zap.Uint64("download_task_id", params.DownloadTaskID))
+
+	accountId, _, err := s.tokenLogic.GetAccountIDAndExpireTime(ctx, params.Token)
+	if err != nil {
+		return CreateShareLinkOutput{}, err
+	}
+
+	downloadTask, err := s.downloadTaskDataAccessor.GetDownloadTask(ctx, params.DownloadTaskID)
+	if err != nil {
+		return CreateShareLinkOutput{}, err
+	}
+
+	if err = checkDownloadTaskNotTrashed(downloadTask); err != nil {
+		return CreateShareLinkOutput{}, err
+	}
+
+	if err = s.downloadTaskPermissionLogic.CheckShareLevel(
+		ctx, accountId, downloadTask, go_idm_v1.DownloadTaskShareLevel_Manage,
+	); err != nil {
+		return CreateShareLinkOutput{}, err
+	}
+
+	if _, err = getDownloadTaskFileName(downloadTask); err != nil {
+		return CreateShareLinkOutput{}, err
+	}
+
+	linkTokenBytes := make([]byte, shareLinkTokenByteCount)
+	if _, err = rand.Read(linkTokenBytes); err != nil {
+		logger.With(zap.Error(err)).Error("failed to generate share link token")
+		return CreateShareLinkOutput{}, status.Error(codes.Internal, "failed to generate share link token")
+	}
+
+	linkToken := base64.RawURLEncoding.EncodeToString(linkTokenBytes)
+	databaseShareLink := database.ShareLink{
+		OfDownloadTaskID: params.DownloadTaskID,
+		OfAccountID:      accountId,
+		HashedLinkToken:  hashShareLinkToken(linkToken),
+		MaxDownloadCount: params.MaxDownloadCount,
+	}
+
+	if params.Password != "" {
+		databaseShareLink.HashedPassword, err = s.hashLogic.Hash(ctx, params.Password)
+		if err != nil {
+			return CreateShareLinkOutput{}, err
+		}
+	}
+
+	if params.ExpiresIn > 0 {
+		expireTime := time.Now().Add(params.ExpiresIn)
+		databaseShareLink.ExpireTime = &expireTime
+	}
+
+	databaseShareLink.ID, err = s.shareLinkDataAccessor.CreateShareLink(ctx, databaseShareLink)
+	if err != nil {
+		return CreateShareLinkOutput{}, err
+	}
+
+	protoShareLink := s.databaseShareLinkToProtoShareLink(databaseShareLink)
+	protoShareLink.LinkToken = linkToken
+	protoShareLink.Url = fmt.Sprintf(shareLinkURLFormat, s.baseURL, linkToken)
+
+	return CreateShareLinkOutput{
+		ShareLink: protoShareLink,
+	}, nil
+}
+
+// RevokeShareLink implements ShareLink.
+func (s *shareLink) RevokeShareLink(ctx context.Context, params RevokeShareLinkParams) error {
+	accountId, _, err := s.tokenLogic.GetAccountIDAndExpireTime(ctx, params.Token)
+	if err != nil {
+		return err
+	}
+
+	return s.goquDatabase.WithTx(func(td *goqu.TxDatabase) error {
+		databaseShareLink, err := s.shareLinkDataAccessor.WithDatabase(td).GetShareLink(ctx, params.ShareLinkID)
+		if err != nil {
+			return err
+		}
+
+		downloadTask, err := s.downloadTaskDataAccessor.WithDatabase(td).
+			GetDownloadTaskWithXLock(ctx, databaseShareLink.OfDownloadTaskID)
+		if err != nil {
+			return err
+		}
+
+		if err = s.downloadTaskPermissionLogic.CheckShareLevel(
+			ctx, accountId, downloadTask, go_idm_v1.DownloadTaskShareLevel_Manage,
+		); err != nil {
+			return err
+		}
+
+		return s.shareLinkDataAccessor.WithDatabase(td).RevokeShareLink(ctx, params.ShareLinkID)
+	})
+}
+
+// GetShareLinkFile implements ShareLink.
+func (s *shareLink) GetShareLinkFile(ctx context.Context, params GetShareLinkFileParams) (GetShareLinkFileOutput, error) {
+	databaseShareLink, err := s.shareLinkDataAccessor.GetShareLinkByHashedLinkToken(ctx, hashShareLinkToken(params.LinkToken))
+	if err != nil {
+		if errors.Is(err, database.ErrShareLinkNotFound) {
+			return GetShareLinkFileOutput{}, errShareLinkNotFound
+		}
+
+		return GetShareLinkFileOutput{}, err
+	}
+
+	logger := utils.LoggerWithContext(ctx, s.logger).With(zap.Uint64("share_link_id", databaseShareLink.ID))
+
+	if databaseShareLink.Revoked {
+		return GetShareLinkFileOutput{}, errShareLinkNotFound
+	}
+
+	if databaseShareLink.ExpireTime != nil && time.Now().After(*databaseShareLink.ExpireTime) {
+		return GetShareLinkFileOutput{}, errShareLinkExpired
+	}
+
+	if databaseShareLink.HashedPassword != "" {
+		if err = s.checkShareLinkPassword(ctx, databaseShareLink, params.Password); err != nil {
+			return GetShareLinkFileOutput{}, err
+		}
+	}
+
+	downloadTask, err := s.downloadTaskDataAccessor.GetDownloadTask(ctx, databaseShareLink.OfDownloadTaskID)
+	if err != nil {
+		return GetShareLinkFileOutput{}, err
+	}
+
+	if downloadTask.IsTrashed() {
+		return GetShareLinkFileOutput{}, errShareLinkNotFound
+	}
+
+	fileName, err := getDownloadTaskFileName(downloadTask)
+	if err != nil {
+		return GetShareLinkFileOutput{}, err
+	}
+
+	fileClient, err := getDownloadTaskFileClient(s.storageSet, downloadTask)
+	if err != nil {
+		return GetShareLinkFileOutput{}, err
+	}
+
+	output := GetShareLinkFileOutput{
+		FileName:    getDownloadTaskAttachmentName(downloadTask, fileName),
+		ContentType: getDownloadTaskContentType(downloadTask),
+	}
+
+	// A presigned URL would serve a compressed file as it is stored.
+	presignedURLClient, ok := fileClient.(file.PresignedURLClient)
+	if ok && s.presignedURLExpiresIn > 0 && getDownloadTaskCompressionCodec(downloadTask) == "" {
+		output.RedirectURL, err = presignedURLClient.GetPresignedURL(ctx, fileName, s.presignedURLExpiresIn)
+		if err != nil {
+			logger.With(zap.Error(err)).Warn("failed to get presigned url, will fall back to streaming")
+		}
+	}
+
+	if output.RedirectURL == "" {
+		output.Reader, err = readDownloadTaskFile(ctx, fileClient, downloadTask, fileName, 0, 0)
+		if err != nil {
+			return GetShareLinkFileOutput{}, err
+		}
+	}
+
+	// The download is only counted once the file could be opened, for a failure of the storage not to use up
+	// the downloads of the link.
+	increased, err := s.shareLinkDataAccessor.IncreaseShareLinkDownloadCount(ctx, databaseShareLink.ID)
+	if err != nil || !increased {
+		if output.Reader != nil {
+			_ = output.Reader.Close()
+		}
+
+		if err != nil {
+			return GetShareLinkFileOutput{}, err
+		}
+
+		return GetShareLinkFileOutput{}, errShareLinkDownloadExceeded
+	}
+
+	return output, nil
+}
+
+// checkShareLinkPassword checks the password given to a share link, refusing any once too many incorrect ones
+// were given within the failure window.
+func (s *shareLink) checkShareLinkPassword(
+	ctx context.Context,
+	databaseShareLink database.ShareLink,
+	password string,
+) error {
+	failureCount, err := s.shareLinkPasswordFailureCache.Get(ctx, databaseShareLink.ID)
+	if err != nil {
+		return err
+	}
+
+	if failureCount >= s.passwordFailureLimit {
+		return errShareLinkPasswordFailureExceeded
+	}
+
+	isHashEqual, err := s.hashLogic.IsHashEqual(ctx, password, databaseShareLink.HashedPassword)
+	if err != nil {
+		return err
+	}
+
+	if !isHashEqual {
+		if _, err = s.shareLinkPasswordFailureCache.Increase(
+			ctx, databaseShareLink.ID, s.passwordFailureWindow,
+		); err != nil {
+			return err
+		}
+
+		return errShareLinkIncorrectPassword
+	}
+
+	return nil
+}
+
+func (s shareLink) databaseShareLinkToProtoShareLink(databaseShareLink database.ShareLink) *go_idm_v1.ShareLink {
+	protoShareLink := &go_idm_v1.ShareLink{
+		Id:                databaseShareLink.ID,
+		DownloadTaskId:    databaseShareLink.OfDownloadTaskID,
+		PasswordProtected: databaseShareLink.HashedPassword != "",
+		MaxDownloadCount:  databaseShareLink.MaxDownloadCount,
+		DownloadCount:     databaseShareLink.DownloadCount,
+	}
+
+	if databaseShareLink.ExpireTime != nil {
+		protoShareLink.ExpireTime = uint64(databaseShareLink.ExpireTime.Unix())
+	}
+
+	return protoShareLink
+}
+
+// hashShareLinkToken returns the value stored in place of a share link token, so that reading the database
+// is not enough to download the shared files.
+func hashShareLinkToken(linkToken string) string {
+	hashedLinkToken := sha256.Sum256([]byte(linkToken))
+	return hex.EncodeToString(hashedLinkToken[:])
+}
+
+// getDownloadTaskAttachmentName returns the file name a client should save the download task's file as,
+// taken from the last segment of the download URL when possible.
+func getDownloadTaskAttachmentName(downloadTask database.DownloadTask, fileName string) string {
+	parsedURL, err := url.Parse(downloadTask.URL)
+	if err != nil {
+		return fileName
+	}
+
+	baseName := path.Base(parsedURL.Path)
+	if baseName == "." || baseName == "/" {
+		return fileName
+	}
+
+	return baseName
+}
+
+func getDownloadTaskContentType(downloadTask database.DownloadTask) string {
+	downloadTaskMetadata, ok := downloadTask.Metadata.Data.(map[string]any)
+	if !ok {
+		return defaultContentType
+	}
+
+	contentType, ok := downloadTaskMetadata[HTTPMetadataKeyContentType].(string)
+	if !ok || contentType == "" {
+		return defaultContentType
+	}
+
+	return contentType
+}
@@ -0,0 +1,365 @@
+package logic
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/manhhung2111/go-idm/internal/config"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/database"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/file"
+	go_idm_v1 "github.com/manhhung2111/go-idm/internal/generated/proto"
+	"github.com/manhhung2111/go-idm/internal/utils"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	storageMigrationBatchSize          = 100
+	defaultStorageMigrationConcurrency = 4
+)
+
+type MigrateStorageParams struct {
+	// From and To are the names of the storages to migrate from and to, or their mode, local or s3, if only
+	// one storage is of that mode.
+	From string
+	To   string
+	// Concurrency bounds the files copied at the same time.
+	Concurrency uint64
+	// DeleteSource deletes the file from From once it is copied and the download task points to To.
+	DeleteSource bool
+	// MinFileAge leaves in From the files modified less than MinFileAge ago.
+	MinFileAge time.Duration
+}
+
+type MigrateStorageFailure struct {
+	DownloadTaskID uint64
+	Error          error
+}
+
+type MigrateStorageOutput struct {
+	MigratedCount uint64
+	SkippedCount  uint64
+	FailureList   []MigrateStorageFailure
+}
+
+// StorageMigration moves the files of the succeeded download tasks from a storage to another. A download task
+// points to the storage its file is in with the storage field of its metadata, the download tasks downloaded
+// before it was recorded are taken to be in the legacy storage. A migration can be stopped and run again,
+// the download tasks already pointing to the storage migrated to are skipped.
+type StorageMigration interface {
+	MigrateStorage(ctx context.Context, params MigrateStorageParams) (MigrateStorageOutput, error)
+}
+
+type storageMigration struct {
+	downloadTaskDataAccessor database.DownloadTaskDataAccessor
+	goquDatabase             *goqu.Database
+	storageSet               file.StorageSet
+	logger                   *zap.Logger
+}
+
+func NewStorageMigration(
+	downloadTaskDataAccessor database.DownloadTaskDataAccessor,
+	goquDatabase *goqu.Database,
+	storageSet file.StorageSet,
+	logger *zap.Logger,
+) StorageMigration {
+	return &storageMigration{
+		downloadTaskDataAccessor: downloadTaskDataAccessor,
+		goquDatabase:             goquDatabase,
+		storageSet:               storageSet,
+		logger:                   logger,
+	}
+}
+
+// MigrateStorage implements StorageMigration.
+func (s *storageMigration) MigrateStorage(ctx context.Context, params MigrateStorageParams) (MigrateStorageOutput, error) {
+	from, err := s.storageSet.ResolveStorageName(params.From)
+	if err != nil {
+		return MigrateStorageOutput{}, err
+	}
+
+	to, err := s.storageSet.ResolveStorageName(params.To)
+	if err != nil {
+		return MigrateStorageOutput{}, err
+	}
+
+	if from == to {
+		return MigrateStorageOutput{}, fmt.Errorf("can not migrate storage %s to itself", from)
+	}
+
+	params.From, params.To = from, to
+	logger := utils.LoggerWithContext(ctx, s.logger).
+		With(zap.String("from", params.From)).
+		With(zap.String("to", params.To))
+
+	fromFileClient, err := s.storageSet.GetClient(params.From)
+	if err != nil {
+		return MigrateStorageOutput{}, err
+	}
+
+	toFileClient, err := s.storageSet.GetClient(params.To)
+	if err != nil {
+		return MigrateStorageOutput{}, err
+	}
+
+	concurrency := params.Concurrency
+	if concurrency == 0 {
+		concurrency = defaultStorageMigrationConcurrency
+	}
+
+	var (
+		output      MigrateStorageOutput
+		outputMutex sync.Mutex
+		afterId     uint64
+	)
+
+	for {
+		// Trashed download tasks are migrated too, their file is deleted from the storage they point to when
+		// they are purged.
+		downloadTaskList, err := s.downloadTaskDataAccessor.GetDownloadTaskListOfStatusAfterId(
+			ctx, go_idm_v1.DownloadStatus_Succeeded, true, afterId, storageMigrationBatchSize,
+		)
+		if err != nil {
+			return output, err
+		}
+
+		errGroup, errGroupCtx := errgroup.WithContext(ctx)
+		errGroup.SetLimit(int(concurrency))
+		for _, downloadTask := range downloadTaskList {
+			errGroup.Go(func() error {
+				migrated, err := s.migrateDownloadTaskFile(
+					errGroupCtx, fromFileClient, toFileClient, downloadTask, params,
+				)
+
+				outputMutex.Lock()
+				defer outputMutex.Unlock()
+
+				switch {
+				case err != nil:
+					logger.With(zap.Uint64("id", downloadTask.ID)).With(zap.Error(err)).Error("failed to migrate download task file")
+					output.FailureList = append(output.FailureList, MigrateStorageFailure{
+						DownloadTaskID: downloadTask.ID,
+						Error:          err,
+					})
+				case migrated:
+					output.MigratedCount++
+				default:
+					output.SkippedCount++
+				}
+
+				// A download task failing to migrate does not stop the others, only the context being done does.
+				return errGroupCtx.Err()
+			})
+		}
+
+		if err = errGroup.Wait(); err != nil {
+			return output, err
+		}
+
+		if uint64(len(downloadTaskList)) < storageMigrationBatchSize {
+			return output, nil
+		}
+
+		afterId = downloadTaskList[len(downloadTaskList)-1].ID
+	}
+}
+
+// migrateDownloadTaskFile copies the file of a download task from a storage to the other, checks the copy and
+// points the download task to it. It returns false if the download task does not point to the storage
+// migrated from, or if its file is not old enough to be migrated.
+func (s *storageMigration) migrateDownloadTaskFile(
+	ctx context.Context,
+	fromFileClient file.Client,
+	toFileClient file.Client,
+	downloadTask database.DownloadTask,
+	params MigrateStorageParams,
+) (bool, error) {
+	logger := utils.LoggerWithContext(ctx, s.logger).With(zap.Uint64("id", downloadTask.ID))
+
+	legacyStorageName := s.storageSet.GetLegacyStorageName()
+	if getDownloadTaskStorage(downloadTask, legacyStorageName) != params.From {
+		return false, nil
+	}
+
+	fileName, err := getDownloadTaskFileName(downloadTask)
+	if err != nil {
+		return false, err
+	}
+
+	if params.MinFileAge > 0 {
+		fileInfo, err := fromFileClient.Stat(ctx, fileName)
+		if err != nil {
+			return false, err
+		}
+
+		if time.Since(fileInfo.ModifiedTime) < params.MinFileAge {
+			return false, nil
+		}
+	}
+
+	// The recorded checksum is the one of the content of the file, which is computed decompressed if the file
+	// is compressed.
+	fileChecksum, err := copyFile(
+		ctx, fromFileClient, toFileClient, fileName, getDownloadTaskCompressionCodec(downloadTask),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	downloadTaskMetadata := downloadTask.Metadata.Data.(map[string]any)
+	if expectedChecksum, ok := downloadTaskMetadata[downloadTaskMetadataFieldNameChecksum].(string); ok &&
+		expectedChecksum != fileChecksum {
+		return false, fmt.Errorf("file %s in %s does not match its recorded checksum", fileName, params.From)
+	}
+
+	updated := false
+	txErr := s.goquDatabase.WithTx(func(td *goqu.TxDatabase) error {
+		downloadTaskDataAccessor := s.downloadTaskDataAccessor.WithDatabase(td)
+		lockedDownloadTask, err := downloadTaskDataAccessor.GetDownloadTaskWithXLock(ctx, downloadTask.ID)
+		if err != nil {
+			return err
+		}
+
+		// The download task may have been executed again while its file was copied.
+		lockedFileName, err := getDownloadTaskFileName(lockedDownloadTask)
+		if err != nil || lockedFileName != fileName || getDownloadTaskStorage(lockedDownloadTask, legacyStorageName) != params.From {
+			logger.Warn("download task changed while its file was migrated, will not point it to the copy")
+			return nil
+		}
+
+		lockedDownloadTask.Metadata.Data.(map[string]any)[downloadTaskMetadataFieldNameStorage] = params.To
+		if err = downloadTaskDataAccessor.UpdateDownloadTask(ctx, lockedDownloadTask); err != nil {
+			return err
+		}
+
+		updated = true
+		return nil
+	})
+	if txErr != nil {
+		return false, txErr
+	}
+
+	if !updated {
+		return false, nil
+	}
+
+	if params.DeleteSource {
+		if err = fromFileClient.Delete(ctx, fileName); err != nil {
+			logger.With(zap.Error(err)).Warn("failed to delete migrated file from the storage migrated from")
+		}
+	}
+
+	return true, nil
+}
+
+// getDownloadTaskStorage returns the name of the storage the file of a download task is in, or
+// legacyStorageName for the download tasks downloaded before it was recorded.
+func getDownloadTaskStorage(downloadTask database.DownloadTask, legacyStorageName string) string {
+	if storageName, ok := getRecordedDownloadTaskStorage(downloadTask); ok {
+		return storageName
+	}
+
+	return legacyStorageName
+}
+
+// getRecordedDownloadTaskStorage returns the name of the storage recorded in the metadata of a download task,
+// and false if the download task was downloaded before it was recorded.
+func getRecordedDownloadTaskStorage(downloadTask database.DownloadTask) (string, bool) {
+	downloadTaskMetadata, ok := downloadTask.Metadata.Data.(map[string]any)
+	if !ok {
+		return "", false
+	}
+
+	storageName, ok := downloadTaskMetadata[downloadTaskMetadataFieldNameStorage].(string)
+	return storageName, ok && storageName != ""
+}
+
+// getDownloadTaskFileClient returns the client of the storage the file of a download task is in.
+func getDownloadTaskFileClient(storageSet file.StorageSet, downloadTask database.DownloadTask) (file.Client, error) {
+	storageName := getDownloadTaskStorage(downloadTask, storageSet.GetLegacyStorageName())
+	if storageName == "" {
+		return nil, status.Error(
+			codes.FailedPrecondition,
+			"download task does not record its storage, set download.placement.legacy_storage",
+		)
+	}
+
+	return storageSet.GetClient(storageName)
+}
+
+// copyFile copies a file from a storage to another, returning the checksum of what it copied, decompressed with
+// compressionCodec if it is set. The checksum is computed while copying, for the file to be read only once.
+func copyFile(
+	ctx context.Context,
+	fromFileClient, toFileClient file.Client,
+	fileName string,
+	compressionCodec config.DownloadCompressionCodec,
+) (string, error) {
+	fileReadCloser, err := fromFileClient.Read(ctx, fileName)
+	if err != nil {
+		return "", err
+	}
+
+	defer fileReadCloser.Close()
+
+	fileWriteCloser, err := toFileClient.Write(ctx, fileName)
+	if err != nil {
+		return "", err
+	}
+
+	fileChecksumHash := sha256.New()
+	checksumWriter := io.Writer(fileChecksumHash)
+	waitChecksum := func() error { return nil }
+	if compressionCodec != "" {
+		// The copied content is decompressed on the side as it goes through a pipe.
+		pipeReader, pipeWriter := io.Pipe()
+		checksumErrChannel := make(chan error, 1)
+		go func() {
+			decompressingReader, err := file.NewDecompressingReader(io.NopCloser(pipeReader), compressionCodec)
+			if err == nil {
+				_, err = io.Copy(fileChecksumHash, decompressingReader)
+				_ = decompressingReader.Close()
+			}
+
+			// The pipe is closed with the error for the copy to stop rather than block on a write, or drained
+			// for the copy to finish.
+			if err != nil {
+				_ = pipeReader.CloseWithError(fmt.Errorf("failed to decompress: %w", err))
+			} else {
+				_, _ = io.Copy(io.Discard, pipeReader)
+			}
+
+			checksumErrChannel <- err
+		}()
+
+		checksumWriter = pipeWriter
+		waitChecksum = func() error {
+			_ = pipeWriter.Close()
+			return <-checksumErrChannel
+		}
+	}
+
+	_, err = io.Copy(io.MultiWriter(fileWriteCloser, checksumWriter), fileReadCloser)
+	if checksumErr := waitChecksum(); err == nil && checksumErr != nil {
+		err = fmt.Errorf("failed to decompress: %w", checksumErr)
+	}
+
+	if err != nil {
+		_ = fileWriteCloser.Abort()
+		return "", fmt.Errorf("failed to copy file %s: %w", fileName, err)
+	}
+
+	if err = fileWriteCloser.Close(); err != nil {
+		return "", fmt.Errorf("failed to copy file %s: %w", fileName, err)
+	}
+
+	return hex.EncodeToString(fileChecksumHash.Sum(nil)), nil
+}
@@ -0,0 +1,90 @@
+package logic
+
+import (
+	"mime"
+	"slices"
+	"strings"
+
+	"github.com/manhhung2111/go-idm/internal/config"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/database"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/file"
+)
+
+// StoragePlacement picks the storage the file of a download task is downloaded to, following the placement
+// rules of the config.
+type StoragePlacement interface {
+	GetStorageName(downloadTask database.DownloadTask, downloadProbe DownloadProbe) string
+}
+
+type storagePlacement struct {
+	ruleList           []config.DownloadPlacementRule
+	defaultStorageName string
+}
+
+func NewStoragePlacement(
+	storageSet file.StorageSet,
+	downloadConfig config.Download,
+) (StoragePlacement, error) {
+	for _, rule := range downloadConfig.Placement.Rules {
+		if _, err := storageSet.GetClient(rule.Storage); err != nil {
+			return nil, err
+		}
+	}
+
+	return &storagePlacement{
+		ruleList:           downloadConfig.Placement.Rules,
+		defaultStorageName: storageSet.GetDefaultStorageName(),
+	}, nil
+}
+
+// GetStorageName implements StoragePlacement. Rules with a file size or content type condition do not match
+// the files whose size or content type is unknown.
+func (s storagePlacement) GetStorageName(downloadTask database.DownloadTask, downloadProbe DownloadProbe) string {
+	contentType, _, err := mime.ParseMediaType(downloadProbe.ContentType)
+	if err != nil {
+		contentType = ""
+	}
+
+	for _, rule := range s.ruleList {
+		if len(rule.AccountIDs) > 0 && !slices.Contains(rule.AccountIDs, downloadTask.OfAccountID) {
+			continue
+		}
+
+		if rule.MinFileSize > 0 && (downloadProbe.FileSize < 0 || uint64(downloadProbe.FileSize) < rule.MinFileSize) {
+			continue
+		}
+
+		if len(rule.ContentTypes) > 0 && !matchContentType(rule.ContentTypes, contentType) {
+			continue
+		}
+
+		return rule.Storage
+	}
+
+	return s.defaultStorageName
+}
+
+// matchContentType returns whether contentType is one of contentTypePatternList, whose elements may end with
+// "/*" to match a whole type.
+func matchContentType(contentTypePatternList []string, contentType string) bool {
+	if contentType == "" {
+		return false
+	}
+
+	for _, contentTypePattern := range contentTypePatternList {
+		contentTypePattern = strings.ToLower(contentTypePattern)
+		if prefix, ok := strings.CutSuffix(contentTypePattern, "/*"); ok {
+			if strings.HasPrefix(contentType, prefix+"/") {
+				return true
+			}
+
+			continue
+		}
+
+		if contentTypePattern == contentType {
+			return true
+		}
+	}
+
+	return false
+}
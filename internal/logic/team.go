@@ -0,0 +1,293 @@
+package logic
+
+import (
+	"context"
+	"errors"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/database"
+	go_idm_v1 "github.com/manhhung2111/go-idm/internal/generated/proto"
+	"github.com/manhhung2111/go-idm/internal/utils"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type CreateTeamParams struct {
+	Token    string
+	TeamName string
+}
+
+type CreateTeamOutput struct {
+	Team *go_idm_v1.Team
+}
+
+type GetTeamListParams struct {
+	Token string
+}
+
+type GetTeamListOutput struct {
+	TeamList []*go_idm_v1.Team
+}
+
+type AddTeamMemberParams struct {
+	Token     string
+	TeamID    uint64
+	AccountID uint64
+	TeamRole  go_idm_v1.TeamRole
+}
+
+type RemoveTeamMemberParams struct {
+	Token     string
+	TeamID    uint64
+	AccountID uint64
+}
+
+type Team interface {
+	CreateTeam(ctx context.Context, params CreateTeamParams) (CreateTeamOutput, error)
+	GetTeamList(ctx context.Context, params GetTeamListParams) (GetTeamListOutput, error)
+	AddTeamMember(ctx context.Context, params AddTeamMemberParams) error
+	RemoveTeamMember(ctx context.Context, params RemoveTeamMemberParams) error
+}
+
+type team struct {
+	tokenLogic             Token
+	accountDataAccessor    database.AccountDataAccessor
+	teamDataAccessor       database.TeamDataAccessor
+	teamMemberDataAccessor database.TeamMemberDataAccessor
+	goquDatabase           *goqu.Database
+	logger                 *zap.Logger
+}
+
+func NewTeam(
+	tokenLogic Token,
+	accountDataAccessor database.AccountDataAccessor,
+	teamDataAccessor database.TeamDataAccessor,
+	teamMemberDataAccessor database.TeamMemberDataAccessor,
+	goquDatabase *goqu.Database,
+	logger *zap.Logger,
+) Team {
+	return &team{
+		tokenLogic:             tokenLogic,
+		accountDataAccessor:    accountDataAccessor,
+		teamDataAccessor:       teamDataAccessor,
+		teamMemberDataAccessor: teamMemberDataAccessor,
+		goquDatabase:           goquDatabase,
+		logger:                 logger,
+	}
+}
+
+// CreateTeam implements Team.
+func (t *team) CreateTeam(ctx context.Context, params CreateTeamParams) (CreateTeamOutput, error) {
+	accountId, _, err := t.tokenLogic.GetAccountIDAndExpireTime(ctx, params.Token)
+	if err != nil {
+		return CreateTeamOutput{}, err
+	}
+
+	if params.TeamName == "" {
+		return CreateTeamOutput{}, status.Error(codes.InvalidArgument, "team name must not be empty")
+	}
+
+	databaseTeam := database.Team{
+		TeamName: params.TeamName,
+	}
+
+	txErr := t.goquDatabase.WithTx(func(td *goqu.TxDatabase) error {
+		teamId, err := t.teamDataAccessor.WithDatabase(td).CreateTeam(ctx, databaseTeam)
+		if err != nil {
+			return err
+		}
+
+		databaseTeam.ID = teamId
+		return t.teamMemberDataAccessor.WithDatabase(td).UpsertTeamMember(ctx, database.TeamMember{
+			OfTeamID:    teamId,
+			OfAccountID: accountId,
+			TeamRole:    go_idm_v1.TeamRole_Owner,
+		})
+	})
+	if txErr != nil {
+		return CreateTeamOutput{}, txErr
+	}
+
+	return CreateTeamOutput{
+		Team: t.databaseTeamToProtoTeam(databaseTeam, go_idm_v1.TeamRole_Owner),
+	}, nil
+}
+
+// GetTeamList implements Team.
+func (t *team) GetTeamList(ctx context.Context, params GetTeamListParams) (GetTeamListOutput, error) {
+	accountId, _, err := t.tokenLogic.GetAccountIDAndExpireTime(ctx, params.Token)
+	if err != nil {
+		return GetTeamListOutput{}, err
+	}
+
+	teamMemberList, err := t.teamMemberDataAccessor.GetTeamMemberListOfAccount(ctx, accountId)
+	if err != nil {
+		return GetTeamListOutput{}, err
+	}
+
+	teamList, err := t.teamDataAccessor.GetTeamList(ctx, lo.Map(teamMemberList, func(item database.TeamMember, _ int) uint64 {
+		return item.OfTeamID
+	}))
+	if err != nil {
+		return GetTeamListOutput{}, err
+	}
+
+	teamIdToTeamRoleMap := lo.SliceToMap(teamMemberList, func(item database.TeamMember) (uint64, go_idm_v1.TeamRole) {
+		return item.OfTeamID, item.TeamRole
+	})
+
+	return GetTeamListOutput{
+		TeamList: lo.Map(teamList, func(item database.Team, _ int) *go_idm_v1.Team {
+			return t.databaseTeamToProtoTeam(item, teamIdToTeamRoleMap[item.ID])
+		}),
+	}, nil
+}
+
+// AddTeamMember implements Team.
+func (t *team) AddTeamMember(ctx context.Context, params AddTeamMemberParams) error {
+	accountId, _, err := t.tokenLogic.GetAccountIDAndExpireTime(ctx, params.Token)
+	if err != nil {
+		return err
+	}
+
+	if params.TeamRole == go_idm_v1.TeamRole_UndefinedTeamRole {
+		return status.Error(codes.InvalidArgument, "team role must be specified")
+	}
+
+	if _, err = t.accountDataAccessor.GetAccountById(ctx, params.AccountID); err != nil {
+		return err
+	}
+
+	return t.goquDatabase.WithTx(func(td *goqu.TxDatabase) error {
+		teamMemberDataAccessor := t.teamMemberDataAccessor.WithDatabase(td)
+
+		requester, err := t.getTeamMember(ctx, teamMemberDataAccessor, params.TeamID, accountId)
+		if err != nil {
+			return err
+		}
+
+		if !isTeamRoleAllowedToManageMembers(requester.TeamRole) {
+			return status.Error(codes.PermissionDenied, "trying to add a member to a team the account does not manage")
+		}
+
+		if params.TeamRole == go_idm_v1.TeamRole_Owner && requester.TeamRole != go_idm_v1.TeamRole_Owner {
+			return status.Error(codes.PermissionDenied, "only team owners can add other owners")
+		}
+
+		member, err := teamMemberDataAccessor.GetTeamMember(ctx, params.TeamID, params.AccountID)
+		if err != nil && !errors.Is(err, database.ErrTeamMemberNotFound) {
+			return err
+		}
+
+		if err == nil && member.TeamRole == go_idm_v1.TeamRole_Owner && params.TeamRole != go_idm_v1.TeamRole_Owner {
+			if requester.TeamRole != go_idm_v1.TeamRole_Owner {
+				return status.Error(codes.PermissionDenied, "only team owners can change the role of other owners")
+			}
+
+			if err = t.checkTeamKeepsOwner(ctx, teamMemberDataAccessor, params.TeamID); err != nil {
+				return err
+			}
+		}
+
+		return teamMemberDataAccessor.UpsertTeamMember(ctx, database.TeamMember{
+			OfTeamID:    params.TeamID,
+			OfAccountID: params.AccountID,
+			TeamRole:    params.TeamRole,
+		})
+	})
+}
+
+// RemoveTeamMember implements Team.
+func (t *team) RemoveTeamMember(ctx context.Context, params RemoveTeamMemberParams) error {
+	accountId, _, err := t.tokenLogic.GetAccountIDAndExpireTime(ctx, params.Token)
+	if err != nil {
+		return err
+	}
+
+	return t.goquDatabase.WithTx(func(td *goqu.TxDatabase) error {
+		teamMemberDataAccessor := t.teamMemberDataAccessor.WithDatabase(td)
+
+		requester, err := t.getTeamMember(ctx, teamMemberDataAccessor, params.TeamID, accountId)
+		if err != nil {
+			return err
+		}
+
+		// Any member is allowed to leave a team on their own.
+		if params.AccountID != accountId && !isTeamRoleAllowedToManageMembers(requester.TeamRole) {
+			return status.Error(codes.PermissionDenied, "trying to remove a member from a team the account does not manage")
+		}
+
+		member, err := t.getTeamMember(ctx, teamMemberDataAccessor, params.TeamID, params.AccountID)
+		if err != nil {
+			return err
+		}
+
+		if member.TeamRole == go_idm_v1.TeamRole_Owner {
+			if requester.TeamRole != go_idm_v1.TeamRole_Owner {
+				return status.Error(codes.PermissionDenied, "only team owners can remove other owners")
+			}
+
+			if err = t.checkTeamKeepsOwner(ctx, teamMemberDataAccessor, params.TeamID); err != nil {
+				return err
+			}
+		}
+
+		return teamMemberDataAccessor.DeleteTeamMember(ctx, params.TeamID, params.AccountID)
+	})
+}
+
+// checkTeamKeepsOwner fails if a team has a single owner, before an owner stops being one, so that a team is
+// never left without an owner. The owners are locked, so that two owners can not both stop being one at once.
+func (t *team) checkTeamKeepsOwner(
+	ctx context.Context,
+	teamMemberDataAccessor database.TeamMemberDataAccessor,
+	teamId uint64,
+) error {
+	ownerList, err := teamMemberDataAccessor.GetTeamMemberListOfTeamRoleWithXLock(ctx, teamId, go_idm_v1.TeamRole_Owner)
+	if err != nil {
+		return err
+	}
+
+	if len(ownerList) <= 1 {
+		return status.Error(codes.FailedPrecondition, "a team must keep at least one owner")
+	}
+
+	return nil
+}
+
+func (t *team) getTeamMember(
+	ctx context.Context,
+	teamMemberDataAccessor database.TeamMemberDataAccessor,
+	teamId uint64,
+	accountId uint64,
+) (database.TeamMember, error) {
+	logger := utils.LoggerWithContext(ctx, t.logger).
+		With(zap.Uint64("team_id", teamId)).
+		With(zap.Uint64("account_id", accountId))
+
+	teamMember, err := teamMemberDataAccessor.GetTeamMember(ctx, teamId, accountId)
+	if err != nil {
+		if errors.Is(err, database.ErrTeamMemberNotFound) {
+			logger.Warn("account is not a member of team")
+			return database.TeamMember{}, status.Error(codes.PermissionDenied, "account is not a member of team")
+		}
+
+		return database.TeamMember{}, err
+	}
+
+	return teamMember, nil
+}
+
+func (t team) databaseTeamToProtoTeam(databaseTeam database.Team, teamRole go_idm_v1.TeamRole) *go_idm_v1.Team {
+	return &go_idm_v1.Team{
+		Id:       databaseTeam.ID,
+		TeamName: databaseTeam.TeamName,
+		TeamRole: teamRole,
+	}
+}
+
+func isTeamRoleAllowedToManageMembers(teamRole go_idm_v1.TeamRole) bool {
+	return teamRole == go_idm_v1.TeamRole_Owner || teamRole == go_idm_v1.TeamRole_Admin
+}
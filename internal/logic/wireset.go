@@ -6,4 +6,17 @@ var WireSet = wire.NewSet(
 	NewAccount,
 	NewToken,
 	NewDownloadTask,
+	NewTeam,
+	NewDownloadTaskPermission,
+	NewShareLink,
+	NewConnectionLimiter,
+	NewDownloadTaskReaper,
+	NewDownloadTaskPurger,
+	NewDownloadFileReconciler,
+	NewDownloadFileCheck,
+	NewStorageMigration,
+	NewStoragePlacement,
+	NewDownloadFileTiering,
+	NewMasterKeyRotation,
+	NewAuditEvent,
 )
\ No newline at end of file
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+type LimiterOccupancy struct {
+	Active   uint64 `json:"active"`
+	Waiting  uint64 `json:"waiting"`
+	Capacity uint64 `json:"capacity"`
+}
+
+// Limiter bounds the number of holders of a resource. Acquire blocks while all the capacity is held, which
+// is how the callers apply backpressure.
+type Limiter interface {
+	Acquire(ctx context.Context) error
+	Release()
+	GetOccupancy() LimiterOccupancy
+}
+
+type limiter struct {
+	slotChannel chan struct{}
+	waiting     *atomic.Int64
+}
+
+func NewLimiter(capacity uint64) Limiter {
+	return &limiter{
+		slotChannel: make(chan struct{}, capacity),
+		waiting:     new(atomic.Int64),
+	}
+}
+
+// Acquire implements Limiter.
+func (l *limiter) Acquire(ctx context.Context) error {
+	l.waiting.Add(1)
+	defer l.waiting.Add(-1)
+
+	select {
+	case l.slotChannel <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Release implements Limiter.
+func (l *limiter) Release() {
+	<-l.slotChannel
+}
+
+// GetOccupancy implements Limiter.
+func (l *limiter) GetOccupancy() LimiterOccupancy {
+	return LimiterOccupancy{
+		Active:   uint64(len(l.slotChannel)),
+		Waiting:  uint64(l.waiting.Load()),
+		Capacity: uint64(cap(l.slotChannel)),
+	}
+}
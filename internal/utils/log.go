@@ -41,6 +41,10 @@ func InitializeLogger(logConfig config.Log) (*zap.Logger, func(), error) {
 	return logger, cleanup, err
 }
 
-func LoggerWithContext(_ context.Context, logger *zap.Logger) *zap.Logger {
+func LoggerWithContext(ctx context.Context, logger *zap.Logger) *zap.Logger {
+	if requestId := RequestInfoFromContext(ctx).RequestID; requestId != "" {
+		return logger.With(zap.String("request_id", requestId))
+	}
+
 	return logger
 }
@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// RequestInfo describes the request a context was created for: RequestID identifies it in the logs and the
+// audit trail, and IPAddress is the address of the client that sent it.
+type RequestInfo struct {
+	RequestID string
+	IPAddress string
+}
+
+type requestInfoContextKey struct{}
+
+func WithRequestInfo(ctx context.Context, requestInfo RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoContextKey{}, requestInfo)
+}
+
+// RequestInfoFromContext returns the info of the request of a context, empty if the context was not created
+// for a request, as is the case of the background work.
+func RequestInfoFromContext(ctx context.Context) RequestInfo {
+	requestInfo, _ := ctx.Value(requestInfoContextKey{}).(RequestInfo)
+	return requestInfo
+}
+
+// GatewaySecretMetadataKey is the metadata key the HTTP gateway sends GatewaySecret in, for the gRPC server to
+// tell its RPCs apart from the ones of other clients.
+const GatewaySecretMetadataKey = "x-go-idm-gateway-secret"
+
+var gatewaySecret = uuid.NewString()
+
+// GatewaySecret returns the secret shared by the HTTP gateway and the gRPC server of a process. It is
+// generated at startup and never leaves the process, so only the gateway can prove the headers it forwards,
+// such as the address of the HTTP client, are not forged.
+func GatewaySecret() string {
+	return gatewaySecret
+}
@@ -10,6 +10,10 @@ import (
 	"github.com/google/wire"
 	"github.com/manhhung2111/go-idm/internal/config"
 	"github.com/manhhung2111/go-idm/internal/dataaccess"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/database"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/kafka/consumer"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/kafka/inprocess"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/kafka/producer"
 	"github.com/manhhung2111/go-idm/internal/handler"
 	"github.com/manhhung2111/go-idm/internal/logic"
 	"github.com/manhhung2111/go-idm/internal/utils"
@@ -29,4 +33,72 @@ func InitializeServer(configFilePath config.ConfigFilePath) (*app.Server, func()
 	wire.Build(WireSet)
 
 	return nil, nil, nil
-}
\ No newline at end of file
+}
+
+func InitializeAPIServer(configFilePath config.ConfigFilePath) (*app.APIServer, func(), error) {
+	wire.Build(WireSet)
+
+	return nil, nil, nil
+}
+
+func InitializeWorker(configFilePath config.ConfigFilePath) (*app.Worker, func(), error) {
+	wire.Build(WireSet)
+
+	return nil, nil, nil
+}
+
+func InitializeMigrator(configFilePath config.ConfigFilePath) (database.Migrator, func(), error) {
+	wire.Build(
+		config.WireSet,
+		utils.WireSet,
+		database.InitializeDB,
+		database.NewMigrator,
+	)
+
+	return nil, nil, nil
+}
+
+func InitializeDownloadFileCheck(configFilePath config.ConfigFilePath) (logic.DownloadFileCheck, func(), error) {
+	wire.Build(
+		config.WireSet,
+		dataaccess.WireSet,
+		logic.WireSet,
+		utils.WireSet,
+	)
+
+	return nil, nil, nil
+}
+
+func InitializeStorageMigration(configFilePath config.ConfigFilePath) (logic.StorageMigration, func(), error) {
+	wire.Build(
+		config.WireSet,
+		dataaccess.WireSet,
+		logic.WireSet,
+		utils.WireSet,
+	)
+
+	return nil, nil, nil
+}
+
+func InitializeMasterKeyRotation(configFilePath config.ConfigFilePath) (logic.MasterKeyRotation, func(), error) {
+	wire.Build(
+		config.WireSet,
+		dataaccess.WireSet,
+		logic.WireSet,
+		utils.WireSet,
+	)
+
+	return nil, nil, nil
+}
+
+func InitializeDeadLetterQueue(configFilePath config.ConfigFilePath) (consumer.DeadLetterQueue, func(), error) {
+	wire.Build(
+		config.WireSet,
+		utils.WireSet,
+		inprocess.NewBroker,
+		producer.NewClient,
+		consumer.NewDeadLetterQueue,
+	)
+
+	return nil, nil, nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/manhhung2111/go-idm/internal/dataaccess/database"
 	"github.com/manhhung2111/go-idm/internal/dataaccess/file"
 	"github.com/manhhung2111/go-idm/internal/dataaccess/kafka/consumer"
+	"github.com/manhhung2111/go-idm/internal/dataaccess/kafka/inprocess"
 	"github.com/manhhung2111/go-idm/internal/dataaccess/kafka/producer"
 	"github.com/manhhung2111/go-idm/internal/handler"
 	"github.com/manhhung2111/go-idm/internal/handler/consumer"
@@ -36,64 +37,646 @@ func InitializeServer(configFilePath config.ConfigFilePath) (*app.Server, func()
 	if err != nil {
 		return nil, nil, err
 	}
-	goquDatabase := database.InitializeGoquDB(db)
+	goquDatabase := database.InitializeGoquDB(db, configDatabase)
+	readReplicaDatabase, cleanup2, err := database.InitializeReadReplicaDB(configDatabase)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
 	log := configConfig.Log
-	logger, cleanup2, err := utils.InitializeLogger(log)
+	logger, cleanup3, err := utils.InitializeLogger(log)
 	if err != nil {
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
-	accountDataAccessor := database.NewAccountDataAccessor(goquDatabase, logger)
+	accountDataAccessor := database.NewAccountDataAccessor(goquDatabase, readReplicaDatabase, logger)
 	accountPasswordDataAccessor := database.NewAccountPasswordDataAccessor(goquDatabase, logger)
+	auditEventDataAccessor := database.NewAuditEventDataAccessor(goquDatabase, readReplicaDatabase, logger)
 	auth := configConfig.Auth
 	hash := logic.NewHash(auth)
 	token, err := logic.NewToken(accountDataAccessor, auth, logger)
 	if err != nil {
+		cleanup3()
 		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
 	configCache := configConfig.Cache
-	cacheClient := cache.NewRedisClient(configCache, logger)
+	cacheClient, cleanup4, err := cache.NewCacheClient(configCache, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
 	accountNameCache := cache.NewAccountNameCache(cacheClient, logger)
-	account := logic.NewAccount(goquDatabase, accountDataAccessor, accountPasswordDataAccessor, hash, token, accountNameCache, logger)
-	downloadTaskDataAccessor := database.NewDownloadTaskDataAccessor(goquDatabase, logger)
+	account := logic.NewAccount(goquDatabase, accountDataAccessor, accountPasswordDataAccessor, auditEventDataAccessor, hash, token, accountNameCache, logger)
+	downloadTaskDataAccessor := database.NewDownloadTaskDataAccessor(goquDatabase, readReplicaDatabase, logger)
+	downloadTaskShareDataAccessor := database.NewDownloadTaskShareDataAccessor(goquDatabase, logger)
+	teamMemberDataAccessor := database.NewTeamMemberDataAccessor(goquDatabase, logger)
+	downloadTaskPermission := logic.NewDownloadTaskPermission(teamMemberDataAccessor, downloadTaskShareDataAccessor)
+	outboxMessageDataAccessor := database.NewOutboxMessageDataAccessor(goquDatabase, logger)
+	downloadTaskCreatedProducer := producer.NewDownloadTaskCreatedProducer(outboxMessageDataAccessor, logger)
+	downloadTaskEventProducer := producer.NewDownloadTaskEventProducer(outboxMessageDataAccessor, logger)
+	download := configConfig.Download
+	connectionLimiter := logic.NewConnectionLimiter(download)
+	storageSet, err := file.NewStorageSet(download, logger)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	storagePlacement, err := logic.NewStoragePlacement(storageSet, download)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	downloadTask, err := logic.NewDownloadTask(token, accountDataAccessor, downloadTaskDataAccessor, downloadTaskShareDataAccessor, teamMemberDataAccessor, auditEventDataAccessor, downloadTaskPermission, goquDatabase, logger, downloadTaskCreatedProducer, downloadTaskEventProducer, connectionLimiter, storageSet, storagePlacement, download)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	teamDataAccessor := database.NewTeamDataAccessor(goquDatabase, logger)
+	team := logic.NewTeam(token, accountDataAccessor, teamDataAccessor, teamMemberDataAccessor, goquDatabase, logger)
+	shareLinkDataAccessor := database.NewShareLinkDataAccessor(goquDatabase, logger)
+	shareLinkPasswordFailureCache := cache.NewShareLinkPasswordFailureCache(cacheClient, logger)
+	configHTTP := configConfig.HTTP
+	shareLink, err := logic.NewShareLink(token, hash, downloadTaskPermission, downloadTaskDataAccessor, shareLinkDataAccessor, shareLinkPasswordFailureCache, goquDatabase, storageSet, configHTTP, logger)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	auditEvent := logic.NewAuditEvent(token, accountDataAccessor, auditEventDataAccessor, auth, logger)
+	downloadFileCheck, err := logic.NewDownloadFileCheck(token, accountDataAccessor, downloadTaskDataAccessor, auditEventDataAccessor, downloadTaskCreatedProducer, downloadTaskEventProducer, goquDatabase, storageSet, download, auth, logger)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	configGRPC := configConfig.GRPC
+	goIDMServiceServer, err := grpc.NewHandler(account, downloadTask, team, shareLink, auditEvent, downloadFileCheck, configGRPC)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	server := grpc.NewServer(goIDMServiceServer, configGRPC, logger)
+	httpServer := http.NewServer(configGRPC, configHTTP, shareLink, logger)
+	downloadTaskCreateHandler := handler_consumer.NewDownloadTaskCreatedHandler(downloadTask, logger)
+	queue := configConfig.Queue
 	kafka := configConfig.Kafka
-	client, err := producer.NewClient(kafka, logger)
+	broker := inprocess.NewBroker(queue)
+	client, cleanup5, err := producer.NewClient(queue, kafka, broker, logger)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	workerPool := consumer.NewWorkerPool(download)
+	consumerConsumer, err := consumer.NewConsumer(queue, kafka, broker, client, workerPool, logger)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	root := handler_consumer.NewRoot(downloadTaskCreateHandler, consumerConsumer, logger)
+	monitoringServer := http.NewMonitoringServer(configHTTP, workerPool, connectionLimiter, logger)
+	outboxRelay, err := producer.NewOutboxRelay(db, configDatabase, client, outboxMessageDataAccessor, goquDatabase, kafka, logger)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	downloadTaskReaper, err := logic.NewDownloadTaskReaper(downloadTaskDataAccessor, downloadTaskCreatedProducer, downloadTaskEventProducer, goquDatabase, download, logger)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	downloadTaskPurger, err := logic.NewDownloadTaskPurger(downloadTaskDataAccessor, auditEventDataAccessor, goquDatabase, storageSet, download, logger)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	downloadFileReconciler, err := logic.NewDownloadFileReconciler(downloadTaskDataAccessor, storageSet, download, logger)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	storageMigration := logic.NewStorageMigration(downloadTaskDataAccessor, goquDatabase, storageSet, logger)
+	downloadFileTiering, err := logic.NewDownloadFileTiering(storageMigration, storageSet, download, logger)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	migrator, err := database.NewMigrator(db, configDatabase, logger)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	shutdown := configConfig.Shutdown
+	appServer := app.NewServer(server, httpServer, root, monitoringServer, outboxRelay, downloadTaskReaper, downloadTaskPurger, downloadFileReconciler, downloadFileTiering, migrator, configDatabase, shutdown, logger)
+	return appServer, func() {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+	}, nil
+}
+
+func InitializeAPIServer(configFilePath config.ConfigFilePath) (*app.APIServer, func(), error) {
+	configConfig, err := config.NewConfig(configFilePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	configDatabase := configConfig.Database
+	db, cleanup, err := database.InitializeDB(configDatabase)
+	if err != nil {
+		return nil, nil, err
+	}
+	goquDatabase := database.InitializeGoquDB(db, configDatabase)
+	readReplicaDatabase, cleanup2, err := database.InitializeReadReplicaDB(configDatabase)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	log := configConfig.Log
+	logger, cleanup3, err := utils.InitializeLogger(log)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	accountDataAccessor := database.NewAccountDataAccessor(goquDatabase, readReplicaDatabase, logger)
+	accountPasswordDataAccessor := database.NewAccountPasswordDataAccessor(goquDatabase, logger)
+	auditEventDataAccessor := database.NewAuditEventDataAccessor(goquDatabase, readReplicaDatabase, logger)
+	auth := configConfig.Auth
+	hash := logic.NewHash(auth)
+	token, err := logic.NewToken(accountDataAccessor, auth, logger)
 	if err != nil {
+		cleanup3()
 		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
-	downloadTaskCreatedProducer := producer.NewDownloadTaskCreatedProducer(client, logger)
+	configCache := configConfig.Cache
+	cacheClient, cleanup4, err := cache.NewCacheClient(configCache, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	accountNameCache := cache.NewAccountNameCache(cacheClient, logger)
+	account := logic.NewAccount(goquDatabase, accountDataAccessor, accountPasswordDataAccessor, auditEventDataAccessor, hash, token, accountNameCache, logger)
+	downloadTaskDataAccessor := database.NewDownloadTaskDataAccessor(goquDatabase, readReplicaDatabase, logger)
+	downloadTaskShareDataAccessor := database.NewDownloadTaskShareDataAccessor(goquDatabase, logger)
+	teamMemberDataAccessor := database.NewTeamMemberDataAccessor(goquDatabase, logger)
+	downloadTaskPermission := logic.NewDownloadTaskPermission(teamMemberDataAccessor, downloadTaskShareDataAccessor)
+	outboxMessageDataAccessor := database.NewOutboxMessageDataAccessor(goquDatabase, logger)
+	downloadTaskCreatedProducer := producer.NewDownloadTaskCreatedProducer(outboxMessageDataAccessor, logger)
+	downloadTaskEventProducer := producer.NewDownloadTaskEventProducer(outboxMessageDataAccessor, logger)
 	download := configConfig.Download
-	fileClient, err := file.NewClient(download, logger)
+	connectionLimiter := logic.NewConnectionLimiter(download)
+	storageSet, err := file.NewStorageSet(download, logger)
 	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	storagePlacement, err := logic.NewStoragePlacement(storageSet, download)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	downloadTask, err := logic.NewDownloadTask(token, accountDataAccessor, downloadTaskDataAccessor, downloadTaskShareDataAccessor, teamMemberDataAccessor, auditEventDataAccessor, downloadTaskPermission, goquDatabase, logger, downloadTaskCreatedProducer, downloadTaskEventProducer, connectionLimiter, storageSet, storagePlacement, download)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	teamDataAccessor := database.NewTeamDataAccessor(goquDatabase, logger)
+	team := logic.NewTeam(token, accountDataAccessor, teamDataAccessor, teamMemberDataAccessor, goquDatabase, logger)
+	shareLinkDataAccessor := database.NewShareLinkDataAccessor(goquDatabase, logger)
+	shareLinkPasswordFailureCache := cache.NewShareLinkPasswordFailureCache(cacheClient, logger)
+	configHTTP := configConfig.HTTP
+	shareLink, err := logic.NewShareLink(token, hash, downloadTaskPermission, downloadTaskDataAccessor, shareLinkDataAccessor, shareLinkPasswordFailureCache, goquDatabase, storageSet, configHTTP, logger)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	auditEvent := logic.NewAuditEvent(token, accountDataAccessor, auditEventDataAccessor, auth, logger)
+	downloadFileCheck, err := logic.NewDownloadFileCheck(token, accountDataAccessor, downloadTaskDataAccessor, auditEventDataAccessor, downloadTaskCreatedProducer, downloadTaskEventProducer, goquDatabase, storageSet, download, auth, logger)
+	if err != nil {
+		cleanup4()
+		cleanup3()
 		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
-	downloadTask := logic.NewDownloadTask(token, accountDataAccessor, downloadTaskDataAccessor, goquDatabase, logger, downloadTaskCreatedProducer, fileClient)
 	configGRPC := configConfig.GRPC
-	goIDMServiceServer, err := grpc.NewHandler(account, downloadTask, configGRPC)
+	goIDMServiceServer, err := grpc.NewHandler(account, downloadTask, team, shareLink, auditEvent, downloadFileCheck, configGRPC)
 	if err != nil {
+		cleanup4()
+		cleanup3()
 		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
 	server := grpc.NewServer(goIDMServiceServer, configGRPC, logger)
-	configHTTP := configConfig.HTTP
-	httpServer := http.NewServer(configGRPC, configHTTP, logger)
+	httpServer := http.NewServer(configGRPC, configHTTP, shareLink, logger)
+	migrator, err := database.NewMigrator(db, configDatabase, logger)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	shutdown := configConfig.Shutdown
+	apiServer := app.NewAPIServer(server, httpServer, migrator, configDatabase, shutdown, logger)
+	return apiServer, func() {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+	}, nil
+}
+
+func InitializeWorker(configFilePath config.ConfigFilePath) (*app.Worker, func(), error) {
+	configConfig, err := config.NewConfig(configFilePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	configDatabase := configConfig.Database
+	db, cleanup, err := database.InitializeDB(configDatabase)
+	if err != nil {
+		return nil, nil, err
+	}
+	goquDatabase := database.InitializeGoquDB(db, configDatabase)
+	readReplicaDatabase, cleanup2, err := database.InitializeReadReplicaDB(configDatabase)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	log := configConfig.Log
+	logger, cleanup3, err := utils.InitializeLogger(log)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	accountDataAccessor := database.NewAccountDataAccessor(goquDatabase, readReplicaDatabase, logger)
+	auth := configConfig.Auth
+	token, err := logic.NewToken(accountDataAccessor, auth, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	downloadTaskDataAccessor := database.NewDownloadTaskDataAccessor(goquDatabase, readReplicaDatabase, logger)
+	downloadTaskShareDataAccessor := database.NewDownloadTaskShareDataAccessor(goquDatabase, logger)
+	teamMemberDataAccessor := database.NewTeamMemberDataAccessor(goquDatabase, logger)
+	auditEventDataAccessor := database.NewAuditEventDataAccessor(goquDatabase, readReplicaDatabase, logger)
+	downloadTaskPermission := logic.NewDownloadTaskPermission(teamMemberDataAccessor, downloadTaskShareDataAccessor)
+	outboxMessageDataAccessor := database.NewOutboxMessageDataAccessor(goquDatabase, logger)
+	downloadTaskCreatedProducer := producer.NewDownloadTaskCreatedProducer(outboxMessageDataAccessor, logger)
+	downloadTaskEventProducer := producer.NewDownloadTaskEventProducer(outboxMessageDataAccessor, logger)
+	download := configConfig.Download
+	connectionLimiter := logic.NewConnectionLimiter(download)
+	storageSet, err := file.NewStorageSet(download, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	storagePlacement, err := logic.NewStoragePlacement(storageSet, download)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	downloadTask, err := logic.NewDownloadTask(token, accountDataAccessor, downloadTaskDataAccessor, downloadTaskShareDataAccessor, teamMemberDataAccessor, auditEventDataAccessor, downloadTaskPermission, goquDatabase, logger, downloadTaskCreatedProducer, downloadTaskEventProducer, connectionLimiter, storageSet, storagePlacement, download)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
 	downloadTaskCreateHandler := handler_consumer.NewDownloadTaskCreatedHandler(downloadTask, logger)
-	consumerConsumer, err := consumer.NewConsumer(kafka, logger)
+	queue := configConfig.Queue
+	kafka := configConfig.Kafka
+	broker := inprocess.NewBroker(queue)
+	client, cleanup4, err := producer.NewClient(queue, kafka, broker, logger)
 	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	workerPool := consumer.NewWorkerPool(download)
+	consumerConsumer, err := consumer.NewConsumer(queue, kafka, broker, client, workerPool, logger)
+	if err != nil {
+		cleanup4()
+		cleanup3()
 		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
 	root := handler_consumer.NewRoot(downloadTaskCreateHandler, consumerConsumer, logger)
-	appServer := app.NewServer(server, httpServer, root, logger)
-	return appServer, func() {
+	configHTTP := configConfig.HTTP
+	monitoringServer := http.NewMonitoringServer(configHTTP, workerPool, connectionLimiter, logger)
+	outboxRelay, err := producer.NewOutboxRelay(db, configDatabase, client, outboxMessageDataAccessor, goquDatabase, kafka, logger)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	downloadTaskReaper, err := logic.NewDownloadTaskReaper(downloadTaskDataAccessor, downloadTaskCreatedProducer, downloadTaskEventProducer, goquDatabase, download, logger)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	downloadTaskPurger, err := logic.NewDownloadTaskPurger(downloadTaskDataAccessor, auditEventDataAccessor, goquDatabase, storageSet, download, logger)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	downloadFileReconciler, err := logic.NewDownloadFileReconciler(downloadTaskDataAccessor, storageSet, download, logger)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	storageMigration := logic.NewStorageMigration(downloadTaskDataAccessor, goquDatabase, storageSet, logger)
+	downloadFileTiering, err := logic.NewDownloadFileTiering(storageMigration, storageSet, download, logger)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	migrator, err := database.NewMigrator(db, configDatabase, logger)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	shutdown := configConfig.Shutdown
+	worker := app.NewWorker(root, monitoringServer, outboxRelay, downloadTaskReaper, downloadTaskPurger, downloadFileReconciler, downloadFileTiering, migrator, configDatabase, shutdown, logger)
+	return worker, func() {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+	}, nil
+}
+
+func InitializeMigrator(configFilePath config.ConfigFilePath) (database.Migrator, func(), error) {
+	configConfig, err := config.NewConfig(configFilePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	configDatabase := configConfig.Database
+	db, cleanup, err := database.InitializeDB(configDatabase)
+	if err != nil {
+		return nil, nil, err
+	}
+	log := configConfig.Log
+	logger, cleanup2, err := utils.InitializeLogger(log)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	migrator, err := database.NewMigrator(db, configDatabase, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	return migrator, func() {
+		cleanup2()
+		cleanup()
+	}, nil
+}
+
+func InitializeDownloadFileCheck(configFilePath config.ConfigFilePath) (logic.DownloadFileCheck, func(), error) {
+	configConfig, err := config.NewConfig(configFilePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	configDatabase := configConfig.Database
+	db, cleanup, err := database.InitializeDB(configDatabase)
+	if err != nil {
+		return nil, nil, err
+	}
+	goquDatabase := database.InitializeGoquDB(db, configDatabase)
+	readReplicaDatabase, cleanup2, err := database.InitializeReadReplicaDB(configDatabase)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	log := configConfig.Log
+	logger, cleanup3, err := utils.InitializeLogger(log)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	accountDataAccessor := database.NewAccountDataAccessor(goquDatabase, readReplicaDatabase, logger)
+	auth := configConfig.Auth
+	token, err := logic.NewToken(accountDataAccessor, auth, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	downloadTaskDataAccessor := database.NewDownloadTaskDataAccessor(goquDatabase, readReplicaDatabase, logger)
+	auditEventDataAccessor := database.NewAuditEventDataAccessor(goquDatabase, readReplicaDatabase, logger)
+	outboxMessageDataAccessor := database.NewOutboxMessageDataAccessor(goquDatabase, logger)
+	downloadTaskCreatedProducer := producer.NewDownloadTaskCreatedProducer(outboxMessageDataAccessor, logger)
+	downloadTaskEventProducer := producer.NewDownloadTaskEventProducer(outboxMessageDataAccessor, logger)
+	download := configConfig.Download
+	storageSet, err := file.NewStorageSet(download, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	downloadFileCheck, err := logic.NewDownloadFileCheck(token, accountDataAccessor, downloadTaskDataAccessor, auditEventDataAccessor, downloadTaskCreatedProducer, downloadTaskEventProducer, goquDatabase, storageSet, download, auth, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	return downloadFileCheck, func() {
+		cleanup3()
+		cleanup2()
+		cleanup()
+	}, nil
+}
+
+func InitializeStorageMigration(configFilePath config.ConfigFilePath) (logic.StorageMigration, func(), error) {
+	configConfig, err := config.NewConfig(configFilePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	configDatabase := configConfig.Database
+	db, cleanup, err := database.InitializeDB(configDatabase)
+	if err != nil {
+		return nil, nil, err
+	}
+	goquDatabase := database.InitializeGoquDB(db, configDatabase)
+	readReplicaDatabase, cleanup2, err := database.InitializeReadReplicaDB(configDatabase)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	log := configConfig.Log
+	logger, cleanup3, err := utils.InitializeLogger(log)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	downloadTaskDataAccessor := database.NewDownloadTaskDataAccessor(goquDatabase, readReplicaDatabase, logger)
+	download := configConfig.Download
+	storageSet, err := file.NewStorageSet(download, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	storageMigration := logic.NewStorageMigration(downloadTaskDataAccessor, goquDatabase, storageSet, logger)
+	return storageMigration, func() {
+		cleanup3()
+		cleanup2()
+		cleanup()
+	}, nil
+}
+
+func InitializeMasterKeyRotation(configFilePath config.ConfigFilePath) (logic.MasterKeyRotation, func(), error) {
+	configConfig, err := config.NewConfig(configFilePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	download := configConfig.Download
+	log := configConfig.Log
+	logger, cleanup, err := utils.InitializeLogger(log)
+	if err != nil {
+		return nil, nil, err
+	}
+	storageSet, err := file.NewStorageSet(download, logger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	masterKeyRotation := logic.NewMasterKeyRotation(storageSet, logger)
+	return masterKeyRotation, func() {
+		cleanup()
+	}, nil
+}
+
+func InitializeDeadLetterQueue(configFilePath config.ConfigFilePath) (consumer.DeadLetterQueue, func(), error) {
+	configConfig, err := config.NewConfig(configFilePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	queue := configConfig.Queue
+	kafka := configConfig.Kafka
+	broker := inprocess.NewBroker(queue)
+	log := configConfig.Log
+	logger, cleanup, err := utils.InitializeLogger(log)
+	if err != nil {
+		return nil, nil, err
+	}
+	client, cleanup2, err := producer.NewClient(queue, kafka, broker, logger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	deadLetterQueue, cleanup3, err := consumer.NewDeadLetterQueue(queue, kafka, client, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	return deadLetterQueue, func() {
+		cleanup3()
 		cleanup2()
 		cleanup()
 	}, nil